@@ -43,6 +43,10 @@ const (
 	MimetypeTextPlain         = "text/plain"
 )
 
+// BorSuccessionParam is the parameter of the Bor header content type carrying
+// the succession number of the producer, for the remote signers to record it.
+const BorSuccessionParam = "succession"
+
 // Wallet represents a software or hardware wallet that might contain one or more
 // accounts (derived from the same seed).
 type Wallet interface {
@@ -17,9 +17,11 @@
 package external
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
+	"mime"
 	"sync"
 
 	"github.com/ethereum/go-ethereum"
@@ -41,8 +43,8 @@ func (eb *ExternalBackend) Wallets() []accounts.Wallet {
 	return eb.signers
 }
 
-func NewExternalBackend(endpoint string) (*ExternalBackend, error) {
-	signer, err := NewExternalSigner(endpoint)
+func NewExternalBackend(endpoint string, options ...rpc.ClientOption) (*ExternalBackend, error) {
+	signer, err := NewExternalSigner(endpoint, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -70,8 +72,8 @@ type ExternalSigner struct {
 	cache    []accounts.Account
 }
 
-func NewExternalSigner(endpoint string) (*ExternalSigner, error) {
-	client, err := rpc.Dial(endpoint)
+func NewExternalSigner(endpoint string, options ...rpc.ClientOption) (*ExternalSigner, error) {
+	client, err := rpc.DialOptions(context.Background(), endpoint, options...)
 	if err != nil {
 		return nil, err
 	}
@@ -175,9 +177,10 @@ func (api *ExternalSigner) SignData(account accounts.Account, mimeType string, d
 		hexutil.Encode(data)); err != nil {
 		return nil, err
 	}
-	// If V is on 27/28-form, convert to 0/1 for Clique
-	if mimeType == accounts.MimetypeClique && (res[64] == 27 || res[64] == 28) {
-		res[64] -= 27 // Transform V from 27/28 to 0/1 for Clique use
+	// If V is on 27/28-form, convert to 0/1 for Clique and Bor
+	mediaType, _, _ := mime.ParseMediaType(mimeType)
+	if (mediaType == accounts.MimetypeClique || mediaType == accounts.MimetypeBor) && (res[64] == 27 || res[64] == 28) {
+		res[64] -= 27 // Transform V from 27/28 to 0/1 for Clique and Bor use
 	}
 
 	return res, nil
@@ -21,6 +21,8 @@ import (
 	"context"
 	"crypto/rand"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -28,6 +30,7 @@ import (
 	"io"
 	"math/big"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -40,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
@@ -126,6 +130,23 @@ var (
 		Name:  "rules",
 		Usage: "Path to the rule file to auto-authorize requests with",
 	}
+	borSealingFlag = &cli.BoolFlag{
+		Name: "bor-sealing",
+		Usage: "Only seal the Bor headers of the current height, rejecting any other request. " +
+			"The released blocks are recorded in the config directory, and a block conflicting with one already released is never signed.",
+	}
+	tlsCertFlag = &cli.StringFlag{
+		Name:  "http.tlscert",
+		Usage: "Certificate to serve the HTTP endpoint over TLS with",
+	}
+	tlsKeyFlag = &cli.StringFlag{
+		Name:  "http.tlskey",
+		Usage: "Key of the certificate of the HTTP endpoint",
+	}
+	tlsClientCAFlag = &cli.StringFlag{
+		Name:  "http.tlsclientca",
+		Usage: "Certificate authority of the clients of the HTTP endpoint, which must then authenticate with a certificate",
+	}
 	stdiouiFlag = &cli.BoolFlag{
 		Name: "stdio-ui",
 		Usage: "Use STDIN/STDOUT as a channel for an external UI. " +
@@ -281,6 +302,10 @@ func init() {
 		customDBFlag,
 		auditLogFlag,
 		ruleFlag,
+		borSealingFlag,
+		tlsCertFlag,
+		tlsKeyFlag,
+		tlsClientCAFlag,
 		stdiouiFlag,
 		testFlag,
 		advancedMode,
@@ -662,6 +687,52 @@ Make sure to backup keystore and passwords in a safe location.`,
 	return nil
 }
 
+// startHTTPSEndpoint starts the HTTP endpoint over TLS, requiring the clients to
+// authenticate with a certificate of the given authority if any.
+func startHTTPSEndpoint(endpoint string, handler http.Handler, certFile, keyFile, clientCAFile string) (*http.Server, net.Addr, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		ca, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, nil, fmt.Errorf("no certificate found in %s", clientCAFile)
+		}
+
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeouts := rpc.DefaultHTTPTimeouts
+	httpSrv := &http.Server{
+		Handler:           handler,
+		ReadTimeout:       timeouts.ReadTimeout,
+		ReadHeaderTimeout: timeouts.ReadHeaderTimeout,
+		WriteTimeout:      timeouts.WriteTimeout,
+		IdleTimeout:       timeouts.IdleTimeout,
+	}
+	go httpSrv.Serve(tls.NewListener(listener, tlsConfig))
+
+	return httpSrv, listener.Addr(), nil
+}
+
 // ipcEndpoint resolves an IPC endpoint based on a configured value, taking into
 // account the set data folders as well as the designated platform we're currently
 // running on.
@@ -770,6 +841,18 @@ func signer(c *cli.Context) error {
 		}
 	}
 
+	// The Bor sealing rules come last, rejecting anything but the Bor headers
+	if c.Bool(borSealingFlag.Name) {
+		store, err := protection.Open(filepath.Join(configDir, protection.FileName))
+		if err != nil {
+			utils.Fatalf("Could not open the sealed blocks: %v", err)
+		}
+
+		ui = rules.NewBorSealingUI(ui, store)
+
+		log.Info("Bor sealing rules configured", "file", filepath.Join(configDir, protection.FileName))
+	}
+
 	var (
 		chainId  = c.Int64(chainIdFlag.Name)
 		ksLoc    = c.String(keystoreFlag.Name)
@@ -830,12 +913,24 @@ func signer(c *cli.Context) error {
 
 		// start http server
 		httpEndpoint := net.JoinHostPort(c.String(utils.HTTPListenAddrFlag.Name), fmt.Sprintf("%d", port))
-		httpServer, addr, err := node.StartHTTPEndpoint(httpEndpoint, rpc.DefaultHTTPTimeouts, handler)
+		var (
+			httpServer *http.Server
+			addr       net.Addr
+			scheme     = "http"
+		)
+
+		if cert := c.String(tlsCertFlag.Name); cert != "" {
+			httpServer, addr, err = startHTTPSEndpoint(httpEndpoint, handler, cert, c.String(tlsKeyFlag.Name), c.String(tlsClientCAFlag.Name))
+			scheme = "https"
+		} else {
+			httpServer, addr, err = node.StartHTTPEndpoint(httpEndpoint, rpc.DefaultHTTPTimeouts, handler)
+		}
+
 		if err != nil {
 			utils.Fatalf("Could not start RPC api: %v", err)
 		}
 
-		extapiURL = fmt.Sprintf("http://%v/", addr)
+		extapiURL = fmt.Sprintf("%s://%v/", scheme, addr)
 		log.Info("HTTP endpoint opened", "url", extapiURL)
 
 		defer func() {
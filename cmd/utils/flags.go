@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"crypto/tls"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -97,7 +98,7 @@ var (
 	}
 	RemoteDBFlag = &cli.StringFlag{
 		Name:     "remotedb",
-		Usage:    "URL for remote database",
+		Usage:    "URL for remote database (grpc://<addr> or grpcs://<addr> over TLS for a database served by 'bor db serve')",
 		Category: flags.LoggingCategory,
 	}
 	DBEngineFlag = &cli.StringFlag{
@@ -2191,6 +2192,12 @@ func MakeChainDatabase(ctx *cli.Context, stack *node.Node, readonly bool) ethdb.
 	)
 
 	switch {
+	case ctx.IsSet(RemoteDBFlag.Name) && strings.HasPrefix(ctx.String(RemoteDBFlag.Name), "grpc://"):
+		log.Info("Using remote db", "url", ctx.String(RemoteDBFlag.Name))
+		chainDb, err = remotedb.Dial(strings.TrimPrefix(ctx.String(RemoteDBFlag.Name), "grpc://"), nil)
+	case ctx.IsSet(RemoteDBFlag.Name) && strings.HasPrefix(ctx.String(RemoteDBFlag.Name), "grpcs://"):
+		log.Info("Using remote db", "url", ctx.String(RemoteDBFlag.Name))
+		chainDb, err = remotedb.Dial(strings.TrimPrefix(ctx.String(RemoteDBFlag.Name), "grpcs://"), &tls.Config{MinVersion: tls.VersionTLS12})
 	case ctx.IsSet(RemoteDBFlag.Name):
 		log.Info("Using remote db", "url", ctx.String(RemoteDBFlag.Name), "headers", len(ctx.StringSlice(HttpHeaderFlag.Name)))
 		client, err := DialRPCWithHeaders(ctx.String(RemoteDBFlag.Name), ctx.StringSlice(HttpHeaderFlag.Name))
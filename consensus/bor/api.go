@@ -1,9 +1,9 @@
 package bor
 
 import (
+	"context"
 	"encoding/hex"
 	"math"
-	"math/big"
 	"sort"
 	"strconv"
 	"sync"
@@ -12,12 +12,9 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	lru "github.com/hashicorp/golang-lru"
-	"github.com/xsleonard/go-merkle"
-	"golang.org/x/crypto/sha3"
 )
 
 var (
@@ -271,6 +268,12 @@ func (api *API) GetCurrentValidators() ([]*valset.Validator, error) {
 	return snap.ValidatorSet.Validators, nil
 }
 
+// VerifySpan compares the given span committed to the validator contract with
+// the one served by Heimdall and with the validator set of the snapshot
+func (api *API) VerifySpan(ctx context.Context, spanID uint64) (*SpanReport, error) {
+	return api.bor.VerifySpan(ctx, api.chain, spanID)
+}
+
 // GetRootHash returns the merkle root of the start to end block headers
 func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	if err := api.initializeRootHashCache(); err != nil {
@@ -313,29 +316,12 @@ func (api *API) GetRootHash(start uint64, end uint64) (string, error) {
 	wg.Wait()
 	close(concurrent)
 
-	headers := make([][32]byte, nextPowerOfTwo(length))
-
-	for i := 0; i < len(blockHeaders); i++ {
-		blockHeader := blockHeaders[i]
-		header := crypto.Keccak256(appendBytes32(
-			blockHeader.Number.Bytes(),
-			new(big.Int).SetUint64(blockHeader.Time).Bytes(),
-			blockHeader.TxHash.Bytes(),
-			blockHeader.ReceiptHash.Bytes(),
-		))
-
-		var arr [32]byte
-
-		copy(arr[:], header)
-		headers[i] = arr
-	}
-
-	tree := merkle.NewTreeWithOpts(merkle.TreeOptions{EnableHashSorting: false, DisableHashLeaves: true})
-	if err := tree.Generate(convert(headers), sha3.NewLegacyKeccak256()); err != nil {
+	hash, err := ComputeRootHash(blockHeaders)
+	if err != nil {
 		return "", err
 	}
 
-	root := hex.EncodeToString(tree.Root().Hash)
+	root := hex.EncodeToString(hash)
 	api.rootHashCache.Add(key, root)
 
 	return root, nil
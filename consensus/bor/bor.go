@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"mime"
 	"sort"
 	"strconv"
 	"sync"
@@ -27,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor/api"
 	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
 	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
 	"github.com/ethereum/go-ethereum/consensus/misc"
@@ -34,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -218,6 +221,7 @@ type Bor struct {
 	signatures *lru.ARCCache // Signatures of recent blocks to speed up mining
 
 	authorizedSigner atomic.Pointer[signer] // Ethereum address and sign function of the signing key
+	protection       *protection.Store      // Last blocks released by the signers, nil if unprotected
 
 	ethAPI                 api.Caller
 	spanner                Spanner
@@ -857,6 +861,33 @@ func (c *Bor) Finalize(chain consensus.ChainHeaderReader, header *types.Header,
 	bc.SetStateSync(stateSyncData)
 }
 
+// TraceSystemCalls re-executes the system calls made by Finalize for the given
+// header (committing spans and state-sync events) on top of the state, reporting
+// each of them to the tracer. Unlike Finalize, errors are returned instead of
+// logged and the chain is left untouched.
+func (c *Bor) TraceSystemCalls(ctx context.Context, chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, tracer vm.EVMLogger) error {
+	headerNumber := header.Number.Uint64()
+
+	if !IsSprintStart(headerNumber, c.config.CalculateSprint(headerNumber)) {
+		return nil
+	}
+
+	ctx = statefull.WithTracer(ctx, tracer)
+	cx := statefull.ChainContext{Chain: chain, Bor: c}
+
+	if err := c.checkAndCommitSpan(ctx, state, header, cx); err != nil {
+		return fmt.Errorf("commit span: %w", err)
+	}
+
+	if c.HeimdallClient != nil {
+		if _, err := c.CommitStates(ctx, state, header, cx); err != nil {
+			return fmt.Errorf("commit states: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func decodeGenesisAlloc(i interface{}) (core.GenesisAlloc, error) {
 	var alloc core.GenesisAlloc
 
@@ -977,6 +1008,12 @@ func (c *Bor) Authorize(currentSigner common.Address, signFn SignerFn) {
 	})
 }
 
+// SetSigningProtection makes the engine refuse to sign a block conflicting with
+// one already released by the signer, as recorded in the given store.
+func (c *Bor) SetSigningProtection(store *protection.Store) {
+	c.protection = store
+}
+
 // Seal implements consensus.Engine, attempting to create a sealed block using
 // the local signing credentials.
 func (c *Bor) Seal(ctx context.Context, chain consensus.ChainHeaderReader, block *types.Block, results chan<- *types.Block, stop <-chan struct{}) error {
@@ -1027,8 +1064,25 @@ func (c *Bor) Seal(ctx context.Context, chain consensus.ChainHeaderReader, block
 	// wiggle was already accounted for in header.Time, this is just for logging
 	wiggle := time.Duration(successionNumber) * time.Duration(c.config.CalculateBackupMultiplier(number)) * time.Second
 
-	// Sign all the things!
-	err = Sign(currentSigner.signFn, currentSigner.signer, header, c.config)
+	// Refuse to sign a block conflicting with one already released, whatever the
+	// signing backend
+	sealHash := SealHash(header, c.config)
+
+	if c.protection != nil {
+		if err := c.protection.Check(currentSigner.signer, number, sealHash); err != nil {
+			log.Error("Refusing to sign block", "number", number, "sealhash", sealHash, "err", err)
+
+			return err
+		}
+	}
+
+	// Sign all the things! The succession number is passed along for the remote
+	// signers to record it
+	mimeType := mime.FormatMediaType(accounts.MimetypeBor, map[string]string{
+		accounts.BorSuccessionParam: strconv.Itoa(successionNumber),
+	})
+
+	err = sign(currentSigner.signFn, currentSigner.signer, mimeType, header, c.config)
 	if err != nil {
 		return err
 	}
@@ -1071,10 +1125,21 @@ func (c *Bor) Seal(ctx context.Context, chain consensus.ChainHeaderReader, block
 
 			tracing.EndSpan(sealSpan)
 		}
+
+		// Record the block before releasing it, so that no conflicting block is
+		// signed even after a restart
+		if c.protection != nil {
+			if err := c.protection.Record(currentSigner.signer, number, sealHash, uint64(successionNumber)); err != nil {
+				log.Error("Discarding signed block", "number", number, "sealhash", sealHash, "err", err)
+
+				return
+			}
+		}
+
 		select {
 		case results <- block.WithSeal(header):
 		default:
-			log.Warn("Sealing result was not read by miner", "number", number, "sealhash", SealHash(header, c.config))
+			log.Warn("Sealing result was not read by miner", "number", number, "sealhash", sealHash)
 		}
 	}(sealSpan)
 
@@ -1085,7 +1150,11 @@ func (c *Bor) Seal(ctx context.Context, chain consensus.ChainHeaderReader, block
 }
 
 func Sign(signFn SignerFn, signer common.Address, header *types.Header, c *params.BorConfig) error {
-	sighash, err := signFn(accounts.Account{Address: signer}, accounts.MimetypeBor, BorRLP(header, c))
+	return sign(signFn, signer, accounts.MimetypeBor, header, c)
+}
+
+func sign(signFn SignerFn, signer common.Address, mimeType string, header *types.Header, c *params.BorConfig) error {
+	sighash, err := signFn(accounts.Account{Address: signer}, mimeType, BorRLP(header, c))
 	if err != nil {
 		return err
 	}
@@ -1223,7 +1292,6 @@ func (c *Bor) CommitStates(
 
 	var (
 		lastStateIDBig *big.Int
-		from           uint64
 		to             time.Time
 		err            error
 	)
@@ -1246,8 +1314,70 @@ func (c *Bor) CommitStates(
 		to = time.Unix(int64(chain.Chain.GetHeaderByNumber(number-c.config.CalculateSprint(number)).Time), 0)
 	}
 
-	lastStateID := lastStateIDBig.Uint64()
-	from = lastStateID + 1
+	return c.commitStateEvents(ctx, state, header, chain, lastStateIDBig.Uint64(), to, fetchStart)
+}
+
+// SimulateCommitStates commits the state-sync events due at the given header
+// into the state the same way Finalize does, but without requiring the header's
+// ancestry to be known to the local chain. It's used to simulate blocks on top
+// of the chain; base is the last canonical block the simulation is built on.
+//
+// The events are only committed if the header is the first block of a sprint
+// and a Heimdall client is available, otherwise nil is returned.
+func (c *Bor) SimulateCommitStates(
+	ctx context.Context,
+	state *state.StateDB,
+	header *types.Header,
+	chain consensus.ChainHeaderReader,
+	base *types.Header,
+) ([]*types.StateSyncData, error) {
+	number := header.Number.Uint64()
+
+	if !IsSprintStart(number, c.config.CalculateSprint(number)) || c.HeimdallClient == nil {
+		return nil, nil
+	}
+
+	fetchStart := time.Now()
+
+	// The last state ID is a plain storage read, so resolve the call against the
+	// canonical base block while reading it from the simulated state.
+	lastStateIDBig, err := c.GenesisContractsClient.LastStateId(state.Copy(), base.Number.Uint64(), base.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	var to time.Time
+
+	if c.config.IsIndore(header.Number) {
+		to = time.Unix(int64(header.Time-c.config.CalculateStateSyncDelay(number)), 0)
+	} else {
+		sprintStart := chain.GetHeaderByNumber(number - c.config.CalculateSprint(number))
+		if sprintStart == nil {
+			return nil, errUnknownBlock
+		}
+
+		to = time.Unix(int64(sprintStart.Time), 0)
+	}
+
+	return c.commitStateEvents(ctx, state, header, statefull.ChainContext{Chain: chain, Bor: c}, lastStateIDBig.Uint64(), to, fetchStart)
+}
+
+// commitStateEvents fetches the state-sync events following lastStateID up to
+// the given time from Heimdall and commits them into the state.
+func (c *Bor) commitStateEvents(
+	ctx context.Context,
+	state *state.StateDB,
+	header *types.Header,
+	chain statefull.ChainContext,
+	lastStateID uint64,
+	to time.Time,
+	fetchStart time.Time,
+) ([]*types.StateSyncData, error) {
+	var (
+		number = header.Number.Uint64()
+		from   = lastStateID + 1
+		err    error
+	)
 
 	log.Info(
 		"Fetching state updates from Heimdall",
@@ -1295,7 +1425,7 @@ func (c *Bor) CommitStates(
 		// we expect that this call MUST emit an event, otherwise we wouldn't make a receipt
 		// if the receiver address is not a contract then we'll skip the most of the execution and emitting an event as well
 		// https://github.com/maticnetwork/genesis-contracts/blob/master/contracts/StateReceiver.sol#L27
-		gasUsed, err = c.GenesisContractsClient.CommitState(eventRecord, state, header, chain)
+		gasUsed, err = c.GenesisContractsClient.CommitState(ctx, eventRecord, state, header, chain)
 		if err != nil {
 			return nil, err
 		}
@@ -1329,6 +1459,32 @@ func (c *Bor) GetCurrentValidators(ctx context.Context, headerHash common.Hash,
 	return c.spanner.GetCurrentValidatorsByHash(ctx, headerHash, blockNumber)
 }
 
+// GetSignerSuccession returns the signer of a block and its position relative
+// to the in-turn proposer of the block, 0 if the block was signed in turn.
+func (c *Bor) GetSignerSuccession(chain consensus.ChainHeaderReader, header *types.Header) (common.Address, int, error) {
+	number := header.Number.Uint64()
+	if number == 0 {
+		return common.Address{}, 0, errUnknownBlock
+	}
+
+	snap, err := c.snapshot(chain, number-1, header.ParentHash, nil)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+
+	signer, err := ecrecover(header, c.signatures, c.config)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+
+	succession, err := snap.GetSignerSuccessionNumber(signer)
+	if err != nil {
+		return common.Address{}, 0, err
+	}
+
+	return signer, succession, nil
+}
+
 //
 // Private methods
 //
@@ -65,6 +65,7 @@ func NewGenesisContractsClient(
 }
 
 func (gc *GenesisContractsClient) CommitState(
+	ctx context.Context,
 	event *clerk.EventRecordWithTime,
 	state *state.StateDB,
 	header *types.Header,
@@ -91,7 +92,7 @@ func (gc *GenesisContractsClient) CommitState(
 
 	log.Info("→ committing new state", "eventRecord", event.ID)
 
-	gasUsed, err := statefull.ApplyMessage(context.Background(), msg, state, header, gc.chainConfig, chCtx)
+	gasUsed, err := statefull.ApplyMessage(ctx, msg, state, header, gc.chainConfig, chCtx)
 
 	// Logging event log with time and individual gasUsed
 	log.Info("→ committed new state", "eventRecord", event.String(gasUsed))
@@ -0,0 +1,68 @@
+package span
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+)
+
+// Diff compares the span a, read from the source named nameA, with the span b,
+// read from the source named nameB, and describes each difference found.
+func Diff(nameA string, a *HeimdallSpan, nameB string, b *HeimdallSpan) []string {
+	var diffs []string
+
+	if a.StartBlock != b.StartBlock || a.EndBlock != b.EndBlock {
+		diffs = append(diffs, fmt.Sprintf("blocks: %s has %d - %d, %s has %d - %d",
+			nameA, a.StartBlock, a.EndBlock, nameB, b.StartBlock, b.EndBlock))
+	}
+
+	minimal := func(validators []valset.Validator) []valset.MinimalVal {
+		vals := make([]valset.MinimalVal, len(validators))
+		for i, v := range validators {
+			vals[i] = v.MinimalVal()
+		}
+
+		return vals
+	}
+
+	validators := func(set valset.ValidatorSet) []valset.MinimalVal {
+		vals := make([]valset.MinimalVal, len(set.Validators))
+		for i, v := range set.Validators {
+			vals[i] = v.MinimalVal()
+		}
+
+		return vals
+	}
+
+	diffs = append(diffs, diffValidators("validator", nameA, validators(a.ValidatorSet), nameB, validators(b.ValidatorSet))...)
+	diffs = append(diffs, diffValidators("producer", nameA, minimal(a.SelectedProducers), nameB, minimal(b.SelectedProducers))...)
+
+	return diffs
+}
+
+// diffValidators compares two lists of validators in order, as committed to
+// the validator contract.
+func diffValidators(kind string, nameA string, a []valset.MinimalVal, nameB string, b []valset.MinimalVal) []string {
+	var diffs []string
+
+	if len(a) != len(b) {
+		diffs = append(diffs, fmt.Sprintf("%ss: %s has %d, %s has %d", kind, nameA, len(a), nameB, len(b)))
+	}
+
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i >= len(a):
+			diffs = append(diffs, fmt.Sprintf("%s %d: missing in %s, %s has %s", kind, i, nameA, nameB, formatMinimalVal(b[i])))
+		case i >= len(b):
+			diffs = append(diffs, fmt.Sprintf("%s %d: %s has %s, missing in %s", kind, i, nameA, formatMinimalVal(a[i]), nameB))
+		case a[i] != b[i]:
+			diffs = append(diffs, fmt.Sprintf("%s %d: %s has %s, %s has %s", kind, i, nameA, formatMinimalVal(a[i]), nameB, formatMinimalVal(b[i])))
+		}
+	}
+
+	return diffs
+}
+
+func formatMinimalVal(v valset.MinimalVal) string {
+	return fmt.Sprintf("{id %d, power %d, signer %s}", v.ID, v.VotingPower, v.Signer)
+}
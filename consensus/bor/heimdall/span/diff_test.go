@@ -0,0 +1,46 @@
+package span
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+)
+
+func newTestSpan(powers ...int64) *HeimdallSpan {
+	s := &HeimdallSpan{Span: Span{ID: 1, StartBlock: 256, EndBlock: 6655}}
+
+	for i, power := range powers {
+		v := valset.Validator{
+			ID:          uint64(i + 1),
+			Address:     common.BigToAddress(big.NewInt(int64(i + 1))),
+			VotingPower: power,
+		}
+
+		s.ValidatorSet.Validators = append(s.ValidatorSet.Validators, v.Copy())
+		s.SelectedProducers = append(s.SelectedProducers, v)
+	}
+
+	return s
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	require.Empty(t, Diff("contract", newTestSpan(10, 20), "heimdall", newTestSpan(10, 20)))
+
+	other := newTestSpan(10, 30, 40)
+	other.EndBlock = 6400
+	other.SelectedProducers = other.SelectedProducers[:2]
+
+	require.Equal(t, []string{
+		"blocks: contract has 256 - 6655, heimdall has 256 - 6400",
+		"validators: contract has 2, heimdall has 3",
+		"validator 1: contract has {id 2, power 20, signer 0x0000000000000000000000000000000000000002}, heimdall has {id 2, power 30, signer 0x0000000000000000000000000000000000000002}",
+		"validator 2: missing in contract, heimdall has {id 3, power 40, signer 0x0000000000000000000000000000000000000003}",
+		"producer 1: contract has {id 2, power 20, signer 0x0000000000000000000000000000000000000002}, heimdall has {id 2, power 30, signer 0x0000000000000000000000000000000000000002}",
+	}, Diff("contract", newTestSpan(10, 20), "heimdall", other))
+}
@@ -3,6 +3,8 @@ package span
 import (
 	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math"
 	"math/big"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
@@ -22,6 +25,15 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+var (
+	// ErrSpanNotFound is returned when reading a span which was never committed
+	// to the validator contract.
+	ErrSpanNotFound = errors.New("span not committed to the validator contract")
+)
+
+// revertErrorCode is the JSON-RPC error code of the reverted calls.
+const revertErrorCode = 3
+
 type ChainSpanner struct {
 	ethAPI                   api.Caller
 	validatorSet             abi.ABI
@@ -201,3 +213,120 @@ func (c *ChainSpanner) CommitSpan(ctx context.Context, heimdallSpan HeimdallSpan
 
 	return err
 }
+
+// GetSpan returns the span with the given id committed to the validator
+// contract, along with its validators and producers, as of the given block.
+func (c *ChainSpanner) GetSpan(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, spanID uint64) (*HeimdallSpan, error) {
+	id := new(big.Int).SetUint64(spanID)
+
+	result, err := c.call(ctx, blockNrOrHash, "getSpan", id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := new(struct {
+		Number     *big.Int
+		StartBlock *big.Int
+		EndBlock   *big.Int
+	})
+
+	if err := c.validatorSet.UnpackIntoInterface(ret, "getSpan", result); err != nil {
+		return nil, err
+	}
+
+	// Spans which were never committed read as zero
+	if ret.EndBlock.Sign() == 0 || ret.Number.Uint64() != spanID {
+		return nil, fmt.Errorf("%w: %d", ErrSpanNotFound, spanID)
+	}
+
+	validators, err := c.getSpanValidators(ctx, blockNrOrHash, "validators", id)
+	if err != nil {
+		return nil, err
+	}
+
+	producers, err := c.getSpanValidators(ctx, blockNrOrHash, "producers", id)
+	if err != nil {
+		return nil, err
+	}
+
+	selectedProducers := make([]valset.Validator, len(producers))
+	for i, p := range producers {
+		selectedProducers[i] = *p
+	}
+
+	return &HeimdallSpan{
+		Span: Span{
+			ID:         spanID,
+			StartBlock: ret.StartBlock.Uint64(),
+			EndBlock:   ret.EndBlock.Uint64(),
+		},
+		ValidatorSet:      valset.ValidatorSet{Validators: validators},
+		SelectedProducers: selectedProducers,
+	}, nil
+}
+
+// getSpanValidators reads the given validator array of a span, the contract
+// not exposing its length, until an out of range index.
+func (c *ChainSpanner) getSpanValidators(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, method string, spanID *big.Int) ([]*valset.Validator, error) {
+	var validators []*valset.Validator
+
+	for i := int64(0); ; i++ {
+		result, err := c.call(ctx, blockNrOrHash, method, spanID, big.NewInt(i))
+		if err != nil {
+			if ctx.Err() == nil && isOutOfRange(err) {
+				return validators, nil
+			}
+
+			return nil, err
+		}
+
+		ret := new(struct {
+			Id     *big.Int
+			Power  *big.Int
+			Signer common.Address
+		})
+
+		if err := c.validatorSet.UnpackIntoInterface(ret, method, result); err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, &valset.Validator{
+			ID:          ret.Id.Uint64(),
+			Address:     ret.Signer,
+			VotingPower: ret.Power.Int64(),
+		})
+	}
+}
+
+// call calls the given view method of the validator contract.
+func (c *ChainSpanner) call(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, method string, args ...interface{}) ([]byte, error) {
+	data, err := c.validatorSet.Pack(method, args...)
+	if err != nil {
+		log.Error("Unable to pack tx for "+method, "error", err)
+		return nil, err
+	}
+
+	msgData := (hexutil.Bytes)(data)
+	toAddress := c.validatorContractAddress
+	gas := (hexutil.Uint64)(uint64(math.MaxUint64 / 2))
+
+	return c.ethAPI.Call(ctx, ethapi.TransactionArgs{
+		Gas:  &gas,
+		To:   &toAddress,
+		Data: &msgData,
+	}, blockNrOrHash, nil, nil)
+}
+
+// isOutOfRange reports whether the error is the failure of a contract call
+// reading past the end of an array, which either hits an invalid opcode or
+// reverts depending on the compiler.
+func isOutOfRange(err error) bool {
+	var invalidOpCode *vm.ErrInvalidOpCode
+	if errors.As(err, &invalidOpCode) || errors.Is(err, vm.ErrExecutionReverted) {
+		return true
+	}
+
+	var rpcErr rpc.Error
+
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == revertErrorCode
+}
@@ -0,0 +1,29 @@
+package heimdalldev
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// API is the developer RPC API of the fake Heimdall.
+type API struct {
+	heimdall *HeimdallDevClient
+}
+
+// InjectStateSync queues a state-sync event delivering data to the given
+// receiver contract, as if it was bridged from the root chain. The event is
+// committed at the start of the next sprint, its ID is returned.
+func (api *API) InjectStateSync(contract common.Address, data hexutil.Bytes) (hexutil.Uint64, error) {
+	id, err := api.heimdall.InjectStateSync(contract, data)
+	return hexutil.Uint64(id), err
+}
+
+// APIs returns the developer RPC APIs of the fake Heimdall.
+func (h *HeimdallDevClient) APIs() []rpc.API {
+	return []rpc.API{{
+		Namespace: "dev",
+		Version:   "1.0",
+		Service:   &API{heimdall: h},
+	}}
+}
@@ -0,0 +1,255 @@
+// Package heimdalldev implements an in-process fake of Heimdall, serving spans
+// and state-sync events to a Bor node running in developer mode.
+package heimdalldev
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// firstSpanLength is the length of the span 0, hardcoded in the validator
+	// contract of the developer allocation.
+	firstSpanLength = 8
+
+	// spanSprints is the number of sprints in each of the following spans.
+	spanSprints = 16
+
+	// validatorPower is the voting power of each validator.
+	validatorPower = 10000
+)
+
+var (
+	// errNoContract is returned when injecting a state-sync event without a
+	// receiver contract.
+	errNoContract = errors.New("missing state-sync receiver contract")
+
+	// errNoValidators is returned when setting an empty validator set.
+	errNoValidators = errors.New("empty validator set")
+)
+
+// HeimdallDevClient is a fake Heimdall client, shared by the nodes of a local
+// network. Spans are produced by the validators set locally, the state-sync
+// events, checkpoints and milestones are the ones added locally. The no-ack
+// milestones are reported as unavailable.
+type HeimdallDevClient struct {
+	chainID    string
+	spanLength uint64
+
+	lock        sync.RWMutex
+	validators  []common.Address
+	spans       map[uint64]*span.HeimdallSpan
+	events      []*clerk.EventRecordWithTime
+	checkpoints []*checkpoint.Checkpoint
+	milestones  []*milestone.Milestone
+}
+
+// NewHeimdallDevClient creates a fake Heimdall client for the given chain,
+// with the given addresses as validators.
+func NewHeimdallDevClient(config *params.ChainConfig, validators ...common.Address) *HeimdallDevClient {
+	log.Info("Using in-process developer Heimdall", "validators", len(validators))
+
+	return &HeimdallDevClient{
+		chainID:    config.ChainID.String(),
+		spanLength: spanSprints * config.Bor.CalculateSprint(firstSpanLength),
+		validators: validators,
+		spans:      make(map[uint64]*span.HeimdallSpan),
+	}
+}
+
+// SetValidators replaces the validators of the spans not fetched yet. The spans
+// already served are never changed, so that all the nodes agree on them.
+func (h *HeimdallDevClient) SetValidators(validators []common.Address) error {
+	if len(validators) == 0 {
+		return errNoValidators
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.validators = append([]common.Address{}, validators...)
+
+	return nil
+}
+
+// AddCheckpoint makes the given checkpoint the latest one.
+func (h *HeimdallDevClient) AddCheckpoint(checkpoint *checkpoint.Checkpoint) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	checkpoint.BorChainID = h.chainID
+	h.checkpoints = append(h.checkpoints, checkpoint)
+}
+
+// AddMilestone makes the given milestone the latest one.
+func (h *HeimdallDevClient) AddMilestone(milestone *milestone.Milestone) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	milestone.BorChainID = h.chainID
+	h.milestones = append(h.milestones, milestone)
+}
+
+// InjectStateSync queues a state-sync event for the given receiver contract,
+// to be committed at the start of the next sprint. It returns the ID of the event.
+func (h *HeimdallDevClient) InjectStateSync(contract common.Address, data []byte) (uint64, error) {
+	if contract == (common.Address{}) {
+		return 0, errNoContract
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	id := uint64(len(h.events)) + 1
+
+	event := &clerk.EventRecordWithTime{
+		EventRecord: clerk.EventRecord{
+			ID:       id,
+			Contract: contract,
+			Data:     common.CopyBytes(data),
+			TxHash:   crypto.Keccak256Hash(binary.BigEndian.AppendUint64(nil, id)),
+			ChainID:  h.chainID,
+		},
+		Time: time.Now().UTC(),
+	}
+	h.events = append(h.events, event)
+
+	log.Info("Injected state-sync event", "id", id, "contract", contract, "size", len(data))
+
+	return id, nil
+}
+
+// StateSyncEvents returns the injected events starting at fromID, recorded
+// before the given time.
+func (h *HeimdallDevClient) StateSyncEvents(_ context.Context, fromID uint64, to int64) ([]*clerk.EventRecordWithTime, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	eventRecords := make([]*clerk.EventRecordWithTime, 0)
+
+	for _, event := range h.events {
+		if event.ID < fromID || event.Time.Unix() >= to {
+			continue
+		}
+
+		eventRecords = append(eventRecords, event)
+	}
+
+	return eventRecords, nil
+}
+
+// Span returns the span with the given ID. The first time a span is fetched,
+// it's assigned the current validators.
+func (h *HeimdallDevClient) Span(_ context.Context, spanID uint64) (*span.HeimdallSpan, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if s, ok := h.spans[spanID]; ok {
+		return s, nil
+	}
+
+	var start, end uint64
+
+	if spanID == 0 {
+		start, end = 0, firstSpanLength-1
+	} else {
+		start = firstSpanLength + (spanID-1)*h.spanLength
+		end = start + h.spanLength - 1
+	}
+
+	validators := make([]*valset.Validator, 0, len(h.validators))
+	producers := make([]valset.Validator, 0, len(h.validators))
+
+	for i, address := range h.validators {
+		validator := valset.NewValidator(address, validatorPower)
+		validator.ID = uint64(i) + 1
+
+		validators = append(validators, validator)
+		producers = append(producers, *validator)
+	}
+
+	s := &span.HeimdallSpan{
+		Span: span.Span{
+			ID:         spanID,
+			StartBlock: start,
+			EndBlock:   end,
+		},
+		ValidatorSet:      *valset.NewValidatorSet(validators),
+		SelectedProducers: producers,
+		ChainID:           h.chainID,
+	}
+	h.spans[spanID] = s
+
+	return s, nil
+}
+
+// FetchCheckpoint returns the checkpoint with the given number, counting from 1,
+// or the latest one if the number is -1.
+func (h *HeimdallDevClient) FetchCheckpoint(_ context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if number == -1 {
+		number = int64(len(h.checkpoints))
+	}
+
+	if number < 1 || number > int64(len(h.checkpoints)) {
+		return nil, heimdall.ErrServiceUnavailable
+	}
+
+	return h.checkpoints[number-1], nil
+}
+
+func (h *HeimdallDevClient) FetchCheckpointCount(_ context.Context) (int64, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return int64(len(h.checkpoints)), nil
+}
+
+// FetchMilestone returns the latest milestone.
+func (h *HeimdallDevClient) FetchMilestone(_ context.Context) (*milestone.Milestone, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if len(h.milestones) == 0 {
+		return nil, heimdall.ErrServiceUnavailable
+	}
+
+	return h.milestones[len(h.milestones)-1], nil
+}
+
+func (h *HeimdallDevClient) FetchMilestoneCount(_ context.Context) (int64, error) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	return int64(len(h.milestones)), nil
+}
+
+func (h *HeimdallDevClient) FetchNoAckMilestone(_ context.Context, _ string) error {
+	return heimdall.ErrServiceUnavailable
+}
+
+func (h *HeimdallDevClient) FetchLastNoAckMilestone(_ context.Context) (string, error) {
+	return "", heimdall.ErrServiceUnavailable
+}
+
+func (h *HeimdallDevClient) FetchMilestoneID(_ context.Context, _ string) error {
+	return heimdall.ErrServiceUnavailable
+}
+
+func (h *HeimdallDevClient) Close() {}
@@ -0,0 +1,142 @@
+package heimdalldev
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient() *HeimdallDevClient {
+	config := &params.ChainConfig{
+		ChainID: big.NewInt(1337),
+		Bor:     &params.BorConfig{Sprint: map[string]uint64{"0": 4}},
+	}
+
+	return NewHeimdallDevClient(config, common.HexToAddress("0x1"))
+}
+
+// TestSpan checks that the spans follow each other and are produced by the
+// developer validator only.
+func TestSpan(t *testing.T) {
+	t.Parallel()
+
+	h := newTestClient()
+
+	var last uint64
+
+	for id := uint64(0); id < 4; id++ {
+		s, err := h.Span(context.Background(), id)
+		require.NoError(t, err)
+
+		require.Equal(t, id, s.ID)
+		require.Equal(t, "1337", s.ChainID)
+
+		if id == 0 {
+			require.Equal(t, uint64(0), s.StartBlock)
+			require.Equal(t, uint64(firstSpanLength-1), s.EndBlock)
+		} else {
+			require.Equal(t, last+1, s.StartBlock)
+			require.Equal(t, uint64(spanSprints*4), s.EndBlock-s.StartBlock+1)
+		}
+
+		last = s.EndBlock
+
+		require.Len(t, s.ValidatorSet.Validators, 1)
+		require.Equal(t, common.HexToAddress("0x1"), s.ValidatorSet.Validators[0].Address)
+		require.Len(t, s.SelectedProducers, 1)
+		require.Equal(t, common.HexToAddress("0x1"), s.SelectedProducers[0].Address)
+	}
+}
+
+// TestStateSyncEvents checks that the injected events are served in order,
+// starting at the requested ID and before the requested time.
+func TestStateSyncEvents(t *testing.T) {
+	t.Parallel()
+
+	h := newTestClient()
+
+	_, err := h.InjectStateSync(common.Address{}, nil)
+	require.ErrorIs(t, err, errNoContract)
+
+	for i := uint64(1); i <= 3; i++ {
+		id, err := h.InjectStateSync(common.HexToAddress("0x1001"), []byte{byte(i)})
+		require.NoError(t, err)
+		require.Equal(t, i, id)
+	}
+
+	to := time.Now().Add(time.Second).Unix()
+
+	events, err := h.StateSyncEvents(context.Background(), 2, to)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	for i, event := range events {
+		require.Equal(t, uint64(i+2), event.ID)
+		require.Equal(t, []byte{byte(i + 2)}, []byte(event.Data))
+		require.Equal(t, "1337", event.ChainID)
+		require.True(t, event.Time.Before(time.Unix(to, 0)))
+	}
+
+	// Events recorded after the requested time are left for later
+	events, err = h.StateSyncEvents(context.Background(), 1, time.Now().Add(-time.Minute).Unix())
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	_, err = h.FetchCheckpoint(context.Background(), -1)
+	require.ErrorIs(t, err, heimdall.ErrServiceUnavailable)
+}
+
+// TestSetValidators checks that the validators only change the spans not
+// fetched yet.
+func TestSetValidators(t *testing.T) {
+	t.Parallel()
+
+	h := newTestClient()
+
+	first, err := h.Span(context.Background(), 1)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, h.SetValidators(nil), errNoValidators)
+	require.NoError(t, h.SetValidators([]common.Address{common.HexToAddress("0x2"), common.HexToAddress("0x3")}))
+
+	s, err := h.Span(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, first, s)
+	require.Len(t, s.ValidatorSet.Validators, 1)
+
+	s, err = h.Span(context.Background(), 2)
+	require.NoError(t, err)
+	require.Len(t, s.ValidatorSet.Validators, 2)
+	require.Len(t, s.SelectedProducers, 2)
+}
+
+// TestMilestones checks that the latest milestone is served once added.
+func TestMilestones(t *testing.T) {
+	t.Parallel()
+
+	h := newTestClient()
+
+	_, err := h.FetchMilestone(context.Background())
+	require.ErrorIs(t, err, heimdall.ErrServiceUnavailable)
+
+	for i := int64(1); i <= 2; i++ {
+		h.AddMilestone(&milestone.Milestone{EndBlock: big.NewInt(i * 16)})
+
+		m, err := h.FetchMilestone(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(i*16), m.EndBlock)
+		require.Equal(t, "1337", m.BorChainID)
+
+		count, err := h.FetchMilestoneCount(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, i, count)
+	}
+}
@@ -1,5 +1,39 @@
 package bor
 
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/xsleonard/go-merkle"
+	"golang.org/x/crypto/sha3"
+)
+
+// ComputeRootHash returns the root hash of a contiguous range of headers, as
+// submitted in a checkpoint to the root chain.
+func ComputeRootHash(headers []*types.Header) ([]byte, error) {
+	leaves := make([][32]byte, nextPowerOfTwo(uint64(len(headers))))
+
+	for i, header := range headers {
+		leaf := crypto.Keccak256(appendBytes32(
+			header.Number.Bytes(),
+			new(big.Int).SetUint64(header.Time).Bytes(),
+			header.TxHash.Bytes(),
+			header.ReceiptHash.Bytes(),
+		))
+
+		copy(leaves[i][:], leaf)
+	}
+
+	tree := merkle.NewTreeWithOpts(merkle.TreeOptions{EnableHashSorting: false, DisableHashLeaves: true})
+	if err := tree.Generate(convert(leaves), sha3.NewLegacyKeccak256()); err != nil {
+		return nil, err
+	}
+
+	return tree.Root().Hash, nil
+}
+
 func appendBytes32(data ...[]byte) []byte {
 	var result []byte
 
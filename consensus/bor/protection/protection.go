@@ -0,0 +1,266 @@
+// Package protection implements the double-sign protection of the Bor block
+// producers, a persistent record of the last block released by each signer
+// refusing to sign a conflicting block at the same or a lower height.
+package protection
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FileName is the name of the protection file in the instance directory of the node.
+const FileName = "signing_protection.json"
+
+// version is the version of the interchange format of the records.
+const version = 1
+
+var (
+	// ErrConflict is returned when signing a block at the height of the last
+	// released block, with a different seal hash.
+	ErrConflict = errors.New("conflicting block already signed at this height")
+
+	// ErrRegression is returned when signing a block below the last released one.
+	ErrRegression = errors.New("block below the last signed height")
+
+	// errVersion is returned when reading records of an unknown format.
+	errVersion = errors.New("unsupported protection format version")
+)
+
+// Record is the last block released by a signer.
+type Record struct {
+	Signer common.Address `json:"signer"`
+	Number uint64         `json:"number"`
+	Hash   common.Hash    `json:"hash"`  // Seal hash of the block, without the signature
+	Round  uint64         `json:"round"` // Succession number of the signer at the block, 0 in turn
+}
+
+// Interchange is the format the records are stored, exported and imported in.
+type Interchange struct {
+	Version uint64    `json:"version"`
+	Records []*Record `json:"records"`
+}
+
+// Store holds the records of the signers, persisted in a file if any.
+type Store struct {
+	path    string
+	lock    sync.Mutex
+	records map[common.Address]*Record
+}
+
+// Open opens the store persisted in the given file, created on the first
+// record. An empty path opens a store kept in memory only.
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, records: make(map[common.Address]*Record)}
+
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid protection file %s: %w", path, err)
+	}
+
+	for _, r := range records {
+		s.records[r.Signer] = r
+	}
+
+	return s, nil
+}
+
+// Decode decodes records in the interchange format.
+func Decode(data []byte) ([]*Record, error) {
+	var interchange Interchange
+	if err := json.Unmarshal(data, &interchange); err != nil {
+		return nil, err
+	}
+
+	if interchange.Version != version {
+		return nil, fmt.Errorf("%w: %d", errVersion, interchange.Version)
+	}
+
+	return interchange.Records, nil
+}
+
+// Check checks that the signer may sign the block with the given number and
+// seal hash, without recording it.
+func (s *Store) Check(signer common.Address, number uint64, hash common.Hash) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.check(signer, number, hash)
+}
+
+func (s *Store) check(signer common.Address, number uint64, hash common.Hash) error {
+	last, ok := s.records[signer]
+
+	switch {
+	case !ok || number > last.Number:
+		return nil
+	case number < last.Number:
+		return fmt.Errorf("%w: block %d, last signed %d", ErrRegression, number, last.Number)
+	case hash != last.Hash:
+		return fmt.Errorf("%w: block %d, signed %s, now %s", ErrConflict, number, last.Hash, hash)
+	}
+
+	return nil
+}
+
+// Record checks that the signer may sign the given block and records it as its
+// last released block. The record is persisted before returning, the block
+// must not be released on error.
+func (s *Store) Record(signer common.Address, number uint64, hash common.Hash, round uint64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.check(signer, number, hash); err != nil {
+		return err
+	}
+
+	prev := s.records[signer]
+	s.records[signer] = &Record{Signer: signer, Number: number, Hash: hash, Round: round}
+
+	if err := s.persist(); err != nil {
+		if prev == nil {
+			delete(s.records, signer)
+		} else {
+			s.records[signer] = prev
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Last returns the last block released by the signer, if any.
+func (s *Store) Last(signer common.Address) (Record, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	r, ok := s.records[signer]
+	if !ok {
+		return Record{}, false
+	}
+
+	return *r, true
+}
+
+// Export returns the records in the interchange format.
+func (s *Store) Export() ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.encode()
+}
+
+// Import merges the records in the interchange format, keeping the highest
+// block of each signer. It fails without importing anything if a record
+// conflicts with the one of the store at the same height.
+func (s *Store) Import(data []byte) (int, error) {
+	records, err := Decode(data)
+	if err != nil {
+		return 0, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	merged := make(map[common.Address]*Record, len(s.records)+len(records))
+	for signer, r := range s.records {
+		merged[signer] = r
+	}
+
+	imported := 0
+
+	for _, r := range records {
+		last, ok := merged[r.Signer]
+
+		switch {
+		case !ok || r.Number > last.Number:
+			merged[r.Signer] = r
+			imported++
+		case r.Number == last.Number && r.Hash != last.Hash:
+			return 0, fmt.Errorf("%w: signer %s at block %d, %s and %s", ErrConflict, r.Signer, r.Number, last.Hash, r.Hash)
+		}
+	}
+
+	prev := s.records
+	s.records = merged
+
+	if err := s.persist(); err != nil {
+		s.records = prev
+		return 0, err
+	}
+
+	return imported, nil
+}
+
+func (s *Store) encode() ([]byte, error) {
+	records := make([]*Record, 0, len(s.records))
+	for _, r := range s.records {
+		records = append(records, r)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Signer.Cmp(records[j].Signer) < 0
+	})
+
+	return json.MarshalIndent(&Interchange{Version: version, Records: records}, "", "  ")
+}
+
+// persist writes the records to a temporary file synced to disk, then moves
+// it over the protection file.
+func (s *Store) persist() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), "."+filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+
+		return err
+	}
+
+	f.Close()
+
+	return os.Rename(f.Name(), s.path)
+}
@@ -0,0 +1,107 @@
+package protection
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	signer = common.HexToAddress("0x0000000000000000000000000000000000000001")
+	other  = common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	hashA = common.HexToHash("0x0a")
+	hashB = common.HexToHash("0x0b")
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), FileName)
+
+	s, err := Open(path)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Check(signer, 10, hashA))
+	require.NoError(t, s.Record(signer, 10, hashA, 1))
+
+	// The same block may be released again, not a conflicting one
+	require.NoError(t, s.Record(signer, 10, hashA, 1))
+	require.ErrorIs(t, s.Check(signer, 10, hashB), ErrConflict)
+	require.ErrorIs(t, s.Record(signer, 10, hashB, 0), ErrConflict)
+	require.ErrorIs(t, s.Check(signer, 9, hashB), ErrRegression)
+
+	// Signers are protected independently
+	require.NoError(t, s.Record(other, 5, hashB, 0))
+	require.NoError(t, s.Record(signer, 11, hashB, 0))
+
+	// The records survive a restart
+	s, err = Open(path)
+	require.NoError(t, err)
+
+	last, ok := s.Last(signer)
+	require.True(t, ok)
+	require.Equal(t, Record{Signer: signer, Number: 11, Hash: hashB}, last)
+	require.ErrorIs(t, s.Check(signer, 11, hashA), ErrConflict)
+
+	last, ok = s.Last(other)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), last.Number)
+}
+
+func TestImport(t *testing.T) {
+	t.Parallel()
+
+	source, err := Open("")
+	require.NoError(t, err)
+	require.NoError(t, source.Record(signer, 20, hashA, 0))
+	require.NoError(t, source.Record(other, 5, hashA, 2))
+
+	data, err := source.Export()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), FileName)
+	target, err := Open(path)
+	require.NoError(t, err)
+	require.NoError(t, target.Record(signer, 10, hashB, 0))
+	require.NoError(t, target.Record(other, 8, hashB, 0))
+
+	// The highest block of each signer is kept
+	n, err := target.Import(data)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	last, _ := target.Last(signer)
+	require.Equal(t, uint64(20), last.Number)
+	require.ErrorIs(t, target.Check(signer, 20, hashB), ErrConflict)
+
+	last, _ = target.Last(other)
+	require.Equal(t, uint64(8), last.Number)
+
+	// A conflicting record imports nothing
+	conflicting, err := Open("")
+	require.NoError(t, err)
+	require.NoError(t, conflicting.Record(signer, 20, hashB, 0))
+	require.NoError(t, conflicting.Record(other, 30, hashB, 0))
+
+	data, err = conflicting.Export()
+	require.NoError(t, err)
+
+	_, err = target.Import(data)
+	require.ErrorIs(t, err, ErrConflict)
+
+	last, _ = target.Last(other)
+	require.Equal(t, uint64(8), last.Number)
+
+	// Unknown formats are refused
+	_, err = target.Import([]byte(`{"version":2,"records":[]}`))
+	require.ErrorIs(t, err, errVersion)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":2}`), 0600))
+	_, err = Open(path)
+	require.ErrorIs(t, err, errVersion)
+}
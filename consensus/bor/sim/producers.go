@@ -0,0 +1,293 @@
+// Package sim simulates offline the block production of a Bor span, replaying
+// the proposer rotation and the wiggle time of the backup producers, to
+// evaluate consensus parameter changes before a fork.
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/valset"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// errNoValidators is returned when simulating a span without validators.
+	errNoValidators = errors.New("no validators to simulate")
+
+	// errInvalidSpan is returned when the span ends before it starts.
+	errInvalidSpan = errors.New("span ends before it starts")
+
+	// errNoProducer is returned when no validator can ever produce a block.
+	errNoProducer = errors.New("no validator is ever available")
+
+	// errInvalidAvailability is returned for an availability out of [0, 1].
+	errInvalidAvailability = errors.New("availability must be between 0 and 1")
+
+	// errInvalidPower is returned for a validator without voting power.
+	errInvalidPower = errors.New("voting power must be positive")
+
+	// errDuplicateValidator is returned when a validator is listed twice.
+	errDuplicateValidator = errors.New("duplicate validator")
+)
+
+// Validator models a validator of the simulated span.
+type Validator struct {
+	Address common.Address
+	Power   int64
+
+	// Availability is the probability for the validator to be online when it's
+	// its turn to produce a block, between 0 and 1.
+	Availability float64
+
+	// Latency is the time taken by the blocks of the validator to reach the
+	// rest of the network, to which a random delay up to Jitter is added.
+	Latency time.Duration
+	Jitter  time.Duration
+}
+
+// Config is the configuration of a simulation.
+type Config struct {
+	Bor        *params.BorConfig
+	Validators []Validator
+	StartBlock uint64 // First block of the span
+	EndBlock   uint64 // Last block of the span
+	Runs       int    // Number of times the span is replayed
+	Seed       int64  // Seed of the random outcomes
+}
+
+// ProducerStats are the outcomes of the simulation for a validator.
+type ProducerStats struct {
+	Address   common.Address
+	InTurn    uint64 // Canonical blocks produced in turn
+	OutOfTurn uint64 // Canonical blocks produced as a backup
+	Missed    uint64 // Turns missed while being offline
+	Reorged   uint64 // Blocks produced, seen by the network and then reorged
+}
+
+// Result are the outcomes of a simulation, accumulated over all the runs.
+type Result struct {
+	Blocks        uint64        // Canonical blocks produced
+	MeanBlockTime time.Duration // Mean time between two canonical blocks
+	MaxBlockTime  time.Duration // Longest time between two canonical blocks
+	OutOfTurnRate float64       // Share of canonical blocks produced as a backup
+	ReorgRate     float64       // Share of heights with a competing block seen first
+	Producers     []*ProducerStats
+}
+
+// producer is a candidate block producer at a given height.
+type producer struct {
+	index      int
+	succession int
+	sealedAt   time.Duration // Time the block is sealed at, from the parent
+	seenAt     time.Duration // Time the block reaches the network, from the parent
+}
+
+// Run replays the span for the configured number of runs and returns the
+// accumulated outcomes.
+func Run(config *Config) (*Result, error) {
+	if len(config.Validators) == 0 {
+		return nil, errNoValidators
+	}
+
+	if config.EndBlock < config.StartBlock {
+		return nil, errInvalidSpan
+	}
+
+	available := false
+	seen := make(map[common.Address]bool, len(config.Validators))
+
+	for _, v := range config.Validators {
+		if v.Availability < 0 || v.Availability > 1 {
+			return nil, errInvalidAvailability
+		}
+
+		if v.Power <= 0 {
+			return nil, errInvalidPower
+		}
+
+		if seen[v.Address] {
+			return nil, fmt.Errorf("%w: %s", errDuplicateValidator, v.Address)
+		}
+
+		seen[v.Address] = true
+
+		available = available || v.Availability > 0
+	}
+
+	if !available {
+		return nil, errNoProducer
+	}
+
+	runs := config.Runs
+	if runs < 1 {
+		runs = 1
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed)) // nolint: gosec
+
+	// Validators are ordered by address in the validator set, the stats follow
+	// the order of the configuration
+	indexes := make(map[common.Address]int, len(config.Validators))
+	stats := make([]*ProducerStats, 0, len(config.Validators))
+
+	for i, v := range config.Validators {
+		indexes[v.Address] = i
+		stats = append(stats, &ProducerStats{Address: v.Address})
+	}
+
+	var (
+		blocks, outOfTurn, reorgs uint64
+		total, longest            time.Duration
+	)
+
+	for run := 0; run < runs; run++ {
+		validators := make([]*valset.Validator, 0, len(config.Validators))
+		for _, v := range config.Validators {
+			validators = append(validators, valset.NewValidator(v.Address, v.Power))
+		}
+
+		set := valset.NewValidatorSet(validators)
+
+		for number := config.StartBlock; number <= config.EndBlock; number++ {
+			// Retry the height until a validator is online, the chain stalls
+			// meanwhile
+			var (
+				winner  *producer
+				reorged []*producer
+				elapsed time.Duration
+			)
+
+			for winner == nil {
+				var stalled time.Duration
+
+				winner, reorged, stalled = produce(config, set, indexes, stats, number, rng)
+				elapsed += stalled
+			}
+
+			blockTime := elapsed + winner.sealedAt
+			total += blockTime
+
+			if blockTime > longest {
+				longest = blockTime
+			}
+
+			blocks++
+
+			if winner.succession == 0 {
+				stats[winner.index].InTurn++
+			} else {
+				stats[winner.index].OutOfTurn++
+				outOfTurn++
+			}
+
+			if len(reorged) > 0 {
+				reorgs++
+			}
+
+			for _, p := range reorged {
+				stats[p.index].Reorged++
+			}
+
+			// The proposer rotates at the end of each sprint
+			if (number+1)%config.Bor.CalculateSprint(number) == 0 {
+				set.IncrementProposerPriority(1)
+			}
+		}
+	}
+
+	return &Result{
+		Blocks:        blocks,
+		MeanBlockTime: total / time.Duration(blocks),
+		MaxBlockTime:  longest,
+		OutOfTurnRate: float64(outOfTurn) / float64(blocks),
+		ReorgRate:     float64(reorgs) / float64(blocks),
+		Producers:     stats,
+	}, nil
+}
+
+// produce simulates the production of the given block. It returns the canonical
+// producer and the ones whose blocks were seen first and then reorged, or the
+// time elapsed without any block if no validator was online.
+func produce(config *Config, set *valset.ValidatorSet, indexes map[common.Address]int, stats []*ProducerStats, number uint64, rng *rand.Rand) (*producer, []*producer, time.Duration) {
+	snap := &bor.Snapshot{Number: number - 1, ValidatorSet: set}
+
+	candidates := make([]*producer, 0, len(set.Validators))
+
+	for _, v := range set.Validators {
+		i := indexes[v.Address]
+		model := config.Validators[i]
+
+		succession, err := snap.GetSignerSuccessionNumber(v.Address)
+		if err != nil {
+			continue
+		}
+
+		if rng.Float64() >= model.Availability {
+			if succession == 0 {
+				stats[i].Missed++
+			}
+
+			continue
+		}
+
+		sealedAt := time.Duration(bor.CalcProducerDelay(number, succession, config.Bor)) * time.Second
+
+		seenAt := sealedAt + model.Latency
+		if model.Jitter > 0 {
+			seenAt += time.Duration(rng.Int63n(int64(model.Jitter)))
+		}
+
+		candidates = append(candidates, &producer{
+			index:      i,
+			succession: succession,
+			sealedAt:   sealedAt,
+			seenAt:     seenAt,
+		})
+	}
+
+	if len(candidates) == 0 {
+		// Nobody sealed, the height is retried after the last backup gave up
+		last := bor.CalcProducerDelay(number, len(set.Validators)-1, config.Bor)
+		return nil, nil, time.Duration(last) * time.Second
+	}
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].succession < candidates[j].succession })
+
+	// A backup only seals if no block of a better producer reached it by then
+	var produced []*producer
+
+	for _, c := range candidates {
+		seen := false
+
+		for _, p := range produced {
+			if p.seenAt <= c.sealedAt {
+				seen = true
+				break
+			}
+		}
+
+		if !seen {
+			produced = append(produced, c)
+		}
+	}
+
+	// The block of the best producer has the highest difficulty and wins, the
+	// competing blocks which reached the network first are reorged
+	winner := produced[0]
+
+	var reorged []*producer
+
+	for _, p := range produced[1:] {
+		if p.seenAt < winner.seenAt {
+			reorged = append(reorged, p)
+		}
+	}
+
+	return winner, reorged, 0
+}
@@ -0,0 +1,133 @@
+package sim
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(validators ...Validator) *Config {
+	return &Config{
+		Bor: &params.BorConfig{
+			Period:           map[string]uint64{"0": 2},
+			ProducerDelay:    map[string]uint64{"0": 4},
+			Sprint:           map[string]uint64{"0": 16},
+			BackupMultiplier: map[string]uint64{"0": 2},
+		},
+		Validators: validators,
+		StartBlock: 256,
+		EndBlock:   256 + 16*8 - 1,
+		Runs:       4,
+		Seed:       1,
+	}
+}
+
+func newTestValidators(n int) []Validator {
+	validators := make([]Validator, 0, n)
+
+	for i := 1; i <= n; i++ {
+		validators = append(validators, Validator{
+			Address:      common.BigToAddress(new(big.Int).Lsh(common.Big1, uint(i))),
+			Power:        100,
+			Availability: 1,
+		})
+	}
+
+	return validators
+}
+
+// TestRunInTurn checks that the proposers rotate every sprint when all the
+// validators are online and blocks propagate instantly.
+func TestRunInTurn(t *testing.T) {
+	t.Parallel()
+
+	result, err := Run(newTestConfig(newTestValidators(4)...))
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(4*16*8), result.Blocks)
+	require.Zero(t, result.OutOfTurnRate)
+	require.Zero(t, result.ReorgRate)
+
+	// One block per sprint waits for the producer delay
+	require.Equal(t, (15*2*time.Second+4*time.Second)/16, result.MeanBlockTime)
+	require.Equal(t, 4*time.Second, result.MaxBlockTime)
+
+	// Validators with the same power produce the same number of sprints
+	for _, p := range result.Producers {
+		require.Equal(t, uint64(4*16*2), p.InTurn)
+		require.Zero(t, p.OutOfTurn)
+		require.Zero(t, p.Missed)
+	}
+}
+
+// TestRunOffline checks that the turns of an offline validator are taken by
+// its first backup after the wiggle time.
+func TestRunOffline(t *testing.T) {
+	t.Parallel()
+
+	validators := newTestValidators(4)
+	validators[0].Availability = 0
+
+	result, err := Run(newTestConfig(validators...))
+	require.NoError(t, err)
+
+	require.Equal(t, 0.25, result.OutOfTurnRate)
+	require.Zero(t, result.ReorgRate)
+	require.Equal(t, 6*time.Second, result.MaxBlockTime)
+
+	require.Zero(t, result.Producers[0].InTurn)
+	require.Equal(t, uint64(4*16*2), result.Producers[0].Missed)
+	require.Equal(t, uint64(4*16*2), result.Producers[1].OutOfTurn)
+}
+
+// TestRunLatency checks that blocks propagating slower than the wiggle time
+// compete with the ones of the backups and get reorged.
+func TestRunLatency(t *testing.T) {
+	t.Parallel()
+
+	validators := newTestValidators(4)
+	for i := range validators {
+		validators[i].Latency = 3 * time.Second
+	}
+
+	result, err := Run(newTestConfig(validators...))
+	require.NoError(t, err)
+
+	// The backups seal before the in-turn block reaches them, their blocks
+	// are seen after the in-turn one though
+	require.Zero(t, result.OutOfTurnRate)
+	require.Zero(t, result.ReorgRate)
+
+	validators[0].Latency = 6 * time.Second
+
+	result, err = Run(newTestConfig(validators...))
+	require.NoError(t, err)
+
+	require.Zero(t, result.OutOfTurnRate)
+	require.Equal(t, 0.25, result.ReorgRate)
+	require.Equal(t, uint64(4*16*2), result.Producers[1].Reorged)
+}
+
+func TestRunInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := Run(newTestConfig())
+	require.ErrorIs(t, err, errNoValidators)
+
+	validators := newTestValidators(2)
+	validators[1].Address = validators[0].Address
+
+	_, err = Run(newTestConfig(validators...))
+	require.ErrorIs(t, err, errDuplicateValidator)
+
+	validators = newTestValidators(2)
+	validators[0].Availability, validators[1].Availability = 0, 0
+
+	_, err = Run(newTestConfig(validators...))
+	require.ErrorIs(t, err, errNoProducer)
+}
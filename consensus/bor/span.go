@@ -2,8 +2,11 @@ package bor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
 	"github.com/ethereum/go-ethereum/consensus/bor/valset"
 	"github.com/ethereum/go-ethereum/core"
@@ -19,3 +22,115 @@ type Spanner interface {
 	GetCurrentValidatorsByBlockNrOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, blockNumber uint64) ([]*valset.Validator, error)
 	CommitSpan(ctx context.Context, heimdallSpan span.HeimdallSpan, state *state.StateDB, header *types.Header, chainContext core.ChainContext) error
 }
+
+// SpanReader is implemented by the spanners able to read back the spans
+// committed to the validator contract.
+type SpanReader interface {
+	GetSpan(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, spanID uint64) (*span.HeimdallSpan, error)
+}
+
+// errSpanReaderUnsupported is returned when verifying spans with a spanner
+// unable to read them back.
+var errSpanReaderUnsupported = errors.New("spanner can't read the committed spans")
+
+// SpanReport is the outcome of the verification of a span committed to the
+// validator contract.
+type SpanReport struct {
+	Span       *span.HeimdallSpan `json:"span"`       // Span read from the validator contract
+	Sources    []string           `json:"sources"`    // Sources the span was compared with
+	Mismatches []string           `json:"mismatches"` // Differences found with the sources
+}
+
+// Consistent reports whether the span matches all the sources it was compared with.
+func (r *SpanReport) Consistent() bool {
+	return len(r.Mismatches) == 0
+}
+
+// VerifySpan reads the given span from the validator contract at the head of
+// the chain, and compares it with the span served by Heimdall and with the
+// validator set of the snapshot at the start of the span, if already reached.
+func (c *Bor) VerifySpan(ctx context.Context, chain consensus.ChainHeaderReader, spanID uint64) (*SpanReport, error) {
+	reader, ok := c.spanner.(SpanReader)
+	if !ok {
+		return nil, errSpanReaderUnsupported
+	}
+
+	head := chain.CurrentHeader()
+
+	committed, err := reader.GetSpan(ctx, rpc.BlockNumberOrHashWithHash(head.Hash(), false), spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SpanReport{Span: committed, Sources: []string{}, Mismatches: []string{}}
+
+	if c.HeimdallClient != nil {
+		heimdallSpan, err := c.HeimdallClient.Span(ctx, spanID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch span %d from heimdall: %w", spanID, err)
+		}
+
+		report.Sources = append(report.Sources, "heimdall")
+		report.Mismatches = append(report.Mismatches, span.Diff("contract", committed, "heimdall", heimdallSpan)...)
+
+		if heimdallSpan.ChainID != c.chainConfig.ChainID.String() {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("chain id: bor has %s, heimdall has %s", c.chainConfig.ChainID, heimdallSpan.ChainID))
+		}
+	}
+
+	// The producers of the span take over at the end of the last sprint
+	// before it, when they are read from the contract into the snapshot
+	number := committed.StartBlock
+	if number > 0 {
+		number--
+	}
+
+	if number <= head.Number.Uint64() {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("%w: %d", errUnknownBlock, number)
+		}
+
+		snap, err := c.snapshot(chain, number, header.Hash(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Sources = append(report.Sources, "snapshot")
+		report.Mismatches = append(report.Mismatches, diffSnapshotProducers(committed.SelectedProducers, snap.ValidatorSet)...)
+	}
+
+	return report, nil
+}
+
+// diffSnapshotProducers compares the producers of a span with the validator
+// set of a snapshot, which only tracks their addresses and voting powers.
+func diffSnapshotProducers(producers []valset.Validator, set *valset.ValidatorSet) []string {
+	var diffs []string
+
+	powers := make(map[common.Address]int64, len(set.Validators))
+	for _, v := range set.Validators {
+		powers[v.Address] = v.VotingPower
+	}
+
+	for _, p := range producers {
+		power, ok := powers[p.Address]
+
+		switch {
+		case !ok:
+			diffs = append(diffs, fmt.Sprintf("producer %s: missing in snapshot", p.Address))
+		case power != p.VotingPower:
+			diffs = append(diffs, fmt.Sprintf("producer %s: contract has power %d, snapshot has %d", p.Address, p.VotingPower, power))
+		}
+
+		delete(powers, p.Address)
+	}
+
+	for _, v := range set.Validators {
+		if _, ok := powers[v.Address]; ok {
+			diffs = append(diffs, fmt.Sprintf("producer %s: missing in contract, snapshot has power %d", v.Address, v.VotingPower))
+		}
+	}
+
+	return diffs
+}
@@ -19,6 +19,27 @@ import (
 
 var systemAddress = common.HexToAddress("0xffffFFFfFFffffffffffffffFfFFFfffFFFfFFfE")
 
+// tracerKey is the context key under which the tracer of system calls is stored.
+type tracerKey struct{}
+
+// WithTracer returns a copy of the context carrying a tracer, which will receive
+// the system calls applied with that context.
+func WithTracer(ctx context.Context, tracer vm.EVMLogger) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// tracerFromContext returns the tracer of system calls carried by the context,
+// if any.
+func tracerFromContext(ctx context.Context) vm.EVMLogger {
+	if ctx == nil {
+		return nil
+	}
+
+	tracer, _ := ctx.Value(tracerKey{}).(vm.EVMLogger)
+
+	return tracer
+}
+
 type ChainContext struct {
 	Chain consensus.ChainHeaderReader
 	Bor   consensus.Engine
@@ -62,7 +83,7 @@ func GetSystemMessage(toAddress common.Address, data []byte) Callmsg {
 
 // apply message
 func ApplyMessage(
-	_ context.Context,
+	ctx context.Context,
 	msg Callmsg,
 	state *state.StateDB,
 	header *types.Header,
@@ -76,7 +97,7 @@ func ApplyMessage(
 
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
-	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, state, chainConfig, vm.Config{})
+	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, state, chainConfig, vm.Config{Tracer: tracerFromContext(ctx)})
 
 	// nolint : contextcheck
 	// Apply the transaction to the current state (included in the env)
@@ -59,6 +59,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/triedb/pathdb"
 	"golang.org/x/exp/slices"
 )
 
@@ -151,6 +152,9 @@ type CacheConfig struct {
 	SnapshotLimit       int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	Preimages           bool          // Whether to store preimage of trie key to the disk
 	TriesInMemory       uint64        // Number of recent tries to keep in memory
+	StateHistory        uint64        // Number of blocks from head whose state histories are reserved (path-based scheme only)
+	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
+	BlockHistory        uint64        // Number of blocks from head whose headers, bodies and receipts are reserved (0 = entire chain)
 
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
@@ -169,6 +173,22 @@ var defaultCacheConfig = &CacheConfig{
 
 var DefaultCacheConfig = defaultCacheConfig
 
+// TriedbConfig derives the configures for trie database.
+func (c *CacheConfig) TriedbConfig() *trie.Config {
+	config := &trie.Config{
+		Cache:     c.TrieCleanLimit,
+		Preimages: c.Preimages,
+	}
+	if c.StateScheme == rawdb.PathScheme {
+		config.PathDB = &pathdb.Config{
+			StateLimit: c.StateHistory,
+			CleanSize:  c.TrieCleanLimit * 1024 * 1024,
+			DirtySize:  c.TrieDirtyLimit * 1024 * 1024,
+		}
+	}
+	return config
+}
+
 // BlockChain represents the canonical chain given a database with a genesis
 // block. The Blockchain manages chain imports, reverts, chain reorganisations.
 //
@@ -202,6 +222,7 @@ type BlockChain struct {
 	//  * N:   means N block limit [HEAD-N+1, HEAD] and delete extra indexes
 	//  * nil: disable tx reindexer/deleter, but still index new blocks
 	txLookupLimit uint64
+	historyLock   sync.Mutex // Lock to serialize the tx indexer and the history expiry
 
 	hc            *HeaderChain
 	rmLogsFeed    event.Feed
@@ -239,9 +260,11 @@ type BlockChain struct {
 	engine                       consensus.Engine
 	validator                    Validator // Block and state validator interface
 	prefetcher                   Prefetcher
-	processor                    Processor // Block transaction processor interface
-	parallelProcessor            Processor // Parallel block transaction processor interface
-	parallelSpeculativeProcesses int       // Number of parallel speculative processes
+	processor                    Processor     // Block transaction processor interface
+	parallelProcessor            Processor     // Parallel block transaction processor interface
+	parallelSpeculativeProcesses int           // Number of parallel speculative processes
+	parallelExecutions           atomic.Uint64 // Number of blocks executed by the parallel processor
+	serialExecutions             atomic.Uint64 // Number of blocks executed by the serial processor
 	forker                       *ForkChoice
 	vmConfig                     vm.Config
 
@@ -266,10 +289,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 		cacheConfig.TriesInMemory = defaultCacheConfig.TriesInMemory
 	}
 	// Open trie database with provided config
-	triedb := trie.NewDatabaseWithConfig(db, &trie.Config{
-		Cache:     cacheConfig.TrieCleanLimit,
-		Preimages: cacheConfig.Preimages,
-	})
+	triedb := trie.NewDatabaseWithConfig(db, cacheConfig.TriedbConfig())
 	// Setup the genesis block, commit the provided genesis specification
 	// to database if the genesis block is not present yet, or load the
 	// stored one from database.
@@ -474,10 +494,21 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *Genesis
 	if txLookupLimit != nil {
 		bc.txLookupLimit = *txLookupLimit
 
+		// Transactions of expired blocks can't be indexed
+		if history := bc.cacheConfig.BlockHistory; history != 0 && (bc.txLookupLimit == 0 || bc.txLookupLimit > history) {
+			bc.txLookupLimit = history
+		}
+
 		bc.wg.Add(1)
 
 		go bc.maintainTxIndex()
 	}
+	// Start history expiry if required.
+	if bc.cacheConfig.BlockHistory != 0 {
+		bc.wg.Add(1)
+
+		go bc.maintainHistory()
+	}
 
 	return bc, nil
 }
@@ -490,12 +521,9 @@ func NewParallelBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis
 		return nil, err
 	}
 
-	// Open trie database with provided config
-	triedb := trie.NewDatabaseWithConfig(db, &trie.Config{
-		Cache:     cacheConfig.TrieCleanLimit,
-		Preimages: cacheConfig.Preimages,
-	})
-	chainConfig, _, genesisErr := SetupGenesisBlockWithOverride(db, triedb, genesis, overrides)
+	// Reuse the trie database of the chain, the path-based one can only be
+	// opened once.
+	chainConfig, _, genesisErr := SetupGenesisBlockWithOverride(db, bc.triedb, genesis, overrides)
 
 	if _, ok := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !ok {
 		return nil, genesisErr
@@ -519,6 +547,7 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		err      error
 		statedb  *state.StateDB
 		counter  metrics.Counter
+		executed *atomic.Uint64
 	}
 
 	resultChan := make(chan Result, 2)
@@ -536,7 +565,7 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		go func() {
 			parallelStatedb.StartPrefetcher("chain")
 			receipts, logs, usedGas, err := bc.parallelProcessor.Process(block, parallelStatedb, bc.vmConfig, ctx)
-			resultChan <- Result{receipts, logs, usedGas, err, parallelStatedb, blockExecutionParallelCounter}
+			resultChan <- Result{receipts, logs, usedGas, err, parallelStatedb, blockExecutionParallelCounter, &bc.parallelExecutions}
 		}()
 	}
 
@@ -551,7 +580,7 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 		go func() {
 			statedb.StartPrefetcher("chain")
 			receipts, logs, usedGas, err := bc.processor.Process(block, statedb, bc.vmConfig, ctx)
-			resultChan <- Result{receipts, logs, usedGas, err, statedb, blockExecutionSerialCounter}
+			resultChan <- Result{receipts, logs, usedGas, err, statedb, blockExecutionSerialCounter, &bc.serialExecutions}
 		}()
 	}
 
@@ -569,6 +598,7 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 	}
 
 	result.counter.Inc(1)
+	result.executed.Add(1)
 
 	// Make sure we are not leaking any prefetchers
 	if processorCount == 2 {
@@ -581,6 +611,13 @@ func (bc *BlockChain) ProcessBlock(block *types.Block, parent *types.Header) (ty
 	return result.receipts, result.logs, result.usedGas, result.statedb, result.err
 }
 
+// BlockExecutionStats returns whether the parallel state processor (block-STM)
+// is enabled, and the number of blocks executed by the parallel and the serial
+// processors since startup.
+func (bc *BlockChain) BlockExecutionStats() (bool, uint64, uint64) {
+	return bc.parallelProcessor != nil, bc.parallelExecutions.Load(), bc.serialExecutions.Load()
+}
+
 // empty returns an indicator whether the blockchain is empty.
 // Note, it's a special case that we connect a non-empty ancient
 // database with an empty node, so that we can plugin the ancient
@@ -910,6 +947,7 @@ func (bc *BlockChain) setHeadBeyondRoot(head uint64, time uint64, root common.Ha
 			rawdb.DeleteReceipts(db, hash, num)
 			rawdb.DeleteBorReceipt(db, hash, num)
 			rawdb.DeleteBorTxLookupEntry(db, hash, num)
+			rawdb.DeleteBorStateSyncs(bc.db, db, hash, num)
 		}
 		// Todo(rjl493456442) txlookup, bloombits, etc
 	}
@@ -1149,7 +1187,13 @@ func (bc *BlockChain) Stop() {
 	//  - HEAD:     So we don't need to reprocess any blocks in the general case
 	//  - HEAD-1:   So we don't do large reorgs if our HEAD becomes an uncle
 	//  - HEAD-127: So we have a hard limit on the number of blocks reexecuted
-	if !bc.cacheConfig.TrieDirtyDisabled {
+	//
+	// The path-based scheme journals the in-memory layers instead.
+	if bc.triedb.Scheme() == rawdb.PathScheme {
+		if err := bc.triedb.Journal(bc.CurrentBlock().Root); err != nil {
+			log.Error("Failed to journal in-memory trie nodes", "err", err)
+		}
+	} else if !bc.cacheConfig.TrieDirtyDisabled {
 		triedb := bc.triedb
 
 		for _, offset := range []uint64{0, 1, bc.cacheConfig.TriesInMemory - 1} {
@@ -1642,6 +1686,9 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 
 			// Write bor tx reverse lookup
 			rawdb.WriteBorTxLookupEntry(blockBatch, block.Hash(), block.NumberU64())
+
+			// Index the state-sync events committed by the block
+			rawdb.WriteBorStateSyncs(blockBatch, block.Hash(), block.NumberU64(), bc.stateSyncData)
 		}
 	}
 
@@ -1655,6 +1702,11 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	if err != nil {
 		return []*types.Log{}, err
 	}
+	// If node is running in path mode, skip explicit gc operation
+	// which is unnecessary in this mode.
+	if bc.triedb.Scheme() == rawdb.PathScheme {
+		return stateSyncLogs, nil
+	}
 	// If we're running an archive node, always flush
 	if bc.cacheConfig.TrieDirtyDisabled {
 		return []*types.Log{}, bc.triedb.Commit(root, false)
@@ -3037,7 +3089,13 @@ func (bc *BlockChain) maintainTxIndex() {
 		case head := <-headCh:
 			if done == nil {
 				done = make(chan struct{})
-				go bc.indexBlocks(rawdb.ReadTxIndexTail(bc.db), head.Block.NumberU64(), done)
+				go func(head uint64) {
+					// Don't race with the history expiry over the index tail
+					bc.historyLock.Lock()
+					defer bc.historyLock.Unlock()
+
+					bc.indexBlocks(rawdb.ReadTxIndexTail(bc.db), head, done)
+				}(head.Block.NumberU64())
 			}
 		case <-done:
 			done = nil
@@ -0,0 +1,178 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// HistoryTail returns the number of the oldest block whose header, body and
+// receipts are still available. Blocks below it were removed by history expiry.
+func (bc *BlockChain) HistoryTail() uint64 {
+	tail, err := bc.db.Tail()
+	if err != nil {
+		return 0
+	}
+
+	return tail
+}
+
+// maintainHistory is responsible for expiring the chain history which is older
+// than the configured retention window.
+//
+// User can use flag `history.blocks` to specify the number of recent blocks whose
+// headers, bodies and receipts are reserved. Only the ancient store is truncated,
+// so the history is retained for at least params.FullImmutabilityThreshold blocks.
+func (bc *BlockChain) maintainHistory() {
+	defer bc.wg.Done()
+
+	// Listening to chain events and expire the history in the background.
+	var (
+		done   chan struct{}                  // Non-nil if background expiry routine is active.
+		headCh = make(chan ChainHeadEvent, 1) // Buffered to avoid locking up the event feed
+	)
+
+	sub := bc.SubscribeChainHeadEvent(headCh)
+	if sub == nil {
+		return
+	}
+
+	defer sub.Unsubscribe()
+
+	for {
+		select {
+		case head := <-headCh:
+			if done == nil {
+				done = make(chan struct{})
+
+				go func(head uint64) {
+					defer close(done)
+
+					if err := bc.pruneHistory(head); err != nil {
+						log.Error("Failed to expire chain history", "err", err)
+					}
+				}(head.Block.NumberU64())
+			}
+		case <-done:
+			done = nil
+		case <-bc.quit:
+			if done != nil {
+				log.Info("Waiting background history expiry to exit")
+				<-done
+			}
+
+			return
+		}
+	}
+}
+
+// pruneHistory removes the frozen blocks below the retention window of the given
+// head from the ancient store. Before truncating the freezer, the transaction
+// lookup entries, the bor transaction lookup entries and the state-sync events
+// of the expired blocks are dropped, while their bodies are still readable.
+func (bc *BlockChain) pruneHistory(head uint64) error {
+	if head < bc.cacheConfig.BlockHistory {
+		return nil
+	}
+
+	frozen, err := bc.db.Ancients()
+	if err != nil {
+		return err
+	}
+
+	tail, err := bc.db.Tail()
+	if err != nil {
+		return err
+	}
+
+	target := head - bc.cacheConfig.BlockHistory + 1
+	if target > frozen {
+		target = frozen
+	}
+
+	if target <= tail {
+		return nil
+	}
+
+	// Don't race with the transaction indexer over the index tail
+	bc.historyLock.Lock()
+	defer bc.historyLock.Unlock()
+
+	var (
+		start = time.Now()
+		batch = bc.db.NewBatch()
+	)
+
+	// flush commits the dropped indices and expires the blocks below next
+	flush := func(next uint64) error {
+		if indexTail := rawdb.ReadTxIndexTail(bc.db); indexTail != nil && *indexTail < next {
+			rawdb.WriteTxIndexTail(batch, next)
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		batch.Reset()
+
+		_, err := bc.db.TruncateTail(next)
+
+		return err
+	}
+
+	for number := tail; number < target; number++ {
+		hash := rawdb.ReadCanonicalHash(bc.db, number)
+
+		if body := rawdb.ReadBody(bc.db, hash, number); body != nil {
+			hashes := make([]common.Hash, 0, len(body.Transactions))
+			for _, tx := range body.Transactions {
+				hashes = append(hashes, tx.Hash())
+			}
+
+			rawdb.DeleteTxLookupEntries(batch, hashes)
+		}
+
+		rawdb.DeleteBorTxLookupEntry(batch, hash, number)
+		rawdb.DeleteBorStateSyncs(bc.db, batch, hash, number)
+
+		if batch.ValueSize() > ethdb.IdealBatchSize {
+			if err := flush(number + 1); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-bc.quit:
+			log.Debug("Chain history expiry interrupted", "tail", number+1, "elapsed", common.PrettyDuration(time.Since(start)))
+			return flush(number + 1)
+		default:
+		}
+	}
+
+	if err := flush(target); err != nil {
+		return err
+	}
+
+	log.Info("Expired chain history", "blocks", target-tail, "tail", target, "elapsed", common.PrettyDuration(time.Since(start)))
+
+	return nil
+}
@@ -1913,6 +1913,114 @@ func TestLargeReorgTrieGC(t *testing.T) {
 	}
 }
 
+// Tests that a chain using the path-based state scheme keeps the state of the
+// head block across restarts.
+func TestPathSchemeStateRestart(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000)
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{address: {Balance: funds}}}
+		signer  = types.LatestSigner(gspec.Config)
+		config  = &CacheConfig{
+			TrieCleanLimit: 256,
+			TrieDirtyLimit: 256,
+			TrieTimeLimit:  5 * time.Minute,
+			TriesInMemory:  128,
+			StateHistory:   16,
+			StateScheme:    rawdb.PathScheme,
+		}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 32, func(i int, gen *BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(address), common.Address{0x01}, big.NewInt(1), params.TxGas, gen.header.BaseFee, nil), signer, key)
+		gen.AddTx(tx)
+	})
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create temp freezer db: %v", err)
+	}
+	defer db.Close()
+
+	chain, err := NewBlockChain(db, config, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	if scheme := chain.TrieDB().Scheme(); scheme != rawdb.PathScheme {
+		t.Fatalf("state scheme mismatch: have %s, want %s", scheme, rawdb.PathScheme)
+	}
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block %d: %v", n, err)
+	}
+	chain.Stop()
+
+	if scheme := rawdb.ReadStateScheme(db); scheme != rawdb.PathScheme {
+		t.Fatalf("stored state scheme mismatch: have %s, want %s", scheme, rawdb.PathScheme)
+	}
+	// Reopen the chain and ensure the head state was journaled
+	chain, err = NewBlockChain(db, config, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to reopen chain: %v", err)
+	}
+	defer chain.Stop()
+
+	head := chain.CurrentBlock()
+	if head.Hash() != blocks[len(blocks)-1].Hash() {
+		t.Fatalf("head block mismatch: have %d, want %d", head.Number, blocks[len(blocks)-1].NumberU64())
+	}
+	statedb, err := chain.StateAt(head.Root)
+	if err != nil {
+		t.Fatalf("head state unavailable: %v", err)
+	}
+	if nonce := statedb.GetNonce(address); nonce != uint64(len(blocks)) {
+		t.Fatalf("nonce mismatch: have %d, want %d", nonce, len(blocks))
+	}
+}
+
+// Tests that the genesis set up over a trie database of the configured scheme,
+// closed afterwards, is picked up by the chain.
+func TestPathSchemeGenesisSetup(t *testing.T) {
+	var (
+		gspec  = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{common.Address{0x01}: {Balance: big.NewInt(1)}}}
+		config = &CacheConfig{
+			TrieCleanLimit: 256,
+			TrieDirtyLimit: 256,
+			TrieTimeLimit:  5 * time.Minute,
+			TriesInMemory:  128,
+			StateHistory:   16,
+			StateScheme:    rawdb.PathScheme,
+		}
+	)
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create temp freezer db: %v", err)
+	}
+	defer db.Close()
+
+	triedb := trie.NewDatabaseWithConfig(db, config.TriedbConfig())
+	if _, _, err := SetupGenesisBlockWithOverride(db, triedb, gspec, nil); err != nil {
+		t.Fatalf("failed to setup genesis: %v", err)
+	}
+	if err := triedb.Close(); err != nil {
+		t.Fatalf("failed to close trie database: %v", err)
+	}
+	if scheme := rawdb.ReadStateScheme(db); scheme != rawdb.PathScheme {
+		t.Fatalf("stored state scheme mismatch: have %s, want %s", scheme, rawdb.PathScheme)
+	}
+	chain, err := NewBlockChain(db, config, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	statedb, err := chain.StateAt(chain.Genesis().Root())
+	if err != nil {
+		t.Fatalf("genesis state unavailable: %v", err)
+	}
+	if balance := statedb.GetBalance(common.Address{0x01}); balance.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("balance mismatch: have %v, want 1", balance)
+	}
+}
+
 func TestBlockchainRecovery(t *testing.T) {
 	// Configure and generate a sample block chain
 	var (
@@ -2883,6 +2991,87 @@ func TestTransactionIndices(t *testing.T) {
 	}
 }
 
+// Tests that history expiry truncates the expired blocks from the ancient store
+// along with their transaction indices, while retaining the configured window.
+func TestHistoryExpiry(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(100000000000000000)
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{address: {Balance: funds}}}
+		signer  = types.LatestSigner(gspec.Config)
+	)
+
+	_, blocks, receipts := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 128, func(i int, block *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{0x00}, big.NewInt(1000), params.TxGas, block.header.BaseFee, nil), signer, key)
+		if err != nil {
+			panic(err)
+		}
+		block.AddTx(tx)
+	})
+
+	ancientDb, _ := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	defer ancientDb.Close()
+
+	_, _ = rawdb.WriteAncientBlocks(ancientDb, append([]*types.Block{gspec.ToBlock()}, blocks...), append([]types.Receipts{{}}, receipts...), make([]types.Receipts, len(blocks)+1), big.NewInt(0))
+
+	cacheConfig := *defaultCacheConfig
+	cacheConfig.BlockHistory = 32
+
+	limit := uint64(0)
+
+	chain, err := NewBlockChain(ancientDb, &cacheConfig, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, &limit, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if chain.txLookupLimit != 32 {
+		t.Fatalf("transaction index limit mismatch: have %d, want 32", chain.txLookupLimit)
+	}
+
+	chain.indexBlocks(nil, 128, make(chan struct{}))
+
+	if err := chain.pruneHistory(128); err != nil {
+		t.Fatalf("failed to expire history: %v", err)
+	}
+
+	if tail := chain.HistoryTail(); tail != 97 {
+		t.Fatalf("history tail mismatch: have %d, want 97", tail)
+	}
+
+	if tail := rawdb.ReadTxIndexTail(ancientDb); tail == nil || *tail != 97 {
+		t.Fatalf("transaction index tail mismatch: have %v, want 97", tail)
+	}
+
+	for _, block := range blocks {
+		number := block.NumberU64()
+
+		var (
+			header = chain.GetHeaderByNumber(number)
+			body   = chain.GetBody(block.Hash())
+			index  = rawdb.ReadTxLookupEntry(ancientDb, block.Transactions()[0].Hash())
+		)
+
+		if number < 97 {
+			if header != nil || body != nil || index != nil {
+				t.Fatalf("block %d not expired: header %v, body %v, index %v", number, header != nil, body != nil, index != nil)
+			}
+		} else if header == nil || body == nil || index == nil {
+			t.Fatalf("block %d missing: header %v, body %v, index %v", number, header != nil, body != nil, index != nil)
+		}
+	}
+
+	// The retained window is reached, another round is a noop
+	if err := chain.pruneHistory(128); err != nil {
+		t.Fatalf("failed to expire history: %v", err)
+	}
+
+	if tail := chain.HistoryTail(); tail != 97 {
+		t.Fatalf("history tail mismatch: have %d, want 97", tail)
+	}
+}
+
 func TestSkipStaleTxIndicesInSnapSync(t *testing.T) {
 	// Configure and generate a sample block chain
 	var (
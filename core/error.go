@@ -32,6 +32,10 @@ var (
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
 
+	// ErrHistoryPruned is returned when the requested block was removed from
+	// the database by history expiry.
+	ErrHistoryPruned = errors.New("pruned history unavailable")
+
 	errSideChainReceipts = errors.New("side blocks can't be accepted as ancient chain data")
 )
 
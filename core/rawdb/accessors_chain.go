@@ -916,6 +916,53 @@ func writeAncientBlock(op ethdb.AncientWriteOp, block *types.Block, header *type
 	return nil
 }
 
+// AncientBlockRLP is a block along with its receipts, bor receipt and total
+// difficulty, RLP encoded as they are stored by the chain freezer.
+type AncientBlockRLP struct {
+	Hash       common.Hash
+	Header     rlp.RawValue
+	Body       rlp.RawValue
+	Receipts   rlp.RawValue
+	Td         rlp.RawValue
+	BorReceipt rlp.RawValue // Empty if the block has no bor receipt
+}
+
+// WriteAncientBlocksRLP appends a contiguous range of RLP encoded blocks, the
+// first one numbered first, to the ancient store.
+func WriteAncientBlocksRLP(db ethdb.AncientWriter, first uint64, blocks []*AncientBlockRLP) (int64, error) {
+	return db.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		for i, block := range blocks {
+			num := first + uint64(i)
+
+			if err := op.AppendRaw(ChainFreezerHashTable, num, block.Hash.Bytes()); err != nil {
+				return fmt.Errorf("can't add block %d hash: %v", num, err)
+			}
+
+			if err := op.AppendRaw(ChainFreezerHeaderTable, num, block.Header); err != nil {
+				return fmt.Errorf("can't append block header %d: %v", num, err)
+			}
+
+			if err := op.AppendRaw(ChainFreezerBodiesTable, num, block.Body); err != nil {
+				return fmt.Errorf("can't append block body %d: %v", num, err)
+			}
+
+			if err := op.AppendRaw(ChainFreezerReceiptTable, num, block.Receipts); err != nil {
+				return fmt.Errorf("can't append block %d receipts: %v", num, err)
+			}
+
+			if err := op.AppendRaw(ChainFreezerDifficultyTable, num, block.Td); err != nil {
+				return fmt.Errorf("can't append block %d total difficulty: %v", num, err)
+			}
+
+			if err := op.AppendRaw(freezerBorReceiptTable, num, block.BorReceipt); err != nil {
+				return fmt.Errorf("can't append block %d borReceipts: %v", num, err)
+			}
+		}
+
+		return nil
+	})
+}
+
 // DeleteBlock removes all block data associated with a hash.
 func DeleteBlock(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
 	DeleteReceipts(db, hash, number)
@@ -269,3 +269,58 @@ func DeleteTrieNode(db ethdb.KeyValueWriter, owner common.Hash, path []byte, has
 		panic(fmt.Sprintf("Unknown scheme %v", scheme))
 	}
 }
+
+// ReadStateScheme reads the state scheme of the persistent state, or none if
+// the state is not present in the database.
+func ReadStateScheme(db ethdb.Reader) string {
+	// Check if state in path-based scheme is present
+	if blob, _ := ReadAccountTrieNode(db, nil); len(blob) != 0 {
+		return PathScheme
+	}
+	// The root node might be deleted during the initial snap sync, check
+	// the persistent state id then.
+	if id := ReadPersistentStateID(db); id != 0 {
+		return PathScheme
+	}
+	// In a hash-based scheme, the genesis state is consistently stored on the
+	// disk. To assess the scheme of the persistent state, it suffices to inspect
+	// the scheme of the genesis state.
+	header := ReadHeader(db, ReadCanonicalHash(db, 0), 0)
+	if header == nil {
+		return "" // empty datadir
+	}
+
+	if blob := ReadLegacyTrieNode(db, header.Root); len(blob) == 0 {
+		return "" // no state in disk
+	}
+
+	return HashScheme
+}
+
+// ParseStateScheme checks if the specified state scheme is compatible with the
+// stored state. If no scheme is provided, the stored one is used, falling back
+// to the hash-based scheme for an empty database.
+func ParseStateScheme(provided string, disk ethdb.Database) (string, error) {
+	if provided != "" && provided != HashScheme && provided != PathScheme {
+		return "", fmt.Errorf("unknown state scheme %q", provided)
+	}
+
+	stored := ReadStateScheme(disk)
+	if provided == "" {
+		if stored == "" {
+			log.Info("State scheme set to default", "scheme", HashScheme)
+			return HashScheme, nil
+		}
+
+		log.Info("State scheme set to already existing", "scheme", stored)
+
+		return stored, nil
+	}
+
+	if stored == "" || provided == stored {
+		log.Info("State scheme set by user", "scheme", provided)
+		return provided, nil
+	}
+
+	return "", fmt.Errorf("incompatible state scheme, stored: %s, provided: %s", stored, provided)
+}
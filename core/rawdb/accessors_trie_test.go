@@ -0,0 +1,54 @@
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Tests that the state scheme is detected from the persisted state, and that
+// a scheme conflicting with it is refused.
+func TestParseStateScheme(t *testing.T) {
+	// An empty database accepts any scheme, defaulting to the hash-based one
+	db := NewMemoryDatabase()
+
+	if scheme, err := ParseStateScheme("", db); err != nil || scheme != HashScheme {
+		t.Fatalf("empty database scheme mismatch: have %s/%v, want %s", scheme, err, HashScheme)
+	}
+	if scheme, err := ParseStateScheme(PathScheme, db); err != nil || scheme != PathScheme {
+		t.Fatalf("empty database scheme mismatch: have %s/%v, want %s", scheme, err, PathScheme)
+	}
+	if _, err := ParseStateScheme("unknown", db); err == nil {
+		t.Fatalf("unknown scheme accepted")
+	}
+	// A database with the genesis state stored by hash only accepts hash-based scheme
+	var (
+		node   = []byte{0xc1, 0x80}
+		header = &types.Header{Number: common.Big0, Root: crypto.Keccak256Hash(node)}
+	)
+	WriteHeader(db, header)
+	WriteCanonicalHash(db, header.Hash(), 0)
+	WriteLegacyTrieNode(db, header.Root, node)
+
+	if scheme := ReadStateScheme(db); scheme != HashScheme {
+		t.Fatalf("stored scheme mismatch: have %s, want %s", scheme, HashScheme)
+	}
+	if scheme, err := ParseStateScheme("", db); err != nil || scheme != HashScheme {
+		t.Fatalf("hash database scheme mismatch: have %s/%v, want %s", scheme, err, HashScheme)
+	}
+	if _, err := ParseStateScheme(PathScheme, db); err == nil {
+		t.Fatalf("path scheme accepted for hash database")
+	}
+	// A database with the state stored by path only accepts path-based scheme
+	db = NewMemoryDatabase()
+	WriteAccountTrieNode(db, nil, node)
+
+	if scheme, err := ParseStateScheme("", db); err != nil || scheme != PathScheme {
+		t.Fatalf("path database scheme mismatch: have %s/%v, want %s", scheme, err, PathScheme)
+	}
+	if _, err := ParseStateScheme(HashScheme, db); err == nil {
+		t.Fatalf("hash scheme accepted for path database")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	// borStateSyncsPrefix + num (uint64 big endian) + hash -> state-sync events committed in the block
+	borStateSyncsPrefix = []byte("matic-bor-state-syncs-")
+
+	// borStateSyncIDPrefix + id (uint64 big endian) + hash -> number of the block committing the event
+	borStateSyncIDPrefix = []byte("matic-bor-state-sync-id-")
+)
+
+// borStateSyncsKey = borStateSyncsPrefix + num (uint64 big endian) + hash
+func borStateSyncsKey(number uint64, hash common.Hash) []byte {
+	return append(append(append([]byte{}, borStateSyncsPrefix...), encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// borStateSyncIDKey = borStateSyncIDPrefix + id (uint64 big endian) + hash
+func borStateSyncIDKey(id uint64, hash common.Hash) []byte {
+	return append(borStateSyncIDKeyPrefix(id), hash.Bytes()...)
+}
+
+// borStateSyncIDKeyPrefix = borStateSyncIDPrefix + id (uint64 big endian)
+func borStateSyncIDKeyPrefix(id uint64) []byte {
+	return append(append([]byte{}, borStateSyncIDPrefix...), encodeBlockNumber(id)...)
+}
+
+// ReadBorStateSyncs retrieves the state-sync events committed in a block.
+func ReadBorStateSyncs(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.StateSyncData {
+	data, _ := db.Get(borStateSyncsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+
+	var events []*types.StateSyncData
+	if err := rlp.DecodeBytes(data, &events); err != nil {
+		log.Error("Invalid state-sync events RLP", "hash", hash, "number", number, "err", err)
+		return nil
+	}
+
+	return events
+}
+
+// WriteBorStateSyncs stores the state-sync events committed in a block, along
+// with a lookup entry from each event ID to the block.
+func WriteBorStateSyncs(db ethdb.KeyValueWriter, hash common.Hash, number uint64, events []*types.StateSyncData) {
+	if len(events) == 0 {
+		return
+	}
+
+	data, err := rlp.EncodeToBytes(events)
+	if err != nil {
+		log.Crit("Failed to encode state-sync events", "err", err)
+	}
+
+	if err := db.Put(borStateSyncsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store state-sync events", "err", err)
+	}
+
+	for _, event := range events {
+		if err := db.Put(borStateSyncIDKey(event.ID, hash), encodeBlockNumber(number)); err != nil {
+			log.Crit("Failed to store state-sync event lookup entry", "err", err)
+		}
+	}
+}
+
+// DeleteBorStateSyncs removes the state-sync events committed in a block along
+// with the event ID lookup entries pointing to it. The events are read from db
+// and deleted through batch.
+func DeleteBorStateSyncs(db ethdb.KeyValueReader, batch ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	for _, event := range ReadBorStateSyncs(db, hash, number) {
+		if err := batch.Delete(borStateSyncIDKey(event.ID, hash)); err != nil {
+			log.Crit("Failed to delete state-sync event lookup entry", "err", err)
+		}
+	}
+
+	if err := batch.Delete(borStateSyncsKey(number, hash)); err != nil {
+		log.Crit("Failed to delete state-sync events", "err", err)
+	}
+}
+
+// ReadBorStateSyncLookupEntry retrieves the canonical block which committed the
+// state-sync event with the given ID. As an event may have been committed by
+// several blocks across reorgs, only the one on the canonical chain is returned.
+func ReadBorStateSyncLookupEntry(db ethdb.Database, id uint64) (common.Hash, uint64, bool) {
+	prefix := borStateSyncIDKeyPrefix(id)
+
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if len(it.Key()) != len(prefix)+common.HashLength || len(it.Value()) != 8 {
+			continue
+		}
+
+		var (
+			hash   = common.BytesToHash(it.Key()[len(prefix):])
+			number = binary.BigEndian.Uint64(it.Value())
+		)
+
+		if ReadCanonicalHash(db, number) == hash {
+			return hash, number, true
+		}
+	}
+
+	return common.Hash{}, 0, false
+}
+
+// ReadBorStateSync retrieves the state-sync event with the given ID along with
+// the canonical block which committed it.
+func ReadBorStateSync(db ethdb.Database, id uint64) (*types.StateSyncData, common.Hash, uint64) {
+	hash, number, ok := ReadBorStateSyncLookupEntry(db, id)
+	if !ok {
+		return nil, common.Hash{}, 0
+	}
+
+	for _, event := range ReadBorStateSyncs(db, hash, number) {
+		if event.ID == id {
+			return event, hash, number
+		}
+	}
+
+	return nil, common.Hash{}, 0
+}
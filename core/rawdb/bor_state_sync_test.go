@@ -0,0 +1,82 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that state-sync events can be looked up by ID, resolving to the block
+// on the canonical chain if the events were committed by several blocks.
+func TestBorStateSyncStorage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	var (
+		events = []*types.StateSyncData{
+			{ID: 1, Contract: common.Address{0x01}, Data: "01", TxHash: common.Hash{0x01}},
+			{ID: 2, Contract: common.Address{0x02}, Data: "02", TxHash: common.Hash{0x02}},
+		}
+		sideHash  = common.Hash{0xaa}
+		canonHash = common.Hash{0xbb}
+	)
+	// Commit the events in a side block first, then in the canonical one
+	WriteBorStateSyncs(db, sideHash, 16, events)
+	WriteBorStateSyncs(db, canonHash, 16, events)
+	WriteCanonicalHash(db, canonHash, 16)
+
+	if stored := ReadBorStateSyncs(db, sideHash, 16); len(stored) != len(events) {
+		t.Fatalf("side block events mismatch: have %d, want %d", len(stored), len(events))
+	}
+	for _, want := range events {
+		event, hash, number := ReadBorStateSync(db, want.ID)
+		if event == nil {
+			t.Fatalf("event %d not found", want.ID)
+		}
+		if *event != *want {
+			t.Fatalf("event %d mismatch: have %+v, want %+v", want.ID, event, want)
+		}
+		if hash != canonHash || number != 16 {
+			t.Fatalf("event %d location mismatch: have %x/%d, want %x/16", want.ID, hash, number, canonHash)
+		}
+	}
+	if event, _, _ := ReadBorStateSync(db, 3); event != nil {
+		t.Fatalf("unknown event found: %+v", event)
+	}
+	// Freezing the canonical block must leave its events in place
+	DeleteBlockWithoutNumber(db, canonHash, 16)
+
+	if event, _, _ := ReadBorStateSync(db, 1); event == nil {
+		t.Fatalf("event dropped on block freeze")
+	}
+	// Drop the blocks and ensure the events and their lookup entries are gone
+	DeleteBorStateSyncs(db, db, sideHash, 16)
+	DeleteBorStateSyncs(db, db, canonHash, 16)
+
+	if event, _, _ := ReadBorStateSync(db, 1); event != nil {
+		t.Fatalf("deleted event found: %+v", event)
+	}
+	for _, event := range events {
+		for _, hash := range []common.Hash{sideHash, canonHash} {
+			if has, _ := db.Has(borStateSyncIDKey(event.ID, hash)); has {
+				t.Fatalf("event %d lookup entry for %x not deleted", event.ID, hash)
+			}
+		}
+	}
+}
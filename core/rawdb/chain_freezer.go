@@ -219,6 +219,7 @@ func (f *chainFreezer) freeze(db ethdb.KeyValueStore) {
 				dangling = ReadAllHashes(db, number)
 				for _, hash := range dangling {
 					log.Trace("Deleting side chain", "number", number, "hash", hash)
+					DeleteBorStateSyncs(db, batch, hash, number)
 					DeleteBlock(batch, hash, number)
 				}
 			}
@@ -260,6 +261,7 @@ func (f *chainFreezer) freeze(db ethdb.KeyValueStore) {
 					}
 					// Delete all block data associated with the child
 					log.Debug("Deleting dangling block", "number", tip, "hash", children[i], "parent", child.ParentHash)
+					DeleteBorStateSyncs(db, batch, children[i], tip)
 					DeleteBlock(batch, children[i], tip)
 				}
 
@@ -0,0 +1,61 @@
+package rawdb
+
+import (
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// peerBanPrefix + node id -> ban of the peer
+var peerBanPrefix = []byte("peer-ban-")
+
+// PeerBan is a timed ban of a peer, persisted across restarts.
+type PeerBan struct {
+	ID     string // Node ID of the peer, in hex
+	Until  uint64 // Unix time the ban expires at
+	Reason string // Why the peer got banned
+}
+
+// peerBanKey = peerBanPrefix + node id
+func peerBanKey(id string) []byte {
+	return append(append([]byte{}, peerBanPrefix...), id...)
+}
+
+// ReadPeerBans retrieves all the stored peer bans, expired or not.
+func ReadPeerBans(db ethdb.Iteratee) []*PeerBan {
+	it := db.NewIterator(peerBanPrefix, nil)
+	defer it.Release()
+
+	var bans []*PeerBan
+
+	for it.Next() {
+		ban := new(PeerBan)
+		if err := rlp.DecodeBytes(it.Value(), ban); err != nil {
+			log.Error("Invalid peer ban RLP", "key", string(it.Key()), "err", err)
+			continue
+		}
+
+		bans = append(bans, ban)
+	}
+
+	return bans
+}
+
+// WritePeerBan stores the ban of a peer, replacing any previous one.
+func WritePeerBan(db ethdb.KeyValueWriter, ban *PeerBan) {
+	data, err := rlp.EncodeToBytes(ban)
+	if err != nil {
+		log.Crit("Failed to RLP encode peer ban", "err", err)
+	}
+
+	if err := db.Put(peerBanKey(ban.ID), data); err != nil {
+		log.Crit("Failed to store peer ban", "err", err)
+	}
+}
+
+// DeletePeerBan removes the ban of a peer.
+func DeletePeerBan(db ethdb.KeyValueWriter, id string) {
+	if err := db.Delete(peerBanKey(id)); err != nil {
+		log.Crit("Failed to delete peer ban", "err", err)
+	}
+}
@@ -54,6 +54,10 @@ const (
 	rangeCompactionThreshold = 100000
 )
 
+// ErrPathScheme is returned when pruning a database holding the state in the
+// path-based scheme, which doesn't leave any stale state behind.
+var ErrPathScheme = errors.New("offline pruning is not supported by the path-based state scheme")
+
 // Config includes all the configurations for pruning.
 type Config struct {
 	Datadir   string // The directory of the state database
@@ -81,6 +85,10 @@ type Pruner struct {
 
 // NewPruner creates the pruner instance.
 func NewPruner(db ethdb.Database, config Config) (*Pruner, error) {
+	// The path-based scheme deletes the stale trie nodes on its own
+	if rawdb.ReadStateScheme(db) == rawdb.PathScheme {
+		return nil, ErrPathScheme
+	}
 	headBlock := rawdb.ReadHeadBlock(db)
 	if headBlock == nil {
 		return nil, errors.New("failed to load head block")
@@ -54,4 +54,8 @@ var (
 	// ErrFutureReplacePending is returned if a future transaction replaces a pending
 	// transaction. Future transactions should only be able to replace other future transactions.
 	ErrFutureReplacePending = errors.New("future transaction tries to replace pending")
+
+	// ErrPrivateTxUnsupported is returned if a private transaction is submitted
+	// to a pool which has no private subpool configured.
+	ErrPrivateTxUnsupported = errors.New("private transactions not supported")
 )
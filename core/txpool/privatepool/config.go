@@ -0,0 +1,60 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatepool
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Config are the configuration parameters of the private transaction pool.
+type Config struct {
+	Lifetime     uint64 // Number of blocks a private transaction is kept before being evicted
+	GlobalSlots  uint64 // Maximum number of private transactions tracked across all accounts
+	AccountSlots uint64 // Maximum number of private transactions tracked per account
+	PriceBump    uint64 // Minimum price bump percentage to replace an already existing nonce
+}
+
+// DefaultConfig contains the default configurations for the private pool.
+var DefaultConfig = Config{
+	Lifetime:     64,
+	GlobalSlots:  4096,
+	AccountSlots: 16,
+	PriceBump:    10,
+}
+
+// sanitize checks the provided user configurations and changes anything that's
+// unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.Lifetime < 1 {
+		log.Warn("Sanitizing invalid privatepool lifetime", "provided", conf.Lifetime, "updated", DefaultConfig.Lifetime)
+		conf.Lifetime = DefaultConfig.Lifetime
+	}
+	if conf.GlobalSlots < 1 {
+		log.Warn("Sanitizing invalid privatepool global slots", "provided", conf.GlobalSlots, "updated", DefaultConfig.GlobalSlots)
+		conf.GlobalSlots = DefaultConfig.GlobalSlots
+	}
+	if conf.AccountSlots < 1 {
+		log.Warn("Sanitizing invalid privatepool account slots", "provided", conf.AccountSlots, "updated", DefaultConfig.AccountSlots)
+		conf.AccountSlots = DefaultConfig.AccountSlots
+	}
+	if conf.PriceBump < 1 {
+		log.Warn("Sanitizing invalid privatepool price bump", "provided", conf.PriceBump, "updated", DefaultConfig.PriceBump)
+		conf.PriceBump = DefaultConfig.PriceBump
+	}
+	return conf
+}
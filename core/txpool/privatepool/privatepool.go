@@ -0,0 +1,532 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package privatepool implements a transaction pool for privately submitted
+// transactions, which are only offered to the local block producer.
+package privatepool
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// txMaxSize is the maximum size a single private transaction can have. It
+	// mirrors the limit of the legacy pool to avoid the private path being used
+	// to sneak in transactions the public one would reject.
+	txMaxSize = 128 * 1024
+)
+
+var (
+	// ErrPrivatePoolOverflow is returned if the private pool is full and can't
+	// accept another transaction.
+	ErrPrivatePoolOverflow = errors.New("private txpool is full")
+
+	// ErrSenderReserved is returned if the sender of a private transaction has
+	// transactions pending in another subpool. An account is tracked by a single
+	// subpool at a time, so its private and public transactions can't be mixed.
+	ErrSenderReserved = errors.New("sender has transactions in the public txpool")
+)
+
+var (
+	pendingGauge = metrics.NewRegisteredGauge("txpool/private/pending", nil)
+	queuedGauge  = metrics.NewRegisteredGauge("txpool/private/queued", nil)
+	expiredMeter = metrics.NewRegisteredMeter("txpool/private/expired", nil)
+)
+
+// privateTx is a transaction tracked by the private pool alongside the metadata
+// needed to expire it.
+type privateTx struct {
+	tx    *types.Transaction
+	time  time.Time // Time when the transaction was submitted
+	block uint64    // Head block number when the transaction was submitted
+}
+
+// PrivatePool is a transaction pool holding privately submitted transactions.
+// Its content is handed to the local miner through the usual pending listing,
+// but it is never announced to (nor served to) remote peers. Transactions not
+// included within a configured number of blocks are dropped.
+//
+// The pool is intentionally simple: transactions are validated against the head
+// state and ordered by nonce per account; there is no pricing-based eviction.
+//
+// Like every subpool, the private pool reserves the senders of its transactions:
+// while an account has private transactions, its public ones are refused by the
+// other subpools, and the other way around, until all of them are included or
+// dropped.
+type PrivatePool struct {
+	config  Config                  // Pool configuration
+	chain   BlockChain              // Chain object to access the state through
+	signer  types.Signer            // Transaction signer to recover senders with
+	reserve txpool.AddressReserver  // Address reserver to ensure exclusivity across subpools
+	gasTip  *big.Int                // Currently accepted minimum gas tip
+	head    *types.Header           // Current head of the chain
+	state   *state.StateDB          // Current state at the head of the chain
+	feed    event.Feed              // Event feed to send out new tx events on pool inclusion
+	scope   event.SubscriptionScope // Subscription scope to unsubscribe all on shutdown
+
+	all   map[common.Hash]*privateTx      // All transactions tracked by the pool
+	index map[common.Address][]*privateTx // Transactions grouped by account, sorted by nonce
+	lock  sync.RWMutex                    // Mutex protecting the pool's internals
+}
+
+// New creates a new private transaction pool. The pool is not operational until
+// it gets initialised by the main transaction pool via Init.
+func New(config Config, chain BlockChain) *PrivatePool {
+	config = (&config).sanitize()
+
+	return &PrivatePool{
+		config: config,
+		chain:  chain,
+		signer: types.LatestSigner(chain.Config()),
+		all:    make(map[common.Hash]*privateTx),
+		index:  make(map[common.Address][]*privateTx),
+	}
+}
+
+// Private marks the pool as a private subpool, hiding its content from the
+// networking layer.
+func (p *PrivatePool) Private() {}
+
+// Filter returns whether the given transaction can be consumed by the private
+// pool. Private transactions never arrive through the public submission path,
+// they are only injected via txpool.TxPool.AddPrivate, so this always rejects.
+func (p *PrivatePool) Filter(tx *types.Transaction) bool {
+	return false
+}
+
+// Init sets the gas price needed to keep a transaction in the pool and the chain
+// head to allow balance / nonce checks.
+func (p *PrivatePool) Init(gasTip *big.Int, head *types.Header, reserve txpool.AddressReserver) error {
+	statedb, err := p.chain.StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+	p.reserve = reserve
+	p.gasTip = new(big.Int).Set(gasTip)
+	p.head, p.state = head, statedb
+
+	return nil
+}
+
+// Close terminates any background processing threads and releases any held
+// resources.
+func (p *PrivatePool) Close() error {
+	p.scope.Close()
+	return nil
+}
+
+// Reset drops all the transactions which were included by the new head or
+// which outlived the configured block lifetime.
+func (p *PrivatePool) Reset(oldHead, newHead *types.Header) {
+	statedb, err := p.chain.StateAt(newHead.Root)
+	if err != nil {
+		log.Error("Failed to reset private pool state", "number", newHead.Number, "err", err)
+		return
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.head, p.state = newHead, statedb
+
+	for addr, txs := range p.index {
+		var (
+			nonce = p.state.GetNonce(addr)
+			keep  = txs[:0]
+		)
+		for _, ptx := range txs {
+			switch {
+			case ptx.tx.Nonce() < nonce:
+				delete(p.all, ptx.tx.Hash())
+
+			case newHead.Number.Uint64() >= ptx.block+p.config.Lifetime:
+				delete(p.all, ptx.tx.Hash())
+				expiredMeter.Mark(1)
+				log.Debug("Private transaction expired", "hash", ptx.tx.Hash(), "submitted", ptx.block, "head", newHead.Number)
+
+			default:
+				keep = append(keep, ptx)
+			}
+		}
+		p.setAccount(addr, keep)
+	}
+	p.updateGauges()
+}
+
+// SetGasTip updates the minimum price required by the subpool for a new
+// transaction, and drops all transactions below this threshold.
+func (p *PrivatePool) SetGasTip(tip *big.Int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.gasTip = new(big.Int).Set(tip)
+
+	for addr, txs := range p.index {
+		for i, ptx := range txs {
+			if ptx.tx.GasTipCapIntCmp(tip) < 0 {
+				// Dropping a transaction gaps all subsequent ones, drop them too
+				for _, drop := range txs[i:] {
+					delete(p.all, drop.tx.Hash())
+				}
+				p.setAccount(addr, txs[:i])
+				break
+			}
+		}
+	}
+	p.updateGauges()
+}
+
+// Has returns an indicator whether subpool has a transaction cached with the
+// given hash.
+func (p *PrivatePool) Has(hash common.Hash) bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.all[hash] != nil
+}
+
+// Get returns a transaction if it is contained in the pool, or nil otherwise.
+func (p *PrivatePool) Get(hash common.Hash) *txpool.Transaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if ptx := p.all[hash]; ptx != nil {
+		return &txpool.Transaction{Tx: ptx.tx}
+	}
+	return nil
+}
+
+// Add validates a batch of private transactions and inserts them into the pool.
+func (p *PrivatePool) Add(txs []*txpool.Transaction, local bool, sync bool) []error {
+	var (
+		errs  = make([]error, len(txs))
+		adds  = make([]*types.Transaction, 0, len(txs))
+		added = time.Now()
+	)
+	p.lock.Lock()
+	for i, tx := range txs {
+		if errs[i] = p.add(tx.Tx, added); errs[i] == nil {
+			adds = append(adds, tx.Tx)
+		}
+	}
+	p.updateGauges()
+	p.lock.Unlock()
+
+	if len(adds) > 0 {
+		p.feed.Send(core.NewTxsEvent{Txs: adds})
+	}
+	return errs
+}
+
+// add validates a single private transaction and inserts it into the pool. The
+// caller must hold the pool lock.
+func (p *PrivatePool) add(tx *types.Transaction, added time.Time) error {
+	hash := tx.Hash()
+	if p.all[hash] != nil {
+		return txpool.ErrAlreadyKnown
+	}
+	if err := p.validateTx(tx); err != nil {
+		return err
+	}
+	from, _ := types.Sender(p.signer, tx) // already validated
+
+	var (
+		txs  = p.index[from]
+		ptx  = &privateTx{tx: tx, time: added, block: p.head.Number.Uint64()}
+		slot = sort.Search(len(txs), func(i int) bool { return txs[i].tx.Nonce() >= tx.Nonce() })
+	)
+	// Handle replacements of an already tracked nonce
+	if slot < len(txs) && txs[slot].tx.Nonce() == tx.Nonce() {
+		prev := txs[slot].tx
+		if !p.bumped(prev, tx) {
+			return txpool.ErrReplaceUnderpriced
+		}
+		delete(p.all, prev.Hash())
+		txs[slot] = ptx
+		p.all[hash] = ptx
+
+		log.Debug("Replaced private transaction", "hash", hash, "old", prev.Hash(), "from", from, "nonce", tx.Nonce())
+		return nil
+	}
+	if uint64(len(p.all)) >= p.config.GlobalSlots {
+		return ErrPrivatePoolOverflow
+	}
+	// New account in the pool, request exclusive access to it
+	if len(txs) == 0 {
+		if err := p.reserve(from, true); err != nil {
+			return ErrSenderReserved
+		}
+	}
+	txs = append(txs, nil)
+	copy(txs[slot+1:], txs[slot:])
+	txs[slot] = ptx
+
+	p.index[from] = txs
+	p.all[hash] = ptx
+
+	log.Debug("Added private transaction", "hash", hash, "from", from, "nonce", tx.Nonce())
+	return nil
+}
+
+// validateTx checks whether a transaction is valid according to the consensus
+// rules and adheres to some heuristic limits of the local node.
+func (p *PrivatePool) validateTx(tx *types.Transaction) error {
+	opts := &txpool.ValidationOptions{
+		Config: p.chain.Config(),
+		Accept: 0 |
+			1<<types.LegacyTxType |
+			1<<types.AccessListTxType |
+			1<<types.DynamicFeeTxType,
+		MaxSize: txMaxSize,
+		MinTip:  p.gasTip,
+	}
+	if err := txpool.ValidateTransaction(tx, nil, nil, nil, p.head, p.signer, opts); err != nil {
+		return err
+	}
+	stateOpts := &txpool.ValidationOptionsWithState{
+		State: p.state,
+
+		FirstNonceGap: nil, // Pool allows arbitrary arrival order, don't invalidate nonce gaps
+		UsedAndLeftSlots: func(addr common.Address) (int, int) {
+			used := len(p.index[addr])
+			return used, int(p.config.AccountSlots) - used
+		},
+		ExistingExpenditure: func(addr common.Address) *big.Int {
+			spent := new(big.Int)
+			for _, ptx := range p.index[addr] {
+				spent.Add(spent, ptx.tx.Cost())
+			}
+			return spent
+		},
+		ExistingCost: func(addr common.Address, nonce uint64) *big.Int {
+			for _, ptx := range p.index[addr] {
+				if ptx.tx.Nonce() == nonce {
+					return ptx.tx.Cost()
+				}
+			}
+			return nil
+		},
+	}
+	return txpool.ValidateTransactionWithState(tx, p.signer, stateOpts)
+}
+
+// bumped returns whether the replacement transaction pays enough over the old
+// one to be allowed to take its place.
+func (p *PrivatePool) bumped(old, tx *types.Transaction) bool {
+	var (
+		bump = big.NewInt(int64(100 + p.config.PriceBump))
+		cent = big.NewInt(100)
+
+		feeCap = new(big.Int).Div(new(big.Int).Mul(old.GasFeeCap(), bump), cent)
+		tipCap = new(big.Int).Div(new(big.Int).Mul(old.GasTipCap(), bump), cent)
+	)
+	return tx.GasFeeCapIntCmp(feeCap) >= 0 && tx.GasTipCapIntCmp(tipCap) >= 0
+}
+
+// setAccount replaces the tracked transactions of an account, releasing the
+// address reservation if none are left. The caller must hold the pool lock.
+func (p *PrivatePool) setAccount(addr common.Address, txs []*privateTx) {
+	if len(txs) > 0 {
+		p.index[addr] = txs
+		return
+	}
+	delete(p.index, addr)
+
+	if err := p.reserve(addr, false); err != nil {
+		log.Error("Failed to release private pool reservation", "address", addr, "err", err)
+	}
+}
+
+// executable returns the number of leading transactions of an account which
+// form a gapless nonce sequence on top of the current state. The caller must
+// hold the pool lock.
+func (p *PrivatePool) executable(addr common.Address) int {
+	var (
+		txs  = p.index[addr]
+		next = p.state.GetNonce(addr)
+	)
+	for i, ptx := range txs {
+		if ptx.tx.Nonce() != next+uint64(i) {
+			return i
+		}
+	}
+	return len(txs)
+}
+
+// Pending retrieves all currently processable transactions, grouped by origin
+// account and sorted by nonce.
+func (p *PrivatePool) Pending(enforceTips bool) map[common.Address][]*txpool.LazyTransaction {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	pending := make(map[common.Address][]*txpool.LazyTransaction)
+	for addr, txs := range p.index {
+		var lazies []*txpool.LazyTransaction
+		for _, ptx := range txs[:p.executable(addr)] {
+			if enforceTips {
+				tip, err := ptx.tx.EffectiveGasTip(p.head.BaseFee)
+				if err != nil || tip.Cmp(p.gasTip) < 0 {
+					break
+				}
+			}
+			lazies = append(lazies, &txpool.LazyTransaction{
+				Pool:      p,
+				Hash:      ptx.tx.Hash(),
+				Tx:        &txpool.Transaction{Tx: ptx.tx},
+				Time:      ptx.time,
+				GasFeeCap: ptx.tx.GasFeeCap(),
+				GasTipCap: ptx.tx.GasTipCap(),
+			})
+		}
+		if len(lazies) > 0 {
+			pending[addr] = lazies
+		}
+	}
+	return pending
+}
+
+// SubscribeTransactions registers a subscription of NewTxsEvent and starts
+// sending events to the given channel.
+func (p *PrivatePool) SubscribeTransactions(ch chan<- core.NewTxsEvent) event.Subscription {
+	return p.scope.Track(p.feed.Subscribe(ch))
+}
+
+// Nonce returns the next nonce of an account, with all transactions executable
+// by the pool already applied on top.
+func (p *PrivatePool) Nonce(addr common.Address) uint64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.state.GetNonce(addr) + uint64(p.executable(addr))
+}
+
+// Stats retrieves the current pool stats, namely the number of pending and the
+// number of queued (non-executable) transactions.
+func (p *PrivatePool) Stats() (int, int) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.stats()
+}
+
+// stats retrieves the current pool stats. The caller must hold the pool lock.
+func (p *PrivatePool) stats() (int, int) {
+	var pending int
+	for addr := range p.index {
+		pending += p.executable(addr)
+	}
+	return pending, len(p.all) - pending
+}
+
+// updateGauges refreshes the pool metrics. The caller must hold the pool lock.
+func (p *PrivatePool) updateGauges() {
+	if !metrics.Enabled {
+		return
+	}
+	pending, queued := p.stats()
+
+	pendingGauge.Update(int64(pending))
+	queuedGauge.Update(int64(queued))
+}
+
+// Content retrieves the data content of the transaction pool, returning all the
+// pending as well as queued transactions, grouped by account and sorted by nonce.
+func (p *PrivatePool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	var (
+		pending = make(map[common.Address][]*types.Transaction)
+		queued  = make(map[common.Address][]*types.Transaction)
+	)
+	for addr := range p.index {
+		run, block := p.contentFrom(addr)
+		if len(run) > 0 {
+			pending[addr] = run
+		}
+		if len(block) > 0 {
+			queued[addr] = block
+		}
+	}
+	return pending, queued
+}
+
+// ContentFrom retrieves the data content of the transaction pool, returning the
+// pending as well as queued transactions of this address, grouped by nonce.
+func (p *PrivatePool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.contentFrom(addr)
+}
+
+// contentFrom splits the transactions of an account into executable and gapped
+// ones. The caller must hold the pool lock.
+func (p *PrivatePool) contentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
+	var (
+		txs     = p.index[addr]
+		exec    = p.executable(addr)
+		pending = make([]*types.Transaction, 0, exec)
+		queued  = make([]*types.Transaction, 0, len(txs)-exec)
+	)
+	for i, ptx := range txs {
+		if i < exec {
+			pending = append(pending, ptx.tx)
+		} else {
+			queued = append(queued, ptx.tx)
+		}
+	}
+	return pending, queued
+}
+
+// Locals retrieves the accounts currently considered local by the pool.
+//
+// There are no local accounts in the private pool, every transaction is
+// subject to the same expiration rules.
+func (p *PrivatePool) Locals() []common.Address {
+	return []common.Address{}
+}
+
+// Status returns the known status (unknown/pending/queued) of a transaction
+// identified by their hashes.
+func (p *PrivatePool) Status(hash common.Hash) txpool.TxStatus {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	ptx := p.all[hash]
+	if ptx == nil {
+		return txpool.TxStatusUnknown
+	}
+	from, _ := types.Sender(p.signer, ptx.tx)
+	for _, exec := range p.index[from][:p.executable(from)] {
+		if exec == ptx {
+			return txpool.TxStatusPending
+		}
+	}
+	return txpool.TxStatusQueued
+}
@@ -0,0 +1,249 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package privatepool
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testBlockChain is a mock of the live chain for testing the pool.
+type testBlockChain struct {
+	head    *types.Header
+	statedb *state.StateDB
+	feed    event.Feed
+}
+
+func (bc *testBlockChain) Config() *params.ChainConfig {
+	return params.TestChainConfig
+}
+
+func (bc *testBlockChain) CurrentBlock() *types.Header {
+	return bc.head
+}
+
+func (bc *testBlockChain) GetBlock(hash common.Hash, number uint64) *types.Block {
+	return nil
+}
+
+func (bc *testBlockChain) StateAt(common.Hash) (*state.StateDB, error) {
+	return bc.statedb, nil
+}
+
+func (bc *testBlockChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return bc.feed.Subscribe(ch)
+}
+
+func newTestBlockChain(t *testing.T) *testBlockChain {
+	t.Helper()
+
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		t.Fatalf("failed to create state: %v", err)
+	}
+	return &testBlockChain{
+		head: &types.Header{
+			Number:   big.NewInt(1),
+			GasLimit: 30_000_000,
+			BaseFee:  big.NewInt(params.InitialBaseFee),
+		},
+		statedb: statedb,
+	}
+}
+
+// setHead moves the chain forward to the given block number.
+func (bc *testBlockChain) setHead(number int64) *types.Header {
+	bc.head = &types.Header{
+		Number:   big.NewInt(number),
+		GasLimit: bc.head.GasLimit,
+		BaseFee:  bc.head.BaseFee,
+	}
+	return bc.head
+}
+
+func makeTx(nonce uint64, tip int64, key *ecdsa.PrivateKey) *types.Transaction {
+	return types.MustSignNewTx(key, types.LatestSigner(params.TestChainConfig), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tip),
+		GasFeeCap: big.NewInt(params.InitialBaseFee + tip),
+		Gas:       21000,
+		To:        &common.Address{0x01},
+		Value:     big.NewInt(1),
+	})
+}
+
+// Tests that private transactions are offered to the miner but hidden from the
+// public view handed to the networking layer.
+func TestPrivateTransactionsHiddenFromPublicView(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		chain  = newTestBlockChain(t)
+	)
+	chain.statedb.AddBalance(addr, big.NewInt(params.Ether))
+
+	pool, err := txpool.New(big.NewInt(1), chain, []txpool.SubPool{New(DefaultConfig, chain)})
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	tx := makeTx(0, 1, key)
+	if errs := pool.Add([]*txpool.Transaction{{Tx: tx}}, true, true); !errors.Is(errs[0], core.ErrTxTypeNotSupported) {
+		t.Fatalf("public add error mismatch: have %v, want %v", errs[0], core.ErrTxTypeNotSupported)
+	}
+	if errs := pool.AddPrivate([]*txpool.Transaction{{Tx: tx}}, true); errs[0] != nil {
+		t.Fatalf("failed to add private transaction: %v", errs[0])
+	}
+	if pending := pool.Pending(true); len(pending[addr]) != 1 {
+		t.Fatalf("private transaction not offered to miner: have %d, want 1", len(pending[addr]))
+	}
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("private transaction counted as public: pending %d, queued %d", pending, queued)
+	}
+	if private := pool.PrivateStats(); private != 1 {
+		t.Fatalf("private stats mismatch: have %d, want 1", private)
+	}
+	public := pool.Public()
+	if public.Has(tx.Hash()) || public.Get(tx.Hash()) != nil {
+		t.Fatalf("private transaction exposed by public view")
+	}
+	if pending := public.Pending(false); len(pending) != 0 {
+		t.Fatalf("private transaction announced by public view: %v", pending)
+	}
+	if pending, queued := pool.Content(); len(pending) != 0 || len(queued) != 0 {
+		t.Fatalf("private transaction listed in content: %v %v", pending, queued)
+	}
+	if pending, queued := pool.ContentFrom(addr); len(pending) != 0 || len(queued) != 0 {
+		t.Fatalf("private transaction listed in sender content: %v %v", pending, queued)
+	}
+	if pool.Get(tx.Hash()) != nil {
+		t.Fatalf("private transaction returned by lookup")
+	}
+	if status := pool.Status(tx.Hash()); status != txpool.TxStatusUnknown {
+		t.Fatalf("private transaction status mismatch: have %v, want %v", status, txpool.TxStatusUnknown)
+	}
+}
+
+// Tests that the transactions of a sender are held by a single subpool at a time,
+// the private ones being refused while it has public ones and the other way around.
+func TestPrivateSenderReservation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		publicKey, _  = crypto.GenerateKey()
+		privateKey, _ = crypto.GenerateKey()
+		publicAddr    = crypto.PubkeyToAddress(publicKey.PublicKey)
+		privateAddr   = crypto.PubkeyToAddress(privateKey.PublicKey)
+		chain         = newTestBlockChain(t)
+	)
+	chain.statedb.AddBalance(publicAddr, big.NewInt(params.Ether))
+	chain.statedb.AddBalance(privateAddr, big.NewInt(params.Ether))
+
+	config := legacypool.DefaultConfig
+	config.Journal = ""
+
+	pool, err := txpool.New(big.NewInt(1), chain, []txpool.SubPool{legacypool.New(config, chain), New(DefaultConfig, chain)})
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	defer pool.Close()
+
+	if errs := pool.Add([]*txpool.Transaction{{Tx: makeTx(0, 1, publicKey)}}, true, true); errs[0] != nil {
+		t.Fatalf("failed to add public transaction: %v", errs[0])
+	}
+	if errs := pool.AddPrivate([]*txpool.Transaction{{Tx: makeTx(1, 1, publicKey)}}, true); !errors.Is(errs[0], ErrSenderReserved) {
+		t.Fatalf("private add error mismatch: have %v, want %v", errs[0], ErrSenderReserved)
+	}
+	if errs := pool.AddPrivate([]*txpool.Transaction{{Tx: makeTx(0, 1, privateKey)}}, true); errs[0] != nil {
+		t.Fatalf("failed to add private transaction: %v", errs[0])
+	}
+	if errs := pool.Add([]*txpool.Transaction{{Tx: makeTx(1, 1, privateKey)}}, true, true); errs[0] == nil {
+		t.Fatalf("public transaction of a private sender accepted")
+	}
+	// Only the public transactions are listed
+	pending, _ := pool.Content()
+	if len(pending) != 1 || len(pending[publicAddr]) != 1 {
+		t.Fatalf("content mismatch: %v", pending)
+	}
+	if pending, _ := pool.ContentFrom(privateAddr); len(pending) != 0 {
+		t.Fatalf("private transaction listed in sender content: %v", pending)
+	}
+}
+
+// Tests that private transactions are dropped once they are included, or once
+// they outlived the configured block lifetime.
+func TestPrivateTransactionExpiry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		chain  = newTestBlockChain(t)
+		pool   = New(Config{Lifetime: 4}, chain)
+	)
+	chain.statedb.AddBalance(addr, big.NewInt(params.Ether))
+
+	var reserved bool
+	reserve := func(common.Address, bool) error { reserved = !reserved; return nil }
+	if err := pool.Init(big.NewInt(1), chain.CurrentBlock(), reserve); err != nil {
+		t.Fatalf("failed to init pool: %v", err)
+	}
+	for _, err := range pool.Add([]*txpool.Transaction{{Tx: makeTx(0, 1, key)}, {Tx: makeTx(1, 1, key)}, {Tx: makeTx(3, 1, key)}}, true, true) {
+		if err != nil {
+			t.Fatalf("failed to add private transaction: %v", err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("stats mismatch: have %d/%d, want 2/1", pending, queued)
+	}
+	if nonce := pool.Nonce(addr); nonce != 2 {
+		t.Fatalf("nonce mismatch: have %d, want 2", nonce)
+	}
+	// Include the first transaction and ensure it's dropped
+	chain.statedb.SetNonce(addr, 1)
+	pool.Reset(nil, chain.setHead(2))
+
+	if pending, queued := pool.Stats(); pending != 1 || queued != 1 {
+		t.Fatalf("stats mismatch after inclusion: have %d/%d, want 1/1", pending, queued)
+	}
+	// Move past the lifetime and ensure everything's dropped
+	pool.Reset(nil, chain.setHead(5))
+
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("stats mismatch after expiry: have %d/%d, want 0/0", pending, queued)
+	}
+	if reserved {
+		t.Fatalf("address reservation not released after expiry")
+	}
+}
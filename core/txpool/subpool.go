@@ -136,3 +136,14 @@ type SubPool interface {
 	// identified by their hashes.
 	Status(hash common.Hash) TxStatus
 }
+
+// PrivateSubPool is a SubPool whose transactions are only offered to the local
+// block producer. Such pools never receive transactions through the public Add
+// path and their content is hidden from the networking layer.
+type PrivateSubPool interface {
+	SubPool
+
+	// Private is a marker method distinguishing private subpools from public
+	// ones.
+	Private()
+}
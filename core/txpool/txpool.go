@@ -249,8 +249,12 @@ func (p *TxPool) Has(hash common.Hash) bool {
 }
 
 // Get returns a transaction if it is contained in the pool, or nil otherwise.
+// Transactions held by private subpools are not returned.
 func (p *TxPool) Get(hash common.Hash) *Transaction {
 	for _, subpool := range p.subpools {
+		if _, ok := subpool.(PrivateSubPool); ok {
+			continue
+		}
 		if tx := subpool.Get(hash); tx != nil {
 			return tx
 		}
@@ -304,6 +308,22 @@ func (p *TxPool) Add(txs []*Transaction, local bool, sync bool) []error {
 	return errs
 }
 
+// AddPrivate enqueues a batch of transactions into the private subpool, from
+// where they are only offered to the local block producer and never propagated
+// to the network.
+func (p *TxPool) AddPrivate(txs []*Transaction, sync bool) []error {
+	for _, subpool := range p.subpools {
+		if private, ok := subpool.(PrivateSubPool); ok {
+			return private.Add(txs, true, sync)
+		}
+	}
+	errs := make([]error, len(txs))
+	for i := range txs {
+		errs[i] = ErrPrivateTxUnsupported
+	}
+	return errs
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 func (p *TxPool) Pending(enforceTips bool) map[common.Address][]*LazyTransaction {
@@ -342,10 +362,14 @@ func (p *TxPool) Nonce(addr common.Address) uint64 {
 }
 
 // Stats retrieves the current pool stats, namely the number of pending and the
-// number of queued (non-executable) transactions.
+// number of queued (non-executable) transactions. Transactions held by private
+// subpools are not included, see PrivateStats.
 func (p *TxPool) Stats() (int, int) {
 	var runnable, blocked int
 	for _, subpool := range p.subpools {
+		if _, ok := subpool.(PrivateSubPool); ok {
+			continue
+		}
 		run, block := subpool.Stats()
 
 		runnable += run
@@ -354,14 +378,30 @@ func (p *TxPool) Stats() (int, int) {
 	return runnable, blocked
 }
 
+// PrivateStats retrieves the number of transactions held by private subpools.
+func (p *TxPool) PrivateStats() int {
+	var count int
+	for _, subpool := range p.subpools {
+		if _, ok := subpool.(PrivateSubPool); ok {
+			run, block := subpool.Stats()
+			count += run + block
+		}
+	}
+	return count
+}
+
 // Content retrieves the data content of the transaction pool, returning all the
 // pending as well as queued transactions, grouped by account and sorted by nonce.
+// Transactions held by private subpools are not included.
 func (p *TxPool) Content() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
 	var (
 		runnable = make(map[common.Address][]*types.Transaction)
 		blocked  = make(map[common.Address][]*types.Transaction)
 	)
 	for _, subpool := range p.subpools {
+		if _, ok := subpool.(PrivateSubPool); ok {
+			continue
+		}
 		run, block := subpool.Content()
 
 		for addr, txs := range run {
@@ -376,8 +416,12 @@ func (p *TxPool) Content() (map[common.Address][]*types.Transaction, map[common.
 
 // ContentFrom retrieves the data content of the transaction pool, returning the
 // pending as well as queued transactions of this address, grouped by nonce.
+// Transactions held by private subpools are not included.
 func (p *TxPool) ContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
 	for _, subpool := range p.subpools {
+		if _, ok := subpool.(PrivateSubPool); ok {
+			continue
+		}
 		run, block := subpool.ContentFrom(addr)
 		if len(run) != 0 || len(block) != 0 {
 			return run, block
@@ -404,12 +448,91 @@ func (p *TxPool) Locals() []common.Address {
 }
 
 // Status returns the known status (unknown/pending/queued) of a transaction
-// identified by their hashes.
+// identified by their hashes. Transactions held by private subpools are reported
+// as unknown.
 func (p *TxPool) Status(hash common.Hash) TxStatus {
 	for _, subpool := range p.subpools {
+		if _, ok := subpool.(PrivateSubPool); ok {
+			continue
+		}
 		if status := subpool.Status(hash); status != TxStatusUnknown {
 			return status
 		}
 	}
 	return TxStatusUnknown
 }
+
+// Public returns a view of the pool which omits the transactions held by private
+// subpools. It is meant to be handed to the networking layer, so that privately
+// submitted transactions are neither announced nor served to remote peers.
+func (p *TxPool) Public() *PublicPool {
+	return &PublicPool{pool: p}
+}
+
+// PublicPool is a view of the transaction pool hiding all private subpools.
+type PublicPool struct {
+	pool *TxPool
+}
+
+// subpools returns the non-private subpools of the underlying pool.
+func (v *PublicPool) subpools() []SubPool {
+	subpools := make([]SubPool, 0, len(v.pool.subpools))
+	for _, subpool := range v.pool.subpools {
+		if _, ok := subpool.(PrivateSubPool); !ok {
+			subpools = append(subpools, subpool)
+		}
+	}
+	return subpools
+}
+
+// Has returns an indicator whether a public subpool has a transaction cached
+// with the given hash.
+func (v *PublicPool) Has(hash common.Hash) bool {
+	for _, subpool := range v.subpools() {
+		if subpool.Has(hash) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get returns a transaction if it is contained in a public subpool, or nil
+// otherwise.
+func (v *PublicPool) Get(hash common.Hash) *Transaction {
+	for _, subpool := range v.subpools() {
+		if tx := subpool.Get(hash); tx != nil {
+			return tx
+		}
+	}
+	return nil
+}
+
+// Add enqueues a batch of transactions into the pool. Private subpools filter
+// out every transaction, so this is equivalent to adding to the full pool.
+func (v *PublicPool) Add(txs []*Transaction, local bool, sync bool) []error {
+	return v.pool.Add(txs, local, sync)
+}
+
+// Pending retrieves all currently processable transactions of the public
+// subpools, grouped by origin account and sorted by nonce.
+func (v *PublicPool) Pending(enforceTips bool) map[common.Address][]*LazyTransaction {
+	txs := make(map[common.Address][]*LazyTransaction)
+	for _, subpool := range v.subpools() {
+		for addr, set := range subpool.Pending(enforceTips) {
+			txs[addr] = set
+		}
+	}
+	return txs
+}
+
+// SubscribeNewTxsEvent registers a subscription of NewTxsEvent for the public
+// subpools and starts sending events to the given channel.
+func (v *PublicPool) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
+	subpools := v.subpools()
+
+	subs := make([]event.Subscription, len(subpools))
+	for i, subpool := range subpools {
+		subs[i] = subpool.SubscribeTransactions(ch)
+	}
+	return v.pool.subs.Track(event.JoinSubscriptions(subs...))
+}
@@ -17,10 +17,13 @@
 package eth
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/miner"
 )
 
 // EthereumAPI provides an API to access Ethereum full node-related information.
@@ -53,6 +56,57 @@ func (api *EthereumAPI) Mining() bool {
 	return api.e.IsMining()
 }
 
+// SendBundleArgs represents the arguments to submit a bundle of transactions.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	MinBlock          *hexutil.Uint64 `json:"minBlock"`
+	MaxBlock          hexutil.Uint64  `json:"maxBlock"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+// SendBundle submits a bundle of signed transactions to the local miner. The
+// bundle is included atomically, in order, in one of the blocks within the
+// requested range, or not at all. Transactions not listed in revertingTxHashes
+// must not revert for the bundle to be included.
+func (api *EthereumAPI) SendBundle(args SendBundleArgs) (common.Hash, error) {
+	if !api.e.IsMining() {
+		return common.Hash{}, errors.New("bundles are only accepted by mining nodes")
+	}
+
+	bundle := &miner.Bundle{
+		Txs:               make(types.Transactions, len(args.Txs)),
+		MaxBlock:          uint64(args.MaxBlock),
+		RevertingTxHashes: args.RevertingTxHashes,
+	}
+	if args.MinBlock != nil {
+		bundle.MinBlock = uint64(*args.MinBlock)
+	}
+
+	included := make(map[common.Hash]bool)
+
+	for i, input := range args.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return common.Hash{}, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+
+		bundle.Txs[i] = tx
+		included[tx.Hash()] = true
+	}
+
+	for _, hash := range args.RevertingTxHashes {
+		if !included[hash] {
+			return common.Hash{}, fmt.Errorf("reverting transaction %x not in bundle", hash)
+		}
+	}
+
+	if err := api.e.Miner().AddBundle(bundle); err != nil {
+		return common.Hash{}, err
+	}
+
+	return bundle.Hash(), nil
+}
+
 func getFinalizedBlockNumber(eth *Ethereum) (uint64, error) {
 	currentBlockNum := eth.BlockChain().CurrentBlock()
 
@@ -50,6 +50,35 @@ type EthAPIBackend struct {
 	gpo                 *gasprice.Oracle
 }
 
+// prunedHistoryError is returned when the requested block was removed by history
+// expiry. It carries a dedicated JSON-RPC error code, so that clients can tell it
+// apart from a block which doesn't exist.
+type prunedHistoryError struct{}
+
+func (e *prunedHistoryError) Error() string  { return core.ErrHistoryPruned.Error() }
+func (e *prunedHistoryError) ErrorCode() int { return 4444 }
+func (e *prunedHistoryError) Unwrap() error  { return core.ErrHistoryPruned }
+
+// prunedHistory returns an error if the block with the given number was removed
+// by history expiry.
+func (b *EthAPIBackend) prunedHistory(number uint64) error {
+	if number < b.eth.blockchain.HistoryTail() {
+		return &prunedHistoryError{}
+	}
+
+	return nil
+}
+
+// prunedHistoryByHash returns an error if the block with the given hash was
+// removed by history expiry. The hash to number mappings are never expired.
+func (b *EthAPIBackend) prunedHistoryByHash(hash common.Hash) error {
+	if number := rawdb.ReadHeaderNumber(b.eth.chainDb, hash); number != nil {
+		return b.prunedHistory(*number)
+	}
+
+	return nil
+}
+
 // ChainConfig returns the active chain configuration.
 func (b *EthAPIBackend) ChainConfig() *params.ChainConfig {
 	return b.eth.blockchain.Config()
@@ -107,7 +136,12 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 		return nil, errors.New("safe block not found")
 	}
 
-	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
+	header := b.eth.blockchain.GetHeaderByNumber(uint64(number))
+	if header == nil {
+		return nil, b.prunedHistory(uint64(number))
+	}
+
+	return header, nil
 }
 
 func (b *EthAPIBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
@@ -118,6 +152,10 @@ func (b *EthAPIBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash
 	if hash, ok := blockNrOrHash.Hash(); ok {
 		header := b.eth.blockchain.GetHeaderByHash(hash)
 		if header == nil {
+			if err := b.prunedHistoryByHash(hash); err != nil {
+				return nil, err
+			}
+
 			return nil, errors.New("header for hash not found")
 		}
 
@@ -132,7 +170,12 @@ func (b *EthAPIBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash
 }
 
 func (b *EthAPIBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	return b.eth.blockchain.GetHeaderByHash(hash), nil
+	header := b.eth.blockchain.GetHeaderByHash(hash)
+	if header == nil {
+		return nil, b.prunedHistoryByHash(hash)
+	}
+
+	return header, nil
 }
 
 func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
@@ -169,11 +212,21 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
 	}
 
-	return b.eth.blockchain.GetBlockByNumber(uint64(number)), nil
+	block := b.eth.blockchain.GetBlockByNumber(uint64(number))
+	if block == nil {
+		return nil, b.prunedHistory(uint64(number))
+	}
+
+	return block, nil
 }
 
 func (b *EthAPIBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
-	return b.eth.blockchain.GetBlockByHash(hash), nil
+	block := b.eth.blockchain.GetBlockByHash(hash)
+	if block == nil {
+		return nil, b.prunedHistoryByHash(hash)
+	}
+
+	return block, nil
 }
 
 // GetBody returns body of a block. It does not resolve special block numbers.
@@ -186,6 +239,10 @@ func (b *EthAPIBackend) GetBody(ctx context.Context, hash common.Hash, number rp
 		return body, nil
 	}
 
+	if err := b.prunedHistory(uint64(number)); err != nil {
+		return nil, err
+	}
+
 	return nil, errors.New("block body not found")
 }
 
@@ -197,6 +254,10 @@ func (b *EthAPIBackend) BlockByNumberOrHash(ctx context.Context, blockNrOrHash r
 	if hash, ok := blockNrOrHash.Hash(); ok {
 		header := b.eth.blockchain.GetHeaderByHash(hash)
 		if header == nil {
+			if err := b.prunedHistoryByHash(hash); err != nil {
+				return nil, err
+			}
+
 			return nil, errors.New("header for hash not found")
 		}
 
@@ -271,11 +332,21 @@ func (b *EthAPIBackend) StateAndHeaderByNumberOrHash(ctx context.Context, blockN
 }
 
 func (b *EthAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
-	return b.eth.blockchain.GetReceiptsByHash(hash), nil
+	receipts := b.eth.blockchain.GetReceiptsByHash(hash)
+	if receipts == nil {
+		return nil, b.prunedHistoryByHash(hash)
+	}
+
+	return receipts, nil
 }
 
 func (b *EthAPIBackend) GetLogs(ctx context.Context, hash common.Hash, number uint64) ([][]*types.Log, error) {
-	return rawdb.ReadLogs(b.eth.chainDb, hash, number, b.ChainConfig()), nil
+	logs := rawdb.ReadLogs(b.eth.chainDb, hash, number, b.ChainConfig())
+	if logs == nil {
+		return nil, b.prunedHistory(number)
+	}
+
+	return logs, nil
 }
 
 func (b *EthAPIBackend) GetTd(ctx context.Context, hash common.Hash) *big.Int {
@@ -333,6 +404,14 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return b.eth.txPool.Add([]*txpool.Transaction{{Tx: signedTx}}, true, false)[0]
 }
 
+func (b *EthAPIBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction) error {
+	if !b.eth.Miner().GetWorker().IsRunning() {
+		return errors.New("private transactions are not broadcasted therefore they will not be submitted to a non-mining node")
+	}
+
+	return b.eth.txPool.AddPrivate([]*txpool.Transaction{{Tx: signedTx}}, false)[0]
+}
+
 func (b *EthAPIBackend) GetPoolTransactions() (types.Transactions, error) {
 	pending := b.eth.txPool.Pending(false)
 
@@ -369,6 +448,10 @@ func (b *EthAPIBackend) Stats() (runnable int, blocked int) {
 	return b.eth.txPool.Stats()
 }
 
+func (b *EthAPIBackend) PrivateStats() int {
+	return b.eth.txPool.PrivateStats()
+}
+
 func (b *EthAPIBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
 	return b.eth.txPool.Content()
 }
@@ -397,6 +480,10 @@ func (b *EthAPIBackend) FeeHistory(ctx context.Context, blockCount uint64, lastB
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
+func (b *EthAPIBackend) GasEstimates(ctx context.Context) (*gasprice.GasEstimates, error) {
+	return b.gpo.GasEstimates(ctx)
+}
+
 func (b *EthAPIBackend) ChainDb() ethdb.Database {
 	return b.eth.ChainDb()
 }
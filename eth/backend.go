@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/big"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -33,6 +34,8 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
@@ -40,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/txpool/privatepool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -50,10 +54,12 @@ import (
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/shutdowncheck"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -139,13 +145,34 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	extraDBConfig := resolveExtraDBConfig(config)
 	// Assemble the Ethereum object
-	chainDb, err := stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "ethereum/db/chaindata/", false, extraDBConfig)
-	if err != nil {
-		return nil, err
+	var (
+		chainDb ethdb.Database
+		err     error
+	)
+	if config.RemoteDB != nil {
+		// The database served by another node is never modified, the local
+		// bookkeeping is kept in memory on top of it
+		chainDb = remotedb.NewOverlay(config.RemoteDB)
+	} else {
+		chainDb, err = stack.OpenDatabaseWithFreezer("chaindata", config.DatabaseCache, config.DatabaseHandles, config.DatabaseFreezer, "ethereum/db/chaindata/", false, extraDBConfig)
+		if err != nil {
+			return nil, err
+		}
 	}
 	if err := pruner.RecoverPruning(stack.ResolvePath(""), chainDb); err != nil {
 		log.Error("Failed to recover state", "error", err)
 	}
+	// Refuse to open the database with a state scheme other than the persisted one
+	scheme, err := rawdb.ParseStateScheme(config.StateScheme, chainDb)
+	if err != nil {
+		return nil, err
+	}
+	if scheme == rawdb.PathScheme && config.NoPruning {
+		return nil, errors.New("archive mode is not supported by the path-based state scheme")
+	}
+	if scheme == rawdb.PathScheme && config.RemoteDB != nil {
+		return nil, errors.New("remote databases are not supported by the path-based state scheme")
+	}
 
 	// START: Bor changes
 	eth := &Ethereum{
@@ -177,6 +204,28 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if gpoParams.Default == nil {
 		gpoParams.Default = config.Miner.GasPrice
 	}
+	if gpoParams.TipFloor == nil {
+		gpoParams.TipFloor = new(big.Int).SetUint64(config.TxPool.PriceLimit)
+	}
+
+	var (
+		vmConfig = vm.Config{
+			EnablePreimageRecording: config.EnablePreimageRecording,
+		}
+		cacheConfig = &core.CacheConfig{
+			TrieCleanLimit:      config.TrieCleanCache,
+			TrieCleanNoPrefetch: config.NoPrefetch,
+			TrieDirtyLimit:      config.TrieDirtyCache,
+			TrieDirtyDisabled:   config.NoPruning,
+			TrieTimeLimit:       config.TrieTimeout,
+			SnapshotLimit:       config.SnapshotCache,
+			Preimages:           config.Preimages,
+			TriesInMemory:       config.TriesInMemory,
+			StateHistory:        config.StateHistory,
+			StateScheme:         scheme,
+			BlockHistory:        config.BlockHistory,
+		}
+	)
 
 	// Override the chain config with provided settings.
 	var overrides core.ChainOverrides
@@ -187,7 +236,15 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		overrides.OverrideVerkle = config.OverrideVerkle
 	}
 
-	chainConfig, _, genesisErr := core.SetupGenesisBlockWithOverride(chainDb, trie.NewDatabase(chainDb), config.Genesis, &overrides)
+	// The trie database is closed right after, the path-based one can't be opened
+	// again by the chain otherwise
+	triedb := trie.NewDatabaseWithConfig(chainDb, cacheConfig.TriedbConfig())
+	chainConfig, _, genesisErr := core.SetupGenesisBlockWithOverride(chainDb, triedb, config.Genesis, &overrides)
+
+	if err := triedb.Close(); err != nil {
+		return nil, err
+	}
+
 	if _, isCompat := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !isCompat {
 		return nil, genesisErr
 	}
@@ -198,6 +255,18 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if borEngine, ok := engine.(*bor.Bor); ok && config.SigningProtection {
+		path := stack.ResolvePath(protection.FileName)
+
+		store, err := protection.Open(path)
+		if err != nil {
+			return nil, err
+		}
+
+		borEngine.SetSigningProtection(store)
+		log.Info("Enabled signing protection", "path", path)
+	}
 	// END: Bor changes
 
 	bcVersion := rawdb.ReadDatabaseVersion(chainDb)
@@ -217,21 +286,6 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			rawdb.WriteDatabaseVersion(chainDb, core.BlockChainVersion)
 		}
 	}
-	var (
-		vmConfig = vm.Config{
-			EnablePreimageRecording: config.EnablePreimageRecording,
-		}
-		cacheConfig = &core.CacheConfig{
-			TrieCleanLimit:      config.TrieCleanCache,
-			TrieCleanNoPrefetch: config.NoPrefetch,
-			TrieDirtyLimit:      config.TrieDirtyCache,
-			TrieDirtyDisabled:   config.NoPruning,
-			TrieTimeLimit:       config.TrieTimeout,
-			SnapshotLimit:       config.SnapshotCache,
-			Preimages:           config.Preimages,
-			TriesInMemory:       config.TriesInMemory,
-		}
-	)
 
 	checker := whitelist.NewService(chainDb)
 
@@ -264,8 +318,9 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		config.TxPool.Journal = stack.ResolvePath(config.TxPool.Journal)
 	}
 	legacyPool := legacypool.New(config.TxPool, eth.blockchain)
+	privatePool := privatepool.New(config.PrivatePool, eth.blockchain)
 
-	eth.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), eth.blockchain, []txpool.SubPool{legacyPool})
+	eth.txPool, err = txpool.New(new(big.Int).SetUint64(config.TxPool.PriceLimit), eth.blockchain, []txpool.SubPool{legacyPool, privatePool})
 	if err != nil {
 		return nil, err
 	}
@@ -274,7 +329,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if eth.handler, err = newHandler(&handlerConfig{
 		Database:            chainDb,
 		Chain:               eth.blockchain,
-		TxPool:              eth.txPool,
+		TxPool:              eth.txPool.Public(),
 		Merger:              eth.merger,
 		Network:             config.NetworkId,
 		Sync:                config.SyncMode,
@@ -285,6 +340,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 		checker:             checker,
 		txArrivalWait:       eth.p2pServer.TxArrivalWait,
 		enableBlockTracking: eth.config.EnableBlockTracking,
+		sentryMode:          eth.config.SentryMode,
+		sentryPeers:         eth.config.SentryPeers,
 	}); err != nil {
 		return nil, err
 	}
@@ -571,6 +628,33 @@ func (s *Ethereum) SetSynced()                         { s.handler.acceptTxs.Sto
 func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruning }
 func (s *Ethereum) BloomIndexer() *core.ChainIndexer   { return s.bloomIndexer }
 func (s *Ethereum) Merger() *consensus.Merger          { return s.merger }
+
+// PeerPropagation returns the summary of the blocks relayed by the given peer,
+// or nil if it isn't connected.
+func (s *Ethereum) PeerPropagation(id string) *PeerPropagation {
+	return s.handler.peerPropagation(id)
+}
+
+// PeerReputation returns the reputation of the given peer, connected or not.
+func (s *Ethereum) PeerReputation(id string) *PeerReputation {
+	return s.handler.reputation.reputation(id, time.Now())
+}
+
+// PeerBans returns the peer bans in force.
+func (s *Ethereum) PeerBans() []*PeerBan {
+	return s.handler.reputation.list(time.Now())
+}
+
+// BanPeer bans the given peer for a duration, disconnecting it.
+func (s *Ethereum) BanPeer(id string, duration time.Duration, reason string) {
+	s.handler.banPeer(id, duration, reason)
+}
+
+// UnbanPeer lifts the ban of the given peer, returning whether it was banned.
+func (s *Ethereum) UnbanPeer(id string) bool {
+	return s.handler.reputation.unban(id)
+}
+
 func (s *Ethereum) SyncMode() downloader.SyncMode {
 	mode, _ := s.handler.chainSync.modeAndLocalHead()
 	return mode
@@ -625,12 +709,19 @@ func (s *Ethereum) Start() error {
 	go s.startNoAckMilestoneService()
 	go s.startNoAckMilestoneByIDService()
 
+	if s.config.VerifySpans {
+		go s.startSpanVerifierService()
+	}
+
 	return nil
 }
 
 var (
 	ErrNotBorConsensus             = errors.New("not bor consensus was given")
 	ErrBorConsensusWithoutHeimdall = errors.New("bor consensus without heimdall")
+
+	// spanMismatchMeter counts the committed spans found not matching their sources
+	spanMismatchMeter = metrics.NewRegisteredMeter("bor/span/mismatch", nil)
 )
 
 const (
@@ -678,6 +769,17 @@ func (s *Ethereum) startNoAckMilestoneByIDService() {
 	s.retryHeimdallHandler(s.handleNoAckMilestoneByID, tickerDuration, noAckMilestoneTimeout, fnName)
 }
 
+// startSpanVerifierService starts the goroutine comparing the spans committed to
+// the validator contract with Heimdall and the snapshots.
+func (s *Ethereum) startSpanVerifierService() {
+	const (
+		tickerDuration = 1 * time.Minute
+		fnName         = "span verifier service"
+	)
+
+	s.retryHeimdallHandler(s.handleSpanVerification, tickerDuration, whitelistTimeout, fnName)
+}
+
 func (s *Ethereum) retryHeimdallHandler(fn heimdallHandler, tickerDuration time.Duration, timeout time.Duration, fnName string) {
 	retryHeimdallHandler(fn, tickerDuration, timeout, fnName, s.closeCh, s.getHandler)
 }
@@ -732,6 +834,10 @@ func (s *Ethereum) handleWhitelistCheckpoint(ctx context.Context, ethHandler *et
 	verifier := newBorVerifier()
 
 	blockNum, blockHash, err := ethHandler.fetchWhitelistCheckpoint(ctx, bor, s, verifier)
+	if errors.Is(err, heimdall.ErrServiceUnavailable) {
+		return nil
+	}
+
 	// If the array is empty, we're bound to receive an error. Non-nill error and non-empty array
 	// means that array has partial elements and it failed for some block. We'll add those partial
 	// elements anyway.
@@ -802,6 +908,43 @@ func (s *Ethereum) handleNoAckMilestoneByID(ctx context.Context, ethHandler *eth
 	return nil
 }
 
+// handleSpanVerification verifies the current span and the next one, if
+// already committed, logging the mismatches found.
+func (s *Ethereum) handleSpanVerification(ctx context.Context, ethHandler *ethHandler, bor *bor.Bor) error {
+	head := ethHandler.chain.CurrentHeader()
+
+	current, err := bor.GetSpanner().GetCurrentSpan(ctx, head.Hash())
+	if err != nil {
+		return err
+	}
+
+	for _, id := range []uint64{current.ID, current.ID + 1} {
+		report, err := bor.VerifySpan(ctx, ethHandler.chain, id)
+		if errors.Is(err, span.ErrSpanNotFound) {
+			continue
+		}
+
+		if errors.Is(err, heimdall.ErrServiceUnavailable) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !report.Consistent() {
+			spanMismatchMeter.Mark(1)
+			log.Error("Committed span doesn't match its sources", "id", id, "sources", report.Sources, "mismatches", strings.Join(report.Mismatches, "; "))
+
+			continue
+		}
+
+		log.Debug("Verified committed span", "id", id, "sources", report.Sources)
+	}
+
+	return nil
+}
+
 func (s *Ethereum) getHandler() (*ethHandler, *bor.Bor, error) {
 	ethHandler := (*ethHandler)(s.handler)
 
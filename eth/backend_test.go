@@ -0,0 +1,141 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/remotedb"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+
+	"google.golang.org/grpc"
+)
+
+// countItems returns the number of key-value items of a database.
+func countItems(db ethdb.Iteratee) int {
+	it := db.NewIterator(nil, nil)
+	defer it.Release()
+
+	var count int
+	for it.Next() {
+		count++
+	}
+	return count
+}
+
+// Tests that a node runs over the database served by another one, serving its
+// chain and state without modifying it.
+func TestRemoteDatabase(t *testing.T) {
+	// Ethash is only run as the pre-merge engine
+	config := *params.TestChainConfig
+	config.TerminalTotalDifficulty = new(big.Int).SetUint64(math.MaxUint64)
+	config.TerminalTotalDifficultyPassed = true
+
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		to     = common.Address{0x01}
+		gspec  = &core.Genesis{
+			Config: &config,
+			Alloc:  core.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		db     = rawdb.NewMemoryDatabase()
+	)
+	// Build an archive chain, committing the state of every block
+	chain, err := core.NewBlockChain(db, &core.CacheConfig{TrieDirtyDisabled: true}, gspec, nil, ethash.NewFaker(), vm.Config{}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 8, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(gen.TxNonce(addr), to, big.NewInt(1), params.TxGas, gen.BaseFee(), nil), signer, key)
+		gen.AddTx(tx)
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	chain.Stop()
+
+	// Serve it and run a node over it
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	remotedb.NewServer(db).Register(srv)
+
+	go srv.Serve(lis) //nolint:errcheck
+	defer srv.Stop()
+
+	remote, err := remotedb.Dial(lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	items := countItems(db)
+
+	stack, err := node.New(&node.Config{})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	backend, err := New(stack, &ethconfig.Config{
+		Genesis:        gspec,
+		NetworkId:      1337,
+		TrieCleanCache: 5,
+		TrieTimeout:    60 * time.Minute,
+		RemoteDB:       remote,
+	})
+	if err != nil {
+		t.Fatalf("failed to create backend: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	head := backend.BlockChain().CurrentBlock()
+	if head.Hash() != blocks[len(blocks)-1].Hash() {
+		t.Fatalf("head mismatch: have #%d %x, want #%d %x", head.Number, head.Hash(), len(blocks), blocks[len(blocks)-1].Hash())
+	}
+	statedb, err := backend.BlockChain().StateAt(head.Root)
+	if err != nil {
+		t.Fatalf("head state unavailable: %v", err)
+	}
+	if balance := statedb.GetBalance(to); balance.Cmp(big.NewInt(int64(len(blocks)))) != 0 {
+		t.Fatalf("balance mismatch: have %v, want %d", balance, len(blocks))
+	}
+	// The node bookkeeping is kept locally
+	if err := stack.Close(); err != nil {
+		t.Fatalf("failed to close node: %v", err)
+	}
+	if have := countItems(db); have != items {
+		t.Fatalf("served database modified: have %d items, want %d", have, items)
+	}
+}
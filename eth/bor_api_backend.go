@@ -107,6 +107,10 @@ func (b *EthAPIBackend) GetVoteOnHash(ctx context.Context, starBlockNr uint64, e
 func (b *EthAPIBackend) GetBorBlockReceipt(ctx context.Context, hash common.Hash) (*types.Receipt, error) {
 	receipt := b.eth.blockchain.GetBorReceiptByHash(hash)
 	if receipt == nil {
+		if err := b.prunedHistoryByHash(hash); err != nil {
+			return nil, err
+		}
+
 		return nil, ethereum.NotFound
 	}
 
@@ -117,7 +121,7 @@ func (b *EthAPIBackend) GetBorBlockReceipt(ctx context.Context, hash common.Hash
 func (b *EthAPIBackend) GetBorBlockLogs(ctx context.Context, hash common.Hash) ([]*types.Log, error) {
 	receipt := b.eth.blockchain.GetBorReceiptByHash(hash)
 	if receipt == nil {
-		return nil, nil
+		return nil, b.prunedHistoryByHash(hash)
 	}
 
 	return receipt.Logs, nil
@@ -143,3 +147,9 @@ func (b *EthAPIBackend) SubscribeStateSyncEvent(ch chan<- core.StateSyncEvent) e
 func (b *EthAPIBackend) SubscribeChain2HeadEvent(ch chan<- core.Chain2HeadEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeChain2HeadEvent(ch)
 }
+
+// BlockExecutionStats returns whether block-STM is enabled, and the number of
+// blocks executed by the parallel and the serial processors.
+func (b *EthAPIBackend) BlockExecutionStats() (bool, uint64, uint64) {
+	return b.eth.BlockChain().BlockExecutionStats()
+}
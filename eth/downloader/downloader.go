@@ -82,6 +82,18 @@ var (
 	ErrMergeTransition         = errors.New("legacy sync reached the merge")
 )
 
+// IsPeerTimeout reports whether a sync failed on a peer too slow to deliver or
+// withholding data, rather than serving invalid data.
+func IsPeerTimeout(err error) bool {
+	return errors.Is(err, errTimeout) || errors.Is(err, errStallingPeer)
+}
+
+// IsPeerViolation reports whether a sync failed on a peer serving invalid data.
+func IsPeerViolation(err error) bool {
+	return errors.Is(err, errInvalidChain) || errors.Is(err, errBadPeer) ||
+		errors.Is(err, errInvalidAncestor) || errors.Is(err, errEmptyHeaderSet)
+}
+
 // peerDropFn is a callback type for dropping a peer detected as malicious.
 type peerDropFn func(id string)
 
@@ -30,18 +30,21 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall" //nolint:typecheck
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdallapp"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdalldev"
 	"github.com/ethereum/go-ethereum/consensus/bor/heimdallgrpc"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/txpool/privatepool"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -65,11 +68,20 @@ var LightClientGPO = gasprice.Config{
 	IgnorePrice:      gasprice.DefaultIgnorePrice,
 }
 
+const (
+	// SentryModeValidator is the role of a validator only peering with its sentries
+	SentryModeValidator = "validator"
+
+	// SentryModeSentry is the role of a sentry relaying for its validators
+	SentryModeSentry = "sentry"
+)
+
 // Defaults contains default settings for use on the Ethereum main net.
 var Defaults = Config{
 	SyncMode:           downloader.SnapSync,
 	NetworkId:          1,
 	TxLookupLimit:      2350000,
+	StateHistory:       params.FullImmutabilityThreshold,
 	LightPeers:         100,
 	DatabaseCache:      512,
 	TrieCleanCache:     154,
@@ -80,6 +92,7 @@ var Defaults = Config{
 	Miner:              miner.DefaultConfig,
 	TxPool:             legacypool.DefaultConfig,
 	BlobPool:           blobpool.DefaultConfig,
+	PrivatePool:        privatepool.DefaultConfig,
 	RPCGasCap:          50000000,
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
@@ -107,6 +120,13 @@ type Config struct {
 	NoPrefetch bool // Whether to disable prefetching and only load state on demand
 
 	TxLookupLimit uint64 `toml:",omitempty"` // The maximum number of blocks from head whose tx indices are reserved.
+	StateHistory  uint64 `toml:",omitempty"` // The maximum number of blocks from head whose state histories are reserved.
+	BlockHistory  uint64 `toml:",omitempty"` // The maximum number of blocks from head whose headers, bodies and receipts are reserved (0 = entire chain).
+
+	// State scheme represents the scheme used to store ethereum states and trie
+	// nodes on top. It can be 'hash', 'path', or none which means use the scheme
+	// consistent with persistent state.
+	StateScheme string `toml:",omitempty"`
 
 	// RequiredBlocks is a set of block number -> hash mappings which must be in the
 	// canonical chain of all remote peers. Setting the option makes geth verify the
@@ -127,6 +147,10 @@ type Config struct {
 	DatabaseCache      int
 	DatabaseFreezer    string
 
+	// RemoteDB is a database served by another node, run over read-only instead
+	// of the local chain database if set
+	RemoteDB ethdb.Database `toml:"-"`
+
 	// Database - LevelDB options
 	LevelDbCompactionTableSize           uint64
 	LevelDbCompactionTableSizeMultiplier float64
@@ -147,8 +171,9 @@ type Config struct {
 	Miner miner.Config
 
 	// Transaction pool options
-	TxPool   legacypool.Config
-	BlobPool blobpool.Config
+	TxPool      legacypool.Config
+	BlobPool    blobpool.Config
+	PrivatePool privatepool.Config
 
 	// Gas Price Oracle options
 	GPO gasprice.Config
@@ -193,6 +218,15 @@ type Config struct {
 	// Use child heimdall process to fetch data, Only works when RunHeimdall is true
 	UseHeimdallApp bool
 
+	// Use an in-process fake heimdall, serving the spans and state syncs of the bor developer mode
+	DevHeimdall bool
+
+	// Compare the committed spans with Heimdall and the snapshots in the background
+	VerifySpans bool
+
+	// Refuse to sign a block conflicting with one already released by the signer
+	SigningProtection bool
+
 	// Bor logs flag
 	BorLogs bool
 
@@ -207,6 +241,13 @@ type Config struct {
 
 	// EnableBlockTracking allows logging of information collected while tracking block lifecycle
 	EnableBlockTracking bool
+
+	// SentryMode is the role of the node in a sentry/validator topology, empty if none
+	SentryMode string
+
+	// SentryPeers are the private peers of the topology: the sentries of a validator,
+	// or the validators behind a sentry
+	SentryPeers []enode.ID
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain configuration.
@@ -229,7 +270,9 @@ func CreateConsensusEngine(chainConfig *params.ChainConfig, ethConfig *Config, d
 			}
 
 			var heimdallClient bor.IHeimdallClient
-			if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
+			if ethConfig.DevHeimdall {
+				heimdallClient = heimdalldev.NewHeimdallDevClient(chainConfig, ethConfig.Miner.Etherbase)
+			} else if ethConfig.RunHeimdall && ethConfig.UseHeimdallApp {
 				heimdallClient = heimdallapp.NewHeimdallAppClient()
 			} else if ethConfig.HeimdallgRPCAddress != "" {
 				heimdallClient = heimdallgrpc.NewHeimdallGRPCClient(ethConfig.HeimdallgRPCAddress)
@@ -10,8 +10,10 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool/blobpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/txpool/privatepool"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/miner"
 )
 
@@ -26,6 +28,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		NoPruning                            bool
 		NoPrefetch                           bool
 		TxLookupLimit                        uint64                 `toml:",omitempty"`
+		StateHistory                         uint64                 `toml:",omitempty"`
+		BlockHistory                         uint64                 `toml:",omitempty"`
+		StateScheme                          string                 `toml:",omitempty"`
 		RequiredBlocks                       map[uint64]common.Hash `toml:"-"`
 		LightServ                            int                    `toml:",omitempty"`
 		LightIngress                         int                    `toml:",omitempty"`
@@ -37,6 +42,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		DatabaseHandles                      int                    `toml:"-"`
 		DatabaseCache                        int
 		DatabaseFreezer                      string
+		RemoteDB                             ethdb.Database `toml:"-"`
 		LevelDbCompactionTableSize           uint64
 		LevelDbCompactionTableSizeMultiplier float64
 		LevelDbCompactionTotalSize           uint64
@@ -51,6 +57,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                                miner.Config
 		TxPool                               legacypool.Config
 		BlobPool                             blobpool.Config
+		PrivatePool                          privatepool.Config
 		GPO                                  gasprice.Config
 		EnablePreimageRecording              bool
 		DocRoot                              string `toml:"-"`
@@ -65,6 +72,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RunHeimdall                          bool
 		RunHeimdallArgs                      string
 		UseHeimdallApp                       bool
+		DevHeimdall                          bool
+		VerifySpans                          bool
+		SigningProtection                    bool
 		BorLogs                              bool
 		ParallelEVM                          core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
@@ -79,6 +89,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.NoPruning = c.NoPruning
 	enc.NoPrefetch = c.NoPrefetch
 	enc.TxLookupLimit = c.TxLookupLimit
+	enc.StateHistory = c.StateHistory
+	enc.BlockHistory = c.BlockHistory
+	enc.StateScheme = c.StateScheme
 	enc.RequiredBlocks = c.RequiredBlocks
 	enc.LightServ = c.LightServ
 	enc.LightIngress = c.LightIngress
@@ -90,6 +103,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
 	enc.DatabaseFreezer = c.DatabaseFreezer
+	enc.RemoteDB = c.RemoteDB
 	enc.LevelDbCompactionTableSize = c.LevelDbCompactionTableSize
 	enc.LevelDbCompactionTableSizeMultiplier = c.LevelDbCompactionTableSizeMultiplier
 	enc.LevelDbCompactionTotalSize = c.LevelDbCompactionTotalSize
@@ -104,6 +118,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.PrivatePool = c.PrivatePool
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.DocRoot = c.DocRoot
@@ -118,6 +133,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RunHeimdall = c.RunHeimdall
 	enc.RunHeimdallArgs = c.RunHeimdallArgs
 	enc.UseHeimdallApp = c.UseHeimdallApp
+	enc.DevHeimdall = c.DevHeimdall
+	enc.VerifySpans = c.VerifySpans
+	enc.SigningProtection = c.SigningProtection
 	enc.BorLogs = c.BorLogs
 	enc.ParallelEVM = c.ParallelEVM
 	enc.DevFakeAuthor = c.DevFakeAuthor
@@ -136,6 +154,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		NoPruning                            *bool
 		NoPrefetch                           *bool
 		TxLookupLimit                        *uint64                `toml:",omitempty"`
+		StateHistory                         *uint64                `toml:",omitempty"`
+		BlockHistory                         *uint64                `toml:",omitempty"`
+		StateScheme                          *string                `toml:",omitempty"`
 		RequiredBlocks                       map[uint64]common.Hash `toml:"-"`
 		LightServ                            *int                   `toml:",omitempty"`
 		LightIngress                         *int                   `toml:",omitempty"`
@@ -147,6 +168,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		DatabaseHandles                      *int                   `toml:"-"`
 		DatabaseCache                        *int
 		DatabaseFreezer                      *string
+		RemoteDB                             ethdb.Database `toml:"-"`
 		LevelDbCompactionTableSize           *uint64
 		LevelDbCompactionTableSizeMultiplier *float64
 		LevelDbCompactionTotalSize           *uint64
@@ -161,6 +183,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                                *miner.Config
 		TxPool                               *legacypool.Config
 		BlobPool                             *blobpool.Config
+		PrivatePool                          *privatepool.Config
 		GPO                                  *gasprice.Config
 		EnablePreimageRecording              *bool
 		DocRoot                              *string `toml:"-"`
@@ -175,6 +198,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RunHeimdall                          *bool
 		RunHeimdallArgs                      *string
 		UseHeimdallApp                       *bool
+		DevHeimdall                          *bool
+		VerifySpans                          *bool
+		SigningProtection                    *bool
 		BorLogs                              *bool
 		ParallelEVM                          *core.ParallelEVMConfig `toml:",omitempty"`
 		DevFakeAuthor                        *bool                   `hcl:"devfakeauthor,optional" toml:"devfakeauthor,optional"`
@@ -208,6 +234,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.TxLookupLimit != nil {
 		c.TxLookupLimit = *dec.TxLookupLimit
 	}
+	if dec.StateHistory != nil {
+		c.StateHistory = *dec.StateHistory
+	}
+	if dec.BlockHistory != nil {
+		c.BlockHistory = *dec.BlockHistory
+	}
+	if dec.StateScheme != nil {
+		c.StateScheme = *dec.StateScheme
+	}
 	if dec.RequiredBlocks != nil {
 		c.RequiredBlocks = dec.RequiredBlocks
 	}
@@ -241,6 +276,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DatabaseFreezer != nil {
 		c.DatabaseFreezer = *dec.DatabaseFreezer
 	}
+	if dec.RemoteDB != nil {
+		c.RemoteDB = dec.RemoteDB
+	}
 	if dec.LevelDbCompactionTableSize != nil {
 		c.LevelDbCompactionTableSize = *dec.LevelDbCompactionTableSize
 	}
@@ -283,6 +321,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.PrivatePool != nil {
+		c.PrivatePool = *dec.PrivatePool
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -325,6 +366,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.UseHeimdallApp != nil {
 		c.UseHeimdallApp = *dec.UseHeimdallApp
 	}
+	if dec.DevHeimdall != nil {
+		c.DevHeimdall = *dec.DevHeimdall
+	}
+	if dec.VerifySpans != nil {
+		c.VerifySpans = *dec.VerifySpans
+	}
+	if dec.SigningProtection != nil {
+		c.SigningProtection = *dec.SigningProtection
+	}
 	if dec.BorLogs != nil {
 		c.BorLogs = *dec.BorLogs
 	}
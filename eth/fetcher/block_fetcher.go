@@ -192,6 +192,7 @@ type BlockFetcher struct {
 	insertHeaders  headersInsertFn    // Injects a batch of headers into the chain
 	insertChain    chainInsertFn      // Injects a batch of blocks into the chain
 	dropPeer       peerDropFn         // Drops a peer for misbehaving
+	dropSlowPeer   peerDropFn         // Drops a peer failing to respond in time, dropPeer if nil
 
 	// Testing hooks
 	announceChangeHook func(common.Hash, bool)           // Method to call upon adding or deleting a hash from the blockAnnounce list
@@ -234,6 +235,23 @@ func NewBlockFetcher(light bool, getHeader HeaderRetrievalFn, getBlock blockRetr
 	}
 }
 
+// SetSlowPeerDrop sets the callback dropping the peers failing to respond in
+// time, instead of the one dropping the misbehaving peers. It must be called
+// before the fetcher is started.
+func (f *BlockFetcher) SetSlowPeerDrop(dropSlowPeer func(id string)) {
+	f.dropSlowPeer = dropSlowPeer
+}
+
+// dropUnresponsivePeer drops a peer which didn't respond in time.
+func (f *BlockFetcher) dropUnresponsivePeer(peer string) {
+	if f.dropSlowPeer != nil {
+		f.dropSlowPeer(peer)
+		return
+	}
+
+	f.dropPeer(peer)
+}
+
 // Start boots up the announcement based synchroniser, accepting and processing
 // hash notifications and block fetches until termination requested.
 func (f *BlockFetcher) Start() {
@@ -517,7 +535,7 @@ func (f *BlockFetcher) loop() {
 								// was already rescheduled at this point, we were
 								// waiting for a catchup. With an unresponsive
 								// peer however, it's a protocol violation.
-								f.dropPeer(peer)
+								f.dropUnresponsivePeer(peer)
 							}
 						}(hash)
 					}
@@ -579,7 +597,7 @@ func (f *BlockFetcher) loop() {
 						// was already rescheduled at this point, we were
 						// waiting for a catchup. With an unresponsive
 						// peer however, it's a protocol violation.
-						f.dropPeer(peer)
+						f.dropUnresponsivePeer(peer)
 					}
 				}(peer, hashes)
 			}
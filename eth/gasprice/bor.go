@@ -0,0 +1,344 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// Percentiles of the recent priority fees, weighted by gas used, the safe
+	// and fast estimates are taken at before accounting for the congestion. The
+	// standard estimate is taken at the configured percentile.
+	safePercentile = 30
+	fastPercentile = 90
+
+	// fullBacklog is the backlog of pending transactions, in blocks, at which
+	// the estimates are taken at the highest recent priority fee.
+	fullBacklog = 4
+)
+
+// ErrBorModeDisabled is returned when requesting the gas estimates of an oracle
+// not tuned for Bor.
+var ErrBorModeDisabled = errors.New("bor gas price oracle mode disabled")
+
+// poolBackend is implemented by the backends with a transaction pool, for the
+// estimates to account for the pending backlog.
+type poolBackend interface {
+	Stats() (pending int, queued int)
+}
+
+// GasEstimate is a fee suggestion for a transaction.
+type GasEstimate struct {
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int // Covering the base fee doubling
+}
+
+// GasEstimates are the safe, standard and fast fee suggestions for the block
+// following the head, with the measures of the congestion they account for.
+type GasEstimates struct {
+	BlockNumber uint64   // Head the estimates are computed at
+	BaseFee     *big.Int // Base fee of the next block
+	TipFloor    *big.Int // Minimum priority fee enforced
+
+	SprintGasUsedRatio float64 // Average gas used ratio over the last sprint
+	GasUsedRatioTrend  float64 // Change of the average gas used ratio since the previous sprint
+	PendingBacklog     float64 // Pending transactions of the pool, in blocks
+	Pressure           float64 // Congestion from 0 to 1, raising the percentiles of the estimates
+
+	Safe     GasEstimate
+	Standard GasEstimate
+	Fast     GasEstimate
+}
+
+// blockSample holds the measures of a block sampled for the estimates.
+type blockSample struct {
+	gasUsedRatio float64
+	gasUsed      uint64
+	txs          int
+	tips         []txGasAndReward // Priority fees of the transactions not sent by the producer
+}
+
+// GasEstimates returns the fee suggestions for the next block, accounting for
+// the fullness of the blocks over the last two sprints, for the pending
+// backlog of the pool and for the tip floor.
+func (oracle *Oracle) GasEstimates(ctx context.Context) (*GasEstimates, error) {
+	if !oracle.bor {
+		return nil, ErrBorModeDisabled
+	}
+
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	headHash := head.Hash()
+
+	oracle.cacheLock.RLock()
+	estimates := oracle.lastEstimates
+	oracle.cacheLock.RUnlock()
+
+	if estimates != nil && estimates.head == headHash {
+		return estimates.estimates.copy(), nil
+	}
+
+	oracle.fetchLock.Lock()
+	defer oracle.fetchLock.Unlock()
+
+	// Try checking the cache again, maybe the last fetch computed what we need
+	oracle.cacheLock.RLock()
+	estimates = oracle.lastEstimates
+	oracle.cacheLock.RUnlock()
+
+	if estimates != nil && estimates.head == headHash {
+		return estimates.estimates.copy(), nil
+	}
+
+	// Sample the last two sprints, the current one and the previous one
+	sprint := uint64(oracle.checkBlocks)
+	if config := oracle.backend.ChainConfig(); config.Bor != nil {
+		sprint = config.Bor.CalculateSprint(head.Number.Uint64())
+	}
+
+	var (
+		number  = head.Number.Uint64()
+		current = make([]*blockSample, 0, sprint)
+		prev    = make([]*blockSample, 0, sprint)
+	)
+
+	for i := uint64(0); i < 2*sprint && i <= number; i++ {
+		sample, err := oracle.sampleBlock(ctx, number-i)
+		if err != nil {
+			return nil, err
+		}
+
+		if i < sprint {
+			current = append(current, sample)
+		} else {
+			prev = append(prev, sample)
+		}
+	}
+
+	result := &GasEstimates{
+		BlockNumber: number,
+		BaseFee:     new(big.Int),
+		TipFloor:    new(big.Int).Set(oracle.tipFloor),
+	}
+
+	if config := oracle.backend.ChainConfig(); config.IsLondon(new(big.Int).SetUint64(number + 1)) {
+		result.BaseFee = eip1559.CalcBaseFee(config, head)
+	}
+
+	// Congestion: the fullness of the last sprint, rising if fuller than the
+	// previous one, and the backlog of the pool
+	var (
+		tips    []txGasAndReward
+		gasUsed uint64
+		txs     int
+	)
+
+	for _, sample := range current {
+		result.SprintGasUsedRatio += sample.gasUsedRatio
+		tips = append(tips, sample.tips...)
+		gasUsed += sample.gasUsed
+		txs += sample.txs
+	}
+
+	result.SprintGasUsedRatio /= float64(len(current))
+
+	if len(prev) > 0 {
+		var prevRatio float64
+		for _, sample := range prev {
+			prevRatio += sample.gasUsedRatio
+		}
+
+		result.GasUsedRatioTrend = result.SprintGasUsedRatio - prevRatio/float64(len(prev))
+	}
+
+	if pool, ok := oracle.backend.(poolBackend); ok && head.GasLimit > 0 {
+		pending, _ := pool.Stats()
+
+		txGas := uint64(params.TxGas)
+		if txs > 0 {
+			txGas = gasUsed / uint64(txs)
+		}
+
+		result.PendingBacklog = float64(pending) * float64(txGas) / float64(head.GasLimit)
+	}
+
+	result.Pressure = 2 * (result.SprintGasUsedRatio - 0.5)
+	if backlog := result.PendingBacklog / fullBacklog; backlog > result.Pressure {
+		result.Pressure = backlog
+	}
+
+	if result.GasUsedRatioTrend > 0 {
+		result.Pressure += result.GasUsedRatioTrend
+	}
+
+	result.Pressure = min(max(result.Pressure, 0), 1)
+
+	// The estimates are percentiles of the recent priority fees, raised with
+	// the congestion, never below the tip floor nor above the price cap
+	slices.SortStableFunc(tips, func(a, b txGasAndReward) int {
+		return a.reward.Cmp(b.reward)
+	})
+
+	for _, estimate := range []struct {
+		percentile int
+		target     *GasEstimate
+	}{
+		{safePercentile, &result.Safe},
+		{oracle.percentile, &result.Standard},
+		{fastPercentile, &result.Fast},
+	} {
+		percentile := float64(estimate.percentile) + float64(100-estimate.percentile)*result.Pressure
+
+		tip := gasWeightedPercentile(tips, percentile)
+		if tip == nil || tip.Cmp(oracle.tipFloor) < 0 {
+			tip = new(big.Int).Set(oracle.tipFloor)
+		}
+
+		if tip.Cmp(oracle.maxPrice) > 0 {
+			tip = new(big.Int).Set(oracle.maxPrice)
+		}
+
+		estimate.target.MaxPriorityFeePerGas = tip
+		estimate.target.MaxFeePerGas = new(big.Int).Add(tip, new(big.Int).Mul(result.BaseFee, common.Big2))
+	}
+
+	// The standard and fast estimates never suggest less than the safer ones
+	if result.Standard.MaxPriorityFeePerGas.Cmp(result.Safe.MaxPriorityFeePerGas) < 0 {
+		result.Standard = result.Safe
+	}
+
+	if result.Fast.MaxPriorityFeePerGas.Cmp(result.Standard.MaxPriorityFeePerGas) < 0 {
+		result.Fast = result.Standard
+	}
+
+	oracle.cacheLock.Lock()
+	oracle.lastEstimates = &cachedEstimates{head: headHash, estimates: result}
+	oracle.cacheLock.Unlock()
+
+	return result.copy(), nil
+}
+
+// sampleBlock returns the measures of the given block, cached by hash.
+func (oracle *Oracle) sampleBlock(ctx context.Context, number uint64) (*blockSample, error) {
+	block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(number))
+	if block == nil {
+		if err == nil {
+			err = errors.New("block not found")
+		}
+
+		return nil, err
+	}
+
+	if sample, ok := oracle.sampleCache.Get(block.Hash()); ok {
+		return sample, nil
+	}
+
+	sample := &blockSample{gasUsed: block.GasUsed(), txs: len(block.Transactions())}
+	if block.GasLimit() > 0 {
+		sample.gasUsedRatio = float64(block.GasUsed()) / float64(block.GasLimit())
+	}
+
+	if len(block.Transactions()) > 0 {
+		receipts, err := oracle.backend.GetReceipts(ctx, block.Hash())
+		if err != nil {
+			return nil, err
+		}
+
+		signer := types.MakeSigner(oracle.backend.ChainConfig(), block.Number(), block.Time())
+
+		for i, tx := range block.Transactions() {
+			if i >= len(receipts) {
+				break
+			}
+
+			tip, _ := tx.EffectiveGasTip(block.BaseFee())
+			if oracle.ignorePrice != nil && tip.Cmp(oracle.ignorePrice) < 0 {
+				continue
+			}
+
+			// The producer's own transactions don't compete for the block space
+			if sender, err := types.Sender(signer, tx); err != nil || sender == block.Coinbase() {
+				continue
+			}
+
+			sample.tips = append(sample.tips, txGasAndReward{gasUsed: receipts[i].GasUsed, reward: tip})
+		}
+	}
+
+	oracle.sampleCache.Add(block.Hash(), sample)
+
+	return sample, nil
+}
+
+// gasWeightedPercentile returns the priority fee at the given percentile of the
+// gas used by the transactions sorted by ascending fee, nil if there is none.
+func gasWeightedPercentile(sorted []txGasAndReward, percentile float64) *big.Int {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	var total uint64
+	for _, tx := range sorted {
+		total += tx.gasUsed
+	}
+
+	var (
+		threshold = uint64(float64(total) * percentile / 100)
+		sum       = sorted[0].gasUsed
+		index     int
+	)
+
+	for sum < threshold && index < len(sorted)-1 {
+		index++
+		sum += sorted[index].gasUsed
+	}
+
+	return new(big.Int).Set(sorted[index].reward)
+}
+
+// cachedEstimates are the estimates computed at a head.
+type cachedEstimates struct {
+	head      common.Hash
+	estimates *GasEstimates
+}
+
+// copy returns a deep copy of the estimates.
+func (e *GasEstimates) copy() *GasEstimates {
+	cpy := *e
+	cpy.BaseFee = new(big.Int).Set(e.BaseFee)
+	cpy.TipFloor = new(big.Int).Set(e.TipFloor)
+
+	for _, estimate := range []*GasEstimate{&cpy.Safe, &cpy.Standard, &cpy.Fast} {
+		estimate.MaxPriorityFeePerGas = new(big.Int).Set(estimate.MaxPriorityFeePerGas)
+		estimate.MaxFeePerGas = new(big.Int).Set(estimate.MaxFeePerGas)
+	}
+
+	return &cpy
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// poolTestBackend is a test backend with pending transactions in its pool.
+type poolTestBackend struct {
+	*testBackend
+	pending int
+}
+
+func (b *poolTestBackend) Stats() (int, int) {
+	return b.pending, 0
+}
+
+func TestGasEstimates(t *testing.T) {
+	gwei := func(n int64) *big.Int { return big.NewInt(n * params.GWei) }
+
+	var cases = []struct {
+		pending  int      // Pending transactions of the pool
+		floor    *big.Int // Tip floor
+		safe     *big.Int
+		standard *big.Int
+		fast     *big.Int
+	}{
+		// The tips sampled are 30G, 31G and 32G, the blocks are almost empty
+		{0, nil, gwei(30), gwei(31), gwei(32)},
+		{0, gwei(31), gwei(31), gwei(31), gwei(32)},
+		{0, gwei(40), gwei(40), gwei(40), gwei(40)},
+		// A backlog of pending transactions raises all the estimates
+		{10000, nil, gwei(32), gwei(32), gwei(32)},
+	}
+
+	for i, c := range cases {
+		backend := newTestBackend(t, big.NewInt(0), false)
+		oracle := NewOracle(&poolTestBackend{backend, c.pending}, Config{
+			Blocks:     3,
+			Percentile: 60,
+			Default:    big.NewInt(params.GWei),
+			Bor:        true,
+			TipFloor:   c.floor,
+		})
+
+		estimates, err := oracle.GasEstimates(context.Background())
+		if err != nil {
+			backend.teardown()
+			t.Fatalf("case %d: failed to retrieve gas estimates: %v", i, err)
+		}
+
+		tip, err := oracle.SuggestTipCap(context.Background())
+
+		backend.teardown()
+
+		if err != nil {
+			t.Fatalf("case %d: failed to retrieve recommended tip: %v", i, err)
+		}
+
+		if estimates.BlockNumber != testHead {
+			t.Errorf("case %d: block number mismatch: have %d, want %d", i, estimates.BlockNumber, testHead)
+		}
+
+		for _, estimate := range []struct {
+			name   string
+			have   GasEstimate
+			expect *big.Int
+		}{
+			{"safe", estimates.Safe, c.safe},
+			{"standard", estimates.Standard, c.standard},
+			{"fast", estimates.Fast, c.fast},
+		} {
+			if estimate.have.MaxPriorityFeePerGas.Cmp(estimate.expect) != 0 {
+				t.Errorf("case %d: %s tip mismatch: have %v, want %v", i, estimate.name, estimate.have.MaxPriorityFeePerGas, estimate.expect)
+			}
+
+			maxFee := new(big.Int).Add(estimate.expect, new(big.Int).Mul(estimates.BaseFee, big.NewInt(2)))
+			if estimate.have.MaxFeePerGas.Cmp(maxFee) != 0 {
+				t.Errorf("case %d: %s fee cap mismatch: have %v, want %v", i, estimate.name, estimate.have.MaxFeePerGas, maxFee)
+			}
+		}
+
+		if tip.Cmp(c.standard) != 0 {
+			t.Errorf("case %d: recommended tip mismatch: have %v, want %v", i, tip, c.standard)
+		}
+
+		if (c.pending > 0) != (estimates.Pressure > 0) {
+			t.Errorf("case %d: unexpected pressure %f with %d pending transactions", i, estimates.Pressure, c.pending)
+		}
+	}
+}
+
+func TestGasEstimatesDisabled(t *testing.T) {
+	backend := newTestBackend(t, big.NewInt(0), false)
+	defer backend.teardown()
+
+	oracle := NewOracle(backend, Config{Blocks: 3, Percentile: 60, Default: big.NewInt(params.GWei)})
+
+	if _, err := oracle.GasEstimates(context.Background()); !errors.Is(err, ErrBorModeDisabled) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrBorModeDisabled)
+	}
+}
@@ -47,6 +47,11 @@ type Config struct {
 	Default          *big.Int `toml:",omitempty"`
 	MaxPrice         *big.Int `toml:",omitempty"`
 	IgnorePrice      *big.Int `toml:",omitempty"`
+
+	// Bor enables the estimates accounting for the fullness of the blocks over
+	// the sprints, for the pending backlog and for the tip floor.
+	Bor      bool
+	TipFloor *big.Int `toml:",omitempty"`
 }
 
 // OracleBackend includes all necessary background APIs for oracle.
@@ -74,6 +79,11 @@ type Oracle struct {
 	maxHeaderHistory, maxBlockHistory uint64
 
 	historyCache *lru.Cache[cacheKey, processedFees]
+
+	bor           bool
+	tipFloor      *big.Int
+	sampleCache   *lru.Cache[common.Hash, *blockSample]
+	lastEstimates *cachedEstimates
 }
 
 // NewOracle returns a new gasprice oracle which can recommend suitable
@@ -120,6 +130,11 @@ func NewOracle(backend OracleBackend, params Config) *Oracle {
 		log.Warn("Sanitizing invalid gasprice oracle max block history", "provided", params.MaxBlockHistory, "updated", maxBlockHistory)
 	}
 
+	tipFloor := params.TipFloor
+	if tipFloor == nil || tipFloor.Sign() < 0 {
+		tipFloor = new(big.Int)
+	}
+
 	cache := lru.NewCache[cacheKey, processedFees](2048)
 	headEvent := make(chan core.ChainHeadEvent, 1)
 	backend.SubscribeChainHeadEvent(headEvent)
@@ -145,6 +160,9 @@ func NewOracle(backend OracleBackend, params Config) *Oracle {
 		maxHeaderHistory: maxHeaderHistory,
 		maxBlockHistory:  maxBlockHistory,
 		historyCache:     cache,
+		bor:              params.Bor,
+		tipFloor:         tipFloor,
+		sampleCache:      lru.NewCache[common.Hash, *blockSample](2048),
 	}
 }
 
@@ -169,8 +187,17 @@ func (oracle *Oracle) ProcessCache() {
 //
 // Note, for legacy transactions and the legacy eth_gasPrice RPC call, it will be
 // necessary to add the basefee to the returned number to fall back to the legacy
-// behavior.
+// behavior. In the Bor mode, the standard estimate is returned.
 func (oracle *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
+	if oracle.bor {
+		estimates, err := oracle.GasEstimates(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return estimates.Standard.MaxPriorityFeePerGas, nil
+	}
+
 	head, _ := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
 	headHash := head.Hash()
 
@@ -26,6 +26,7 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/core"
@@ -42,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 )
 
 const (
@@ -98,6 +100,8 @@ type handlerConfig struct {
 	RequiredBlocks      map[uint64]common.Hash // Hard coded map of required block hashes for sync challenges
 	EthAPI              *ethapi.BlockChainAPI  // EthAPI to interact
 	enableBlockTracking bool                   // Whether to log information collected while tracking block lifecycle
+	sentryMode          string                 // Role of the node in a sentry/validator topology, if any
+	sentryPeers         []enode.ID             // Private peers of the sentry/validator topology
 }
 
 type handler struct {
@@ -117,6 +121,7 @@ type handler struct {
 	txFetcher    *fetcher.TxFetcher
 	peers        *peerSet
 	merger       *consensus.Merger
+	propagation  *propagationTracker
 
 	ethAPI *ethapi.BlockChainAPI // EthAPI to interact
 
@@ -129,6 +134,12 @@ type handler struct {
 
 	enableBlockTracking bool
 
+	sentryMode     string
+	sentryPeers    map[string]struct{}
+	priorityBlocks *lru.Cache[common.Hash, struct{}]
+
+	reputation *peerReputation
+
 	// channels for fetcher, syncer, txsyncLoop
 	quitSync chan struct{}
 
@@ -154,14 +165,23 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		txpool:              config.TxPool,
 		chain:               config.Chain,
 		peers:               newPeerSet(),
+		propagation:         newPropagationTracker(),
 		merger:              config.Merger,
 		ethAPI:              config.EthAPI,
 		requiredBlocks:      config.RequiredBlocks,
 		enableBlockTracking: config.enableBlockTracking,
+		sentryMode:          config.sentryMode,
+		sentryPeers:         make(map[string]struct{}, len(config.sentryPeers)),
+		priorityBlocks:      lru.NewCache[common.Hash, struct{}](priorityBlocksCacheSize),
+		reputation:          newPeerReputation(config.Database),
 		quitSync:            make(chan struct{}),
 		handlerDoneCh:       make(chan struct{}),
 		handlerStartCh:      make(chan struct{}),
 	}
+	for _, id := range config.sentryPeers {
+		h.sentryPeers[id.String()] = struct{}{}
+	}
+
 	if config.Sync == downloader.FullSync {
 		// The database seems empty as the current block is the genesis. Yet the snap
 		// block is ahead, so snap sync was enabled for this node at a certain point.
@@ -295,11 +315,15 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		n, err := h.chain.InsertChain(blocks)
 		if err == nil {
 			h.acceptTxs.Store(true) // Mark initial sync done on any fetcher import
+			h.trackImport(blocks)
+		} else {
+			h.trackImport(blocks[:n])
 		}
 
 		return n, err
 	}
-	h.blockFetcher = fetcher.NewBlockFetcher(false, nil, h.chain.GetBlockByHash, validator, h.BroadcastBlock, heighter, nil, inserter, h.removePeer, h.enableBlockTracking)
+	h.blockFetcher = fetcher.NewBlockFetcher(false, nil, h.chain.GetBlockByHash, validator, h.BroadcastBlock, heighter, nil, inserter, h.dropMisbehavingPeer, h.enableBlockTracking)
+	h.blockFetcher.SetSlowPeerDrop(h.dropSlowPeer)
 
 	fetchTx := func(peer string, hashes []common.Hash) error {
 		p := h.peers.peer(peer)
@@ -369,6 +393,11 @@ func (h *handler) runEthPeer(peer *eth.Peer, handler eth.Handler) error {
 		peer.Log().Error("Snapshot extension barrier failed", "err", err)
 		return err
 	}
+	// Reject the banned peers, once their `snap` extension is accounted for
+	if ban := h.reputation.banned(peer.ID(), time.Now()); ban != nil {
+		peer.Log().Debug("Rejecting banned peer", "until", ban.Until, "reason", ban.Reason)
+		return errPeerBanned
+	}
 
 	// Execute the Ethereum handshake
 	var (
@@ -616,8 +645,12 @@ func (h *handler) BroadcastBlock(block *types.Block, propagate bool) {
 			log.Error("Propagating dangling block", "number", block.Number(), "hash", hash)
 			return
 		}
-		// Send the block to a subset of our peers
+		// Send the block to a subset of our peers, or to all of them if it
+		// has priority in the sentry topology
 		transfer := peers[:int(math.Sqrt(float64(len(peers))))]
+		if h.priorityBroadcast(hash) {
+			transfer = peers
+		}
 		for _, peer := range transfer {
 			peer.AsyncSendNewBlock(block, td)
 		}
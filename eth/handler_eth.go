@@ -95,6 +95,17 @@ func (h *ethHandler) handleBlockAnnounces(peer *eth.Peer, hashes []common.Hash,
 		return nil
 		// return errors.New("unexpected block announces")
 	}
+	// Track the sightings of the announced blocks
+	if p := h.peers.peer(peer.ID()); p != nil {
+		now := time.Now()
+		for _, hash := range hashes {
+			(*handler)(h).trackAnnounce(p, hash, now)
+		}
+	}
+
+	for _, hash := range hashes {
+		(*handler)(h).trackPriorityBlock(peer.ID(), hash)
+	}
 	// Schedule all the unknown hashes for retrieval
 	var (
 		unknownHashes  = make([]common.Hash, 0, len(hashes))
@@ -126,6 +137,13 @@ func (h *ethHandler) handleBlockBroadcast(peer *eth.Peer, block *types.Block, td
 		return nil
 		// return errors.New("unexpected block announces")
 	}
+	// Track the sighting of the block, before it gets imported
+	if p := h.peers.peer(peer.ID()); p != nil {
+		(*handler)(h).trackBlock(p, block.Header(), h.chain.HasBlock(block.Hash(), block.NumberU64()), time.Now())
+	}
+
+	(*handler)(h).trackPriorityBlock(peer.ID(), block.Hash())
+
 	// Schedule the block for import
 	h.blockFetcher.Enqueue(peer.ID(), block)
 
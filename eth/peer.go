@@ -24,20 +24,23 @@ import (
 // ethPeerInfo represents a short summary of the `eth` sub-protocol metadata known
 // about a connected peer.
 type ethPeerInfo struct {
-	Version uint `json:"version"` // Ethereum protocol version negotiated
+	Version     uint             `json:"version"`     // Ethereum protocol version negotiated
+	Propagation *PeerPropagation `json:"propagation"` // Blocks relayed by the peer
 }
 
 // ethPeer is a wrapper around eth.Peer to maintain a few extra metadata.
 type ethPeer struct {
 	*eth.Peer
-	snapExt *snapPeer // Satellite `snap` connection
+	snapExt     *snapPeer        // Satellite `snap` connection
+	propagation *peerPropagation // Blocks relayed by the peer
 }
 
 // info gathers and returns some `eth` protocol metadata known about a peer.
 // nolint:typecheck
 func (p *ethPeer) info() *ethPeerInfo {
 	return &ethPeerInfo{
-		Version: p.Version(),
+		Version:     p.Version(),
+		Propagation: p.propagation.summary(),
 	}
 }
 
@@ -154,7 +154,8 @@ func (ps *peerSet) registerPeer(peer *eth.Peer, ext *snap.Peer) error {
 	}
 
 	eth := &ethPeer{
-		Peer: peer,
+		Peer:        peer,
+		propagation: newPeerPropagation(),
 	}
 	if ext != nil {
 		eth.snapExt = &snapPeer{ext}
@@ -0,0 +1,368 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// propagationCacheSize is the number of recent blocks whose first sighting
+	// is remembered.
+	propagationCacheSize = 1024
+
+	// lateBlockThreshold is the delay after its timestamp past which a block is
+	// considered late, the period of a bor block.
+	lateBlockThreshold = 2 * time.Second
+)
+
+var (
+	blockDelayTimer     = metrics.NewRegisteredTimer("eth/propagation/block/delay", nil)
+	blockLagTimer       = metrics.NewRegisteredTimer("eth/propagation/block/lag", nil)
+	blockDuplicateMeter = metrics.NewRegisteredMeter("eth/propagation/block/duplicate", nil)
+	blockLateMeter      = metrics.NewRegisteredMeter("eth/propagation/block/late", nil)
+	announceLagTimer    = metrics.NewRegisteredTimer("eth/propagation/announce/lag", nil)
+)
+
+// PeerPropagation is a summary of the block announcements and broadcasts relayed
+// by a peer. Delays are measured from the block timestamps, lags from the first
+// sighting of the blocks from any peer.
+type PeerPropagation struct {
+	Announces      uint64        `json:"announces"`      // Block announcements received
+	FirstAnnounces uint64        `json:"firstAnnounces"` // Announcements of blocks not seen before
+	AnnounceLag    time.Duration `json:"announceLag"`    // Mean lag of the announcements of blocks seen before
+
+	Blocks      uint64        `json:"blocks"`      // Full blocks received
+	FirstBlocks uint64        `json:"firstBlocks"` // Full blocks not seen before
+	Duplicates  uint64        `json:"duplicates"`  // Full blocks already seen or known
+	Late        uint64        `json:"late"`        // Full blocks received past the late threshold
+	BlockLag    time.Duration `json:"blockLag"`    // Mean lag of the duplicate full blocks
+	MeanDelay   time.Duration `json:"meanDelay"`   // Mean delay of the full blocks
+	MaxDelay    time.Duration `json:"maxDelay"`    // Maximum delay of the full blocks
+
+	Producers map[common.Address]*ProducerPropagation `json:"producers,omitempty"` // Full blocks by producer
+}
+
+// ProducerPropagation is a summary of the full blocks of a producer relayed by
+// a peer.
+type ProducerPropagation struct {
+	Blocks      uint64        `json:"blocks"`      // Full blocks received
+	FirstBlocks uint64        `json:"firstBlocks"` // Full blocks not seen before
+	MeanDelay   time.Duration `json:"meanDelay"`   // Mean delay of the full blocks
+}
+
+// blockSighting is the reception of a full block from a peer, accounted for by
+// producer once the block is imported and its seal verified.
+type blockSighting struct {
+	peer  *peerPropagation
+	first bool
+	delay time.Duration
+}
+
+// propagationTracker remembers the first sighting of the recently propagated
+// blocks, to measure how early each peer relays them.
+type propagationTracker struct {
+	announces lru.BasicLRU[common.Hash, time.Time]
+	blocks    lru.BasicLRU[common.Hash, time.Time]
+	sightings lru.BasicLRU[common.Hash, []*blockSighting] // Receptions of the blocks not imported yet
+	lock      sync.Mutex
+}
+
+func newPropagationTracker() *propagationTracker {
+	return &propagationTracker{
+		announces: lru.NewBasicLRU[common.Hash, time.Time](propagationCacheSize),
+		blocks:    lru.NewBasicLRU[common.Hash, time.Time](propagationCacheSize),
+		sightings: lru.NewBasicLRU[common.Hash, []*blockSighting](propagationCacheSize),
+	}
+}
+
+// announce records the announcement of a block, returning whether it is its
+// first sighting and otherwise the lag behind it.
+func (t *propagationTracker) announce(hash common.Hash, now time.Time) (bool, time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if seen, ok := t.blocks.Get(hash); ok {
+		return false, now.Sub(seen)
+	}
+
+	if seen, ok := t.announces.Get(hash); ok {
+		return false, now.Sub(seen)
+	}
+
+	t.announces.Add(hash, now)
+
+	return true, 0
+}
+
+// block records the reception of a full block, returning whether it is its
+// first sighting and otherwise the lag behind it. Announcements don't count
+// as sightings of full blocks.
+func (t *propagationTracker) block(hash common.Hash, now time.Time) (bool, time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if seen, ok := t.blocks.Get(hash); ok {
+		return false, now.Sub(seen)
+	}
+
+	t.blocks.Add(hash, now)
+
+	return true, 0
+}
+
+// sighted records the reception of a full block not imported yet, once per peer.
+func (t *propagationTracker) sighted(hash common.Hash, sighting *blockSighting) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	sightings, _ := t.sightings.Get(hash)
+	for _, s := range sightings {
+		if s.peer == sighting.peer {
+			return
+		}
+	}
+
+	t.sightings.Add(hash, append(sightings, sighting))
+}
+
+// imported returns the receptions of an imported block, forgetting them.
+func (t *propagationTracker) imported(hash common.Hash) []*blockSighting {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	sightings, _ := t.sightings.Get(hash)
+	t.sightings.Remove(hash)
+
+	return sightings
+}
+
+// producerStats accumulates the full blocks of a producer relayed by a peer.
+type producerStats struct {
+	blocks      uint64
+	firstBlocks uint64
+	delay       time.Duration
+}
+
+// peerPropagation accumulates the blocks relayed by a peer.
+type peerPropagation struct {
+	announces      uint64
+	firstAnnounces uint64
+	announceLag    time.Duration
+
+	blocks      uint64
+	firstBlocks uint64
+	duplicates  uint64
+	late        uint64
+	blockLag    time.Duration
+	delay       time.Duration
+	maxDelay    time.Duration
+
+	producers map[common.Address]*producerStats
+	lock      sync.Mutex
+}
+
+func newPeerPropagation() *peerPropagation {
+	return &peerPropagation{
+		producers: make(map[common.Address]*producerStats),
+	}
+}
+
+// announce accounts for a block announcement.
+func (p *peerPropagation) announce(first bool, lag time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.announces++
+
+	if first {
+		p.firstAnnounces++
+	} else {
+		p.announceLag += lag
+	}
+}
+
+// block accounts for a full block received the given delay after its timestamp.
+func (p *peerPropagation) block(first bool, lag time.Duration, delay time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.blocks++
+
+	if first {
+		p.firstBlocks++
+	} else {
+		p.duplicates++
+		p.blockLag += lag
+	}
+
+	if delay > lateBlockThreshold {
+		p.late++
+	}
+
+	p.delay += delay
+	if delay > p.maxDelay {
+		p.maxDelay = delay
+	}
+}
+
+// producerBlock accounts for a full block of the given producer, received the
+// given delay after its timestamp.
+func (p *peerPropagation) producerBlock(producer common.Address, first bool, delay time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	stats := p.producers[producer]
+	if stats == nil {
+		stats = new(producerStats)
+		p.producers[producer] = stats
+	}
+
+	stats.blocks++
+	stats.delay += delay
+
+	if first {
+		stats.firstBlocks++
+	}
+}
+
+// summary returns the propagation summary of the peer.
+func (p *peerPropagation) summary() *PeerPropagation {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	summary := &PeerPropagation{
+		Announces:      p.announces,
+		FirstAnnounces: p.firstAnnounces,
+		Blocks:         p.blocks,
+		FirstBlocks:    p.firstBlocks,
+		Duplicates:     p.duplicates,
+		Late:           p.late,
+		MaxDelay:       p.maxDelay,
+		Producers:      make(map[common.Address]*ProducerPropagation, len(p.producers)),
+	}
+
+	// The lags are only accounted for the blocks seen before
+	if lagged := p.announces - p.firstAnnounces; lagged > 0 {
+		summary.AnnounceLag = p.announceLag / time.Duration(lagged)
+	}
+
+	if p.duplicates > 0 {
+		summary.BlockLag = p.blockLag / time.Duration(p.duplicates)
+	}
+
+	if p.blocks > 0 {
+		summary.MeanDelay = p.delay / time.Duration(p.blocks)
+	}
+
+	for producer, stats := range p.producers {
+		summary.Producers[producer] = &ProducerPropagation{
+			Blocks:      stats.blocks,
+			FirstBlocks: stats.firstBlocks,
+			MeanDelay:   stats.delay / time.Duration(stats.blocks),
+		}
+	}
+
+	return summary
+}
+
+// trackAnnounce accounts for a block announcement received from a peer.
+func (h *handler) trackAnnounce(peer *ethPeer, hash common.Hash, now time.Time) {
+	first, lag := h.propagation.announce(hash, now)
+	if !first {
+		announceLagTimer.Update(lag)
+	}
+
+	peer.propagation.announce(first, lag)
+}
+
+// trackBlock accounts for a full block received from a peer. Blocks already in
+// the chain, like the locally produced ones, are duplicates. The block is only
+// accounted for by producer once imported, the producer of an unverified block
+// could be anyone.
+func (h *handler) trackBlock(peer *ethPeer, header *types.Header, known bool, now time.Time) {
+	first, lag := h.propagation.block(header.Hash(), now)
+	first = first && !known
+
+	delay := now.Sub(time.Unix(int64(header.Time), 0))
+	if delay < 0 {
+		delay = 0
+	}
+
+	if first {
+		blockDelayTimer.Update(delay)
+
+		if delay > lateBlockThreshold {
+			blockLateMeter.Mark(1)
+		}
+	} else {
+		blockDuplicateMeter.Mark(1)
+		blockLagTimer.Update(lag)
+	}
+
+	peer.propagation.block(first, lag, delay)
+
+	sighting := &blockSighting{peer: peer.propagation, first: first, delay: delay}
+	if known {
+		h.trackProducer(header, []*blockSighting{sighting})
+	} else {
+		h.propagation.sighted(header.Hash(), sighting)
+	}
+}
+
+// trackImport accounts for the receptions of the given imported blocks by
+// producer.
+func (h *handler) trackImport(blocks types.Blocks) {
+	for _, block := range blocks {
+		if sightings := h.propagation.imported(block.Hash()); len(sightings) > 0 {
+			h.trackProducer(block.Header(), sightings)
+		}
+	}
+}
+
+// trackProducer accounts for the receptions of a verified block by producer.
+func (h *handler) trackProducer(header *types.Header, sightings []*blockSighting) {
+	producer, err := h.chain.Engine().Author(header)
+	if err != nil {
+		return
+	}
+
+	for _, sighting := range sightings {
+		if sighting.first {
+			metrics.GetOrRegisterTimer("eth/propagation/producer/"+producer.Hex()+"/delay", nil).Update(sighting.delay)
+		}
+
+		sighting.peer.producerBlock(producer, sighting.first, sighting.delay)
+	}
+}
+
+// peerPropagation returns the propagation summary of the given peer, or nil if
+// it isn't connected.
+func (h *handler) peerPropagation(id string) *PeerPropagation {
+	peer := h.peers.peer(id)
+	if peer == nil {
+		return nil
+	}
+
+	return peer.propagation.summary()
+}
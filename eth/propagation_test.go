@@ -0,0 +1,124 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that the blocks relayed by the peers are accounted for relative to
+// their first sighting and their timestamp.
+func TestBlockPropagationTracking(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	var (
+		fast     = &ethPeer{propagation: newPeerPropagation()}
+		slow     = &ethPeer{propagation: newPeerPropagation()}
+		producer = common.Address{0x01}
+		header   = &types.Header{Number: big.NewInt(1), Time: 1000, Coinbase: producer, Difficulty: big.NewInt(1)}
+		stamp    = time.Unix(int64(header.Time), 0)
+	)
+
+	// The fast peer announces and relays the block first, the slow one late
+	handler.handler.trackAnnounce(fast, header.Hash(), stamp.Add(100*time.Millisecond))
+	handler.handler.trackAnnounce(slow, header.Hash(), stamp.Add(400*time.Millisecond))
+	handler.handler.trackBlock(fast, header, false, stamp.Add(500*time.Millisecond))
+	handler.handler.trackBlock(slow, header, false, stamp.Add(3*time.Second))
+
+	// Both relay the genesis, already known locally
+	genesis := handler.chain.Genesis().Header()
+
+	handler.handler.trackBlock(fast, genesis, true, time.Unix(int64(genesis.Time), 0))
+	handler.handler.trackBlock(slow, genesis, true, time.Unix(int64(genesis.Time), 0))
+
+	// The producer of the block is accounted for once it is imported only
+	if producers := fast.propagation.summary().Producers; producers[producer] != nil {
+		t.Fatalf("unverified block accounted for by producer: %+v", producers[producer])
+	}
+
+	handler.handler.trackImport(types.Blocks{types.NewBlockWithHeader(header)})
+
+	summary := fast.propagation.summary()
+	if summary.Announces != 1 || summary.FirstAnnounces != 1 || summary.AnnounceLag != 0 {
+		t.Fatalf("fast peer announces mismatch: %+v", summary)
+	}
+
+	if summary.Blocks != 2 || summary.FirstBlocks != 1 || summary.Duplicates != 1 || summary.Late != 0 {
+		t.Fatalf("fast peer blocks mismatch: %+v", summary)
+	}
+
+	if summary.MaxDelay != 500*time.Millisecond {
+		t.Fatalf("fast peer max delay mismatch: have %v, want %v", summary.MaxDelay, 500*time.Millisecond)
+	}
+
+	if stats := summary.Producers[producer]; stats == nil || stats.Blocks != 1 || stats.FirstBlocks != 1 || stats.MeanDelay != 500*time.Millisecond {
+		t.Fatalf("fast peer producer mismatch: %+v", stats)
+	}
+
+	summary = slow.propagation.summary()
+	if summary.Announces != 1 || summary.FirstAnnounces != 0 || summary.AnnounceLag != 300*time.Millisecond {
+		t.Fatalf("slow peer announces mismatch: %+v", summary)
+	}
+
+	if summary.Blocks != 2 || summary.FirstBlocks != 0 || summary.Duplicates != 2 || summary.Late != 1 || summary.BlockLag != 1250*time.Millisecond {
+		t.Fatalf("slow peer blocks mismatch: %+v", summary)
+	}
+
+	if summary.MaxDelay != 3*time.Second {
+		t.Fatalf("slow peer max delay mismatch: have %v, want %v", summary.MaxDelay, 3*time.Second)
+	}
+
+	if stats := summary.Producers[producer]; stats == nil || stats.Blocks != 1 || stats.FirstBlocks != 0 || stats.MeanDelay != 3*time.Second {
+		t.Fatalf("slow peer producer mismatch: %+v", stats)
+	}
+}
+
+// Tests that the mean lags of a peer are only averaged over the blocks seen
+// before, the first sightings having no lag.
+func TestPeerPropagationLag(t *testing.T) {
+	t.Parallel()
+
+	p := newPeerPropagation()
+
+	if summary := p.summary(); summary.AnnounceLag != 0 || summary.BlockLag != 0 {
+		t.Fatalf("empty summary lags mismatch: %+v", summary)
+	}
+
+	p.announce(true, 0)
+	p.announce(false, 200*time.Millisecond)
+	p.announce(false, 400*time.Millisecond)
+
+	p.block(true, 0, time.Second)
+	p.block(false, 300*time.Millisecond, time.Second)
+
+	summary := p.summary()
+	if summary.AnnounceLag != 300*time.Millisecond {
+		t.Fatalf("announce lag mismatch: have %v, want %v", summary.AnnounceLag, 300*time.Millisecond)
+	}
+
+	if summary.BlockLag != 300*time.Millisecond {
+		t.Fatalf("block lag mismatch: have %v, want %v", summary.BlockLag, 300*time.Millisecond)
+	}
+}
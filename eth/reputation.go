@@ -0,0 +1,333 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// banThreshold is the reputation score at which a peer gets banned.
+	banThreshold = -100
+
+	// mismatchPenalty is the penalty of a peer serving a chain contradicting the
+	// whitelisted checkpoint or milestone, banning it right away.
+	mismatchPenalty = banThreshold
+
+	// misbehaviourPenalty is the penalty of a peer dropped by the syncer or the
+	// fetcher for a protocol violation or serving invalid data.
+	misbehaviourPenalty = -20
+
+	// timeoutPenalty is the penalty of a peer dropped for failing to deliver in
+	// time, which a well behaving peer may do under load.
+	timeoutPenalty = -5
+
+	// scoreRecoveryInterval is the time it takes for a penalised peer to recover
+	// a point of reputation.
+	scoreRecoveryInterval = time.Minute
+
+	// peerBanDuration is how long a peer is banned for once its reputation drops
+	// to the threshold.
+	peerBanDuration = 6 * time.Hour
+)
+
+var (
+	errPeerBanned = errors.New("peer banned")
+
+	peerPenaltyMeter = metrics.NewRegisteredMeter("eth/reputation/penalties", nil)
+	peerBanMeter     = metrics.NewRegisteredMeter("eth/reputation/bans", nil)
+)
+
+// PeerReputation is the reputation of a peer, along with its ban if any.
+type PeerReputation struct {
+	Score       int       `json:"score"`                 // Reputation score, 0 for a well behaving peer
+	Banned      bool      `json:"banned"`                // Whether the peer is banned
+	BannedUntil time.Time `json:"bannedUntil,omitempty"` // Expiry of the ban
+	Reason      string    `json:"reason,omitempty"`      // Why the peer got banned
+}
+
+// PeerBan is a timed ban of a peer.
+type PeerBan struct {
+	ID     string    `json:"id"`     // Node ID of the peer
+	Until  time.Time `json:"until"`  // Expiry of the ban
+	Reason string    `json:"reason"` // Why the peer got banned
+}
+
+// peerScore is the reputation score of a peer, recovering over time.
+type peerScore struct {
+	value   int
+	updated time.Time
+}
+
+// peerReputation scores the peers on their protocol errors, and bans the ones
+// whose score drops to the threshold. Bans are persisted across restarts.
+type peerReputation struct {
+	db     ethdb.KeyValueStore
+	scores map[string]*peerScore
+	bans   map[string]*PeerBan
+	lock   sync.Mutex
+}
+
+// newPeerReputation creates a reputation tracker, restoring the bans persisted
+// in the database.
+func newPeerReputation(db ethdb.KeyValueStore) *peerReputation {
+	r := &peerReputation{
+		db:     db,
+		scores: make(map[string]*peerScore),
+		bans:   make(map[string]*PeerBan),
+	}
+
+	now := time.Now()
+
+	for _, ban := range rawdb.ReadPeerBans(db) {
+		until := time.Unix(int64(ban.Until), 0)
+		if !until.After(now) {
+			rawdb.DeletePeerBan(db, ban.ID)
+			continue
+		}
+
+		r.bans[ban.ID] = &PeerBan{ID: ban.ID, Until: until, Reason: ban.Reason}
+	}
+
+	if len(r.bans) > 0 {
+		log.Info("Restored peer bans", "count", len(r.bans))
+	}
+
+	return r
+}
+
+// score returns the current score of a peer, recovered since its last update.
+// The lock must be held by the caller.
+func (r *peerReputation) score(id string, now time.Time) *peerScore {
+	score := r.scores[id]
+	if score == nil {
+		return &peerScore{updated: now}
+	}
+
+	if recovered := int(now.Sub(score.updated) / scoreRecoveryInterval); recovered > 0 {
+		score.value += recovered
+		score.updated = score.updated.Add(time.Duration(recovered) * scoreRecoveryInterval)
+	}
+
+	if score.value >= 0 {
+		delete(r.scores, id)
+		return &peerScore{updated: now}
+	}
+
+	return score
+}
+
+// penalise lowers the score of a peer, banning it if it drops to the threshold.
+// It returns whether the peer got banned.
+func (r *peerReputation) penalise(id string, penalty int, reason string, now time.Time) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	peerPenaltyMeter.Mark(1)
+
+	score := r.score(id, now)
+	score.value += penalty
+
+	if score.value > banThreshold {
+		r.scores[id] = score
+		return false
+	}
+
+	delete(r.scores, id)
+	r.ban(id, now.Add(peerBanDuration), reason)
+
+	return true
+}
+
+// ban bans a peer until the given time, persisting the ban. The lock must be held
+// by the caller.
+func (r *peerReputation) ban(id string, until time.Time, reason string) {
+	peerBanMeter.Mark(1)
+
+	r.bans[id] = &PeerBan{ID: id, Until: until, Reason: reason}
+	rawdb.WritePeerBan(r.db, &rawdb.PeerBan{ID: id, Until: uint64(until.Unix()), Reason: reason})
+}
+
+// banUntil bans a peer until the given time, regardless of its score.
+func (r *peerReputation) banUntil(id string, until time.Time, reason string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.scores, id)
+	r.ban(id, until, reason)
+}
+
+// unban lifts the ban of a peer and resets its score, returning whether it was
+// banned.
+func (r *peerReputation) unban(id string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.scores, id)
+
+	if _, ok := r.bans[id]; !ok {
+		return false
+	}
+
+	delete(r.bans, id)
+	rawdb.DeletePeerBan(r.db, id)
+
+	return true
+}
+
+// banned returns the ban of a peer, or nil if it isn't banned. Expired bans are
+// lifted on the go.
+func (r *peerReputation) banned(id string, now time.Time) *PeerBan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	ban := r.bans[id]
+	if ban == nil {
+		return nil
+	}
+
+	if !ban.Until.After(now) {
+		delete(r.bans, id)
+		rawdb.DeletePeerBan(r.db, id)
+
+		return nil
+	}
+
+	return ban
+}
+
+// reputation returns the reputation of a peer.
+func (r *peerReputation) reputation(id string, now time.Time) *PeerReputation {
+	ban := r.banned(id, now)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rep := &PeerReputation{
+		Score: r.score(id, now).value,
+	}
+
+	if ban != nil {
+		rep.Banned, rep.BannedUntil, rep.Reason = true, ban.Until, ban.Reason
+	}
+
+	return rep
+}
+
+// list returns the bans in force, sorted by peer ID.
+func (r *peerReputation) list(now time.Time) []*PeerBan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	bans := make([]*PeerBan, 0, len(r.bans))
+
+	for id, ban := range r.bans {
+		if !ban.Until.After(now) {
+			delete(r.bans, id)
+			rawdb.DeletePeerBan(r.db, id)
+
+			continue
+		}
+
+		bans = append(bans, &PeerBan{ID: ban.ID, Until: ban.Until, Reason: ban.Reason})
+	}
+
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].ID < bans[j].ID
+	})
+
+	return bans
+}
+
+// exemptPeer returns whether the peer is exempt from the reputation, being one
+// of the trusted, static or sentry peers configured by the operator.
+func (h *handler) exemptPeer(id string) bool {
+	if _, ok := h.sentryPeers[id]; ok {
+		return true
+	}
+
+	if peer := h.peers.peer(id); peer != nil {
+		info := peer.Peer.Info()
+		return info.Network.Trusted || info.Network.Static
+	}
+
+	return false
+}
+
+// penalisePeer lowers the reputation of a peer, disconnecting it if it gets
+// banned. It returns whether the peer got banned.
+func (h *handler) penalisePeer(id string, penalty int, reason string) bool {
+	if h.exemptPeer(id) {
+		return false
+	}
+
+	if !h.reputation.penalise(id, penalty, reason, time.Now()) {
+		return false
+	}
+
+	log.Warn("Banning misbehaving peer", "peer", id, "reason", reason, "duration", peerBanDuration)
+	h.removePeer(id)
+
+	return true
+}
+
+// dropMisbehavingPeer penalises and disconnects a peer dropped by the fetcher
+// for a protocol violation.
+func (h *handler) dropMisbehavingPeer(id string) {
+	if !h.penalisePeer(id, misbehaviourPenalty, "dropped for misbehaving") {
+		h.removePeer(id)
+	}
+}
+
+// dropSlowPeer penalises lightly and disconnects a peer dropped by the fetcher
+// for failing to respond in time.
+func (h *handler) dropSlowPeer(id string) {
+	if !h.penalisePeer(id, timeoutPenalty, "dropped for timing out") {
+		h.removePeer(id)
+	}
+}
+
+// trackSyncFailure penalises the peer a sync failed with: heavily if it served a
+// chain contradicting the whitelisted checkpoint or milestone, as much as the
+// fetcher if it served invalid data and lightly if it timed out. The syncer
+// disconnects the peer itself.
+func (h *handler) trackSyncFailure(id string, err error) {
+	switch {
+	case errors.Is(err, whitelist.ErrMismatch) || errors.Is(err, whitelist.ErrCheckpointMismatch):
+		h.penalisePeer(id, mismatchPenalty, err.Error())
+	case downloader.IsPeerViolation(err):
+		h.penalisePeer(id, misbehaviourPenalty, err.Error())
+	case downloader.IsPeerTimeout(err):
+		h.penalisePeer(id, timeoutPenalty, err.Error())
+	}
+}
+
+// banPeer bans a peer for the given duration, disconnecting it.
+func (h *handler) banPeer(id string, duration time.Duration, reason string) {
+	h.reputation.banUntil(id, time.Now().Add(duration), reason)
+	h.removePeer(id)
+}
@@ -0,0 +1,163 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Tests that misbehaving peers get banned once their score drops to the threshold,
+// that their score recovers over time and that bans survive restarts.
+func TestPeerReputation(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db   = rawdb.NewMemoryDatabase()
+		rep  = newPeerReputation(db)
+		now  = time.Now()
+		peer = enode.ID{1}.String()
+	)
+
+	// Misbehaving a bit less than the threshold doesn't get a peer banned
+	drops := -banThreshold/-misbehaviourPenalty - 1
+	for i := 0; i < drops; i++ {
+		if rep.penalise(peer, misbehaviourPenalty, "dropped", now) {
+			t.Fatalf("peer banned after %d drops", i+1)
+		}
+	}
+
+	if score := rep.reputation(peer, now).Score; score != drops*misbehaviourPenalty {
+		t.Fatalf("score mismatch: have %d, want %d", score, drops*misbehaviourPenalty)
+	}
+
+	// The score recovers over time, postponing the ban
+	later := now.Add(-misbehaviourPenalty * scoreRecoveryInterval)
+	if rep.penalise(peer, misbehaviourPenalty, "dropped", later) {
+		t.Fatalf("peer banned despite recovering")
+	}
+
+	if !rep.penalise(peer, misbehaviourPenalty, "dropped", later) {
+		t.Fatalf("peer not banned past the threshold")
+	}
+
+	if ban := rep.banned(peer, later); ban == nil || !ban.Until.Equal(later.Add(peerBanDuration)) {
+		t.Fatalf("ban mismatch: %+v", ban)
+	}
+
+	// Bans are restored on restart, unless expired
+	other := enode.ID{2}.String()
+	rep.banUntil(other, now.Add(-time.Second), "expired")
+
+	rep = newPeerReputation(db)
+	if bans := rep.list(later); len(bans) != 1 || bans[0].ID != peer || bans[0].Reason != "dropped" {
+		t.Fatalf("restored bans mismatch: %v", bans)
+	}
+
+	if bans := rawdb.ReadPeerBans(db); len(bans) != 1 {
+		t.Fatalf("expired ban not deleted: %d bans stored", len(bans))
+	}
+
+	// Unbanning lifts the ban and resets the score
+	if !rep.unban(peer) {
+		t.Fatalf("banned peer not unbanned")
+	}
+
+	if rep := rep.reputation(peer, later); rep.Banned || rep.Score != 0 {
+		t.Fatalf("unbanned peer reputation mismatch: %+v", rep)
+	}
+
+	if bans := rawdb.ReadPeerBans(db); len(bans) != 0 {
+		t.Fatalf("lifted ban not deleted: %d bans stored", len(bans))
+	}
+}
+
+// Tests that peers serving a chain contradicting the whitelisted milestones or
+// checkpoints get banned, and rejected on their next connection.
+func TestWhitelistMismatchBan(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	id := enode.ID{1}
+
+	// Failures unrelated to the whitelist don't affect the reputation
+	handler.handler.trackSyncFailure(id.String(), errors.New("sync failed"))
+	if rep := handler.handler.reputation.reputation(id.String(), time.Now()); rep.Score != 0 {
+		t.Fatalf("reputation affected by unrelated failure: %+v", rep)
+	}
+
+	handler.handler.trackSyncFailure(id.String(), fmt.Errorf("%w: checkpoint", whitelist.ErrMismatch))
+	if rep := handler.handler.reputation.reputation(id.String(), time.Now()); !rep.Banned {
+		t.Fatalf("peer not banned on whitelist mismatch: %+v", rep)
+	}
+
+	// Connect the banned peer, it should be rejected
+	p2pSrc, p2pSink := p2p.MsgPipe()
+	defer p2pSrc.Close()
+	defer p2pSink.Close()
+
+	sink := eth.NewPeer(eth.ETH68, p2p.NewPeerPipe(id, "", nil, p2pSink), p2pSink, handler.txpool)
+	defer sink.Close()
+
+	err := handler.handler.runEthPeer(sink, func(peer *eth.Peer) error { return nil })
+	if !errors.Is(err, errPeerBanned) {
+		t.Fatalf("banned peer error mismatch: have %v, want %v", err, errPeerBanned)
+	}
+}
+
+// Tests that the peers configured by the operator are never penalised, and that
+// timeouts weigh less than protocol violations.
+func TestPeerPenalties(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	var (
+		sentry = enode.ID{1}.String()
+		peer   = enode.ID{2}.String()
+	)
+
+	handler.handler.sentryPeers[sentry] = struct{}{}
+
+	handler.handler.trackSyncFailure(sentry, fmt.Errorf("%w: checkpoint", whitelist.ErrMismatch))
+	handler.handler.dropMisbehavingPeer(sentry)
+
+	if rep := handler.handler.reputation.reputation(sentry, time.Now()); rep.Banned || rep.Score != 0 {
+		t.Fatalf("sentry peer penalised: %+v", rep)
+	}
+
+	handler.handler.dropSlowPeer(peer)
+	if rep := handler.handler.reputation.reputation(peer, time.Now()); rep.Score != timeoutPenalty {
+		t.Fatalf("timeout penalty mismatch: have %d, want %d", rep.Score, timeoutPenalty)
+	}
+
+	handler.handler.dropMisbehavingPeer(peer)
+	if rep := handler.handler.reputation.reputation(peer, time.Now()); rep.Score != timeoutPenalty+misbehaviourPenalty {
+		t.Fatalf("misbehaviour penalty mismatch: have %d, want %d", rep.Score, timeoutPenalty+misbehaviourPenalty)
+	}
+}
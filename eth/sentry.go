@@ -0,0 +1,55 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+)
+
+// priorityBlocksCacheSize is the number of recent blocks relayed by validators
+// remembered by a sentry.
+const priorityBlocksCacheSize = 128
+
+// isSentryPeer returns whether the given peer is a private peer of the sentry
+// topology: a sentry of this validator, or a validator behind this sentry.
+func (h *handler) isSentryPeer(id string) bool {
+	_, ok := h.sentryPeers[id]
+	return ok
+}
+
+// trackPriorityBlock marks the blocks relayed by the validators behind a sentry,
+// to be propagated to all its peers at once.
+func (h *handler) trackPriorityBlock(id string, hash common.Hash) {
+	if h.sentryMode == ethconfig.SentryModeSentry && h.isSentryPeer(id) {
+		h.priorityBlocks.Add(hash, struct{}{})
+	}
+}
+
+// priorityBroadcast returns whether a block is to be sent in full to all peers,
+// instead of a subset of them. A validator sends its blocks to all its sentries,
+// which relay them to all their peers.
+func (h *handler) priorityBroadcast(hash common.Hash) bool {
+	switch h.sentryMode {
+	case ethconfig.SentryModeValidator:
+		return true
+	case ethconfig.SentryModeSentry:
+		return h.priorityBlocks.Contains(hash)
+	default:
+		return false
+	}
+}
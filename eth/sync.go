@@ -275,6 +275,7 @@ func (h *handler) doSync(op *chainSyncOp) error {
 	// Run the sync cycle, and disable snap sync if we're past the pivot block
 	err := h.downloader.LegacySync(op.peer.ID(), op.head, op.td, h.chain.Config().TerminalTotalDifficulty, op.mode)
 	if err != nil {
+		h.trackSyncFailure(op.peer.ID(), err)
 		return err
 	}
 	if h.snapSync.Load() {
@@ -205,6 +205,7 @@ type TraceConfig struct {
 	TracerConfig    json.RawMessage
 	BorTraceEnabled *bool
 	BorTx           *bool
+	BorSystemCalls  *bool // Trace the system calls made by Bor when finalizing the block
 }
 
 // TraceCallConfig is the config for traceCall API. It holds one more
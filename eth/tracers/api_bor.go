@@ -2,25 +2,42 @@ package tracers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+var errBorSystemCallsUnsupported = errors.New("system calls can only be traced with the bor engine")
+
+// borSystemCaller is implemented by consensus engines which are able to re-execute
+// the system calls made when finalizing a block (i.e. Bor).
+type borSystemCaller interface {
+	TraceSystemCalls(ctx context.Context, chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, tracer vm.EVMLogger) error
+}
+
 type BlockTraceResult struct {
 	// Trace of each transaction executed
 	Transactions []*TxTraceResult `json:"transactions,omitempty"`
 
 	// Block that we are executing on the trace
 	Block interface{} `json:"block"`
+
+	// Trace of the system calls made when finalizing the block
+	SystemCalls json.RawMessage `json:"systemCalls,omitempty"`
 }
 
 type TxTraceResult struct {
@@ -134,9 +151,78 @@ func (api *API) traceBorBlock(ctx context.Context, block *types.Block, config *T
 		}
 	}
 
+	if config != nil && config.BorSystemCalls != nil && *config.BorSystemCalls {
+		res.SystemCalls, err = api.traceBorSystemCalls(ctx, block, statedb)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return res, nil
 }
 
+// traceBorSystemCalls re-executes the system calls made when finalizing the block
+// on top of the given state, which must be the state after all its transactions,
+// and returns their traces.
+func (api *API) traceBorSystemCalls(ctx context.Context, block *types.Block, statedb *state.StateDB) (json.RawMessage, error) {
+	engine, ok := api.backend.Engine().(borSystemCaller)
+	if !ok {
+		return nil, errBorSystemCallsUnsupported
+	}
+
+	tracer, err := DefaultDirectory.New("borSystemCallTracer", &Context{BlockHash: block.Hash(), BlockNumber: block.Number()}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := engine.TraceSystemCalls(ctx, &borChainReader{ctx: ctx, backend: api.backend}, block.Header(), statedb, tracer); err != nil {
+		return nil, err
+	}
+
+	return tracer.GetResult()
+}
+
+// borChainReader implements consensus.ChainHeaderReader on top of the tracing
+// backend, for the consensus engine to access the chain while re-executing the
+// system calls.
+type borChainReader struct {
+	ctx     context.Context
+	backend Backend
+}
+
+func (r *borChainReader) Config() *params.ChainConfig {
+	return r.backend.ChainConfig()
+}
+
+func (r *borChainReader) CurrentHeader() *types.Header {
+	header, _ := r.backend.HeaderByNumber(r.ctx, rpc.LatestBlockNumber)
+	return header
+}
+
+func (r *borChainReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header, _ := r.backend.HeaderByHash(r.ctx, hash)
+	if header == nil || header.Number.Uint64() != number {
+		return nil
+	}
+
+	return header
+}
+
+func (r *borChainReader) GetHeaderByNumber(number uint64) *types.Header {
+	header, _ := r.backend.HeaderByNumber(r.ctx, rpc.BlockNumber(number))
+	return header
+}
+
+func (r *borChainReader) GetHeaderByHash(hash common.Hash) *types.Header {
+	header, _ := r.backend.HeaderByHash(r.ctx, hash)
+	return header
+}
+
+// GetTd is not available through the tracing backend, nor needed by the system calls.
+func (r *borChainReader) GetTd(hash common.Hash, number uint64) *big.Int {
+	return nil
+}
+
 type TraceBlockRequest struct {
 	Number     int64
 	Hash       string
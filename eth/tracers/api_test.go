@@ -533,6 +533,9 @@ func TestIOdump(t *testing.T) {
 
 	*ioflag = true
 
+	// The IO dump is written to the trace path
+	path := t.TempDir()
+
 	var testSuite = []struct {
 		blockNumber rpc.BlockNumber
 		config      *TraceConfig
@@ -543,6 +546,7 @@ func TestIOdump(t *testing.T) {
 		{
 			config: &TraceConfig{
 				IOFlag: ioflag,
+				Path:   &path,
 			},
 			blockNumber: rpc.BlockNumber(genBlocks),
 			want:        `[{"result":{"gas":21000,"failed":false,"returnValue":"","structLogs":[]}},{"result":{"gas":21000,"failed":false,"returnValue":"","structLogs":[]}},{"result":{"gas":21000,"failed":false,"returnValue":"","structLogs":[]}},{"result":{"gas":21000,"failed":false,"returnValue":"","structLogs":[]}},{"result":{"gas":21000,"failed":false,"returnValue":"","structLogs":[]}}]`,
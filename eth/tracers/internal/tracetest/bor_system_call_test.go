@@ -0,0 +1,120 @@
+package tracetest
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/contract"
+	"github.com/ethereum/go-ethereum/consensus/bor/statefull"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// noopChainContext is a core.ChainContext for system calls which don't need
+// to access the chain.
+type noopChainContext struct{}
+
+func (noopChainContext) Engine() consensus.Engine                    { return nil }
+func (noopChainContext) GetHeader(common.Hash, uint64) *types.Header { return nil }
+
+// revertingCode returns contract code which reverts with the given reason.
+func revertingCode(t *testing.T, reason string) []byte {
+	t.Helper()
+
+	typ, _ := abi.NewType("string", "", nil)
+
+	data, err := (abi.Arguments{{Type: typ}}).Pack(reason)
+	if err != nil {
+		t.Fatalf("failed to pack revert reason: %v", err)
+	}
+	// Error(string) selector followed by the encoded reason
+	payload := append(common.FromHex("0x08c379a0"), data...)
+
+	var size [2]byte
+	binary.BigEndian.PutUint16(size[:], uint16(len(payload)))
+
+	// PUSH2 size, PUSH2 15, PUSH1 0, CODECOPY, PUSH2 size, PUSH1 0, REVERT
+	code := []byte{0x61, size[0], size[1], 0x61, 0x00, 0x0f, 0x60, 0x00, 0x39, 0x61, size[0], size[1], 0x60, 0x00, 0xfd}
+
+	return append(code, payload...)
+}
+
+// Tests that the system call tracer reports the span and state-sync event IDs of
+// the system calls, along with their gas usage and revert reasons.
+func TestBorSystemCallTracer(t *testing.T) {
+	var (
+		config        = params.BorUnittestChainConfig
+		validatorSet  = common.HexToAddress(config.Bor.ValidatorContract)
+		stateReceiver = common.HexToAddress(config.Bor.StateReceiverContract)
+		header        = &types.Header{Number: big.NewInt(16), Difficulty: common.Big1, GasLimit: 30_000_000}
+	)
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		t.Fatalf("failed to create state: %v", err)
+	}
+	statedb.SetCode(stateReceiver, revertingCode(t, "no receiver"))
+
+	tracer, err := tracers.DefaultDirectory.New("borSystemCallTracer", new(tracers.Context), nil)
+	if err != nil {
+		t.Fatalf("failed to create tracer: %v", err)
+	}
+	ctx := statefull.WithTracer(context.Background(), tracer)
+
+	// Commit a span, followed by a state-sync event
+	spanData, err := contract.ValidatorSet().Pack("commitSpan", big.NewInt(7), big.NewInt(6656), big.NewInt(13055), []byte{}, []byte{})
+	if err != nil {
+		t.Fatalf("failed to pack commitSpan: %v", err)
+	}
+	if _, err := statefull.ApplyMessage(ctx, statefull.GetSystemMessage(validatorSet, spanData), statedb, header, config, noopChainContext{}); err != nil {
+		t.Fatalf("failed to commit span: %v", err)
+	}
+	record, err := rlp.EncodeToBytes(&clerk.EventRecord{ID: 42, ChainID: config.ChainID.String()})
+	if err != nil {
+		t.Fatalf("failed to encode event record: %v", err)
+	}
+	stateData, err := contract.StateReceiver().Pack("commitState", big.NewInt(1), record)
+	if err != nil {
+		t.Fatalf("failed to pack commitState: %v", err)
+	}
+	if _, err := statefull.ApplyMessage(ctx, statefull.GetSystemMessage(stateReceiver, stateData), statedb, header, config, noopChainContext{}); err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to get trace result: %v", err)
+	}
+	var calls []struct {
+		Method       string  `json:"method"`
+		SpanID       *uint64 `json:"spanId"`
+		EventID      *uint64 `json:"eventId"`
+		Error        string  `json:"error"`
+		RevertReason string  `json:"revertReason"`
+	}
+	if err := json.Unmarshal(res, &calls); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if len(calls) != 2 {
+		t.Fatalf("system call count mismatch: have %d, want 2", len(calls))
+	}
+	if calls[0].Method != "commitSpan" || calls[0].SpanID == nil || *calls[0].SpanID != 7 || calls[0].Error != "" {
+		t.Fatalf("span commit mismatch: %s", res)
+	}
+	if calls[1].Method != "commitState" || calls[1].EventID == nil || *calls[1].EventID != 42 {
+		t.Fatalf("state commit mismatch: %s", res)
+	}
+	if calls[1].Error != "execution reverted" || calls[1].RevertReason != "no receiver" {
+		t.Fatalf("state commit failure mismatch: %s", res)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/clerk"
+	"github.com/ethereum/go-ethereum/consensus/bor/contract"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func init() {
+	tracers.DefaultDirectory.Register("borSystemCallTracer", newBorSystemCallTracer, false)
+}
+
+// borSystemCall is a single system call made by Bor when finalizing a block,
+// along with the Heimdall object it commits.
+type borSystemCall struct {
+	Method       string         `json:"method"`
+	SpanID       *uint64        `json:"spanId,omitempty"`
+	EventID      *uint64        `json:"eventId,omitempty"`
+	GasUsed      hexutil.Uint64 `json:"gasUsed"`
+	Error        string         `json:"error,omitempty"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Call         callFrame      `json:"call"`
+}
+
+// borSystemCallTracer is a native go tracer which collects the system calls
+// made by Bor when finalizing a block: commitSpan on the validator set contract
+// and commitState on the state receiver contract. Every system call is run in
+// its own EVM, so unlike the callTracer, it expects several top-level calls.
+type borSystemCallTracer struct {
+	noopTracer
+	calls     []borSystemCall
+	callstack []callFrame
+	interrupt atomic.Bool // Atomic flag to signal execution interruption
+	reason    error       // Textual reason for the interruption
+}
+
+// newBorSystemCallTracer returns a native go tracer which tracks the system
+// calls of a Bor block, and implements vm.EVMLogger.
+func newBorSystemCallTracer(ctx *tracers.Context, _ json.RawMessage) (tracers.Tracer, error) {
+	return &borSystemCallTracer{}, nil
+}
+
+// CaptureStart implements the EVMLogger interface to initialize tracing a
+// system call.
+func (t *borSystemCallTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	toCopy := to
+
+	t.callstack = []callFrame{{
+		Type:  vm.CALL,
+		From:  from,
+		To:    &toCopy,
+		Input: common.CopyBytes(input),
+		Gas:   gas,
+		Value: value,
+	}}
+}
+
+// CaptureEnd is called after a system call finishes to finalize its tracing.
+func (t *borSystemCallTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if len(t.callstack) != 1 {
+		return
+	}
+
+	frame := t.callstack[0]
+	frame.GasUsed = gasUsed
+	frame.processOutput(output, err)
+
+	call := borSystemCall{
+		GasUsed:      hexutil.Uint64(gasUsed),
+		Error:        frame.Error,
+		RevertReason: frame.RevertReason,
+		Call:         frame,
+	}
+	call.decode(frame.Input)
+
+	t.calls = append(t.calls, call)
+	t.callstack = nil
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *borSystemCallTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	// Skip if tracing was interrupted
+	if t.interrupt.Load() || len(t.callstack) == 0 {
+		return
+	}
+
+	toCopy := to
+	t.callstack = append(t.callstack, callFrame{
+		Type:  typ,
+		From:  from,
+		To:    &toCopy,
+		Input: common.CopyBytes(input),
+		Gas:   gas,
+		Value: value,
+	})
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *borSystemCallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	size := len(t.callstack)
+	if size <= 1 {
+		return
+	}
+	// pop call
+	call := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+	size -= 1
+
+	call.GasUsed = gasUsed
+	call.processOutput(output, err)
+	t.callstack[size-1].Calls = append(t.callstack[size-1].Calls, call)
+}
+
+// GetResult returns the json-encoded list of system calls, and any error
+// arising from the encoding or forceful termination (via `Stop`).
+func (t *borSystemCallTracer) GetResult() (json.RawMessage, error) {
+	calls := t.calls
+	if calls == nil {
+		calls = []borSystemCall{}
+	}
+
+	res, err := json.Marshal(calls)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.RawMessage(res), t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *borSystemCallTracer) Stop(err error) {
+	t.reason = err
+	t.interrupt.Store(true)
+}
+
+// decode fills the method and the Heimdall object ID of a system call from its
+// input. Unknown calls are left with the method set only.
+func (c *borSystemCall) decode(input []byte) {
+	c.Method = "unknown"
+
+	if len(input) < 4 {
+		return
+	}
+
+	validatorSet, stateReceiver := contract.ValidatorSet(), contract.StateReceiver()
+
+	if method, err := validatorSet.MethodById(input[:4]); err == nil && method.Name == "commitSpan" {
+		c.Method = method.Name
+
+		args, err := method.Inputs.Unpack(input[4:])
+		if err != nil || len(args) == 0 {
+			return
+		}
+
+		if id, ok := args[0].(*big.Int); ok {
+			spanID := id.Uint64()
+			c.SpanID = &spanID
+		}
+
+		return
+	}
+
+	if method, err := stateReceiver.MethodById(input[:4]); err == nil && method.Name == "commitState" {
+		c.Method = method.Name
+
+		args, err := method.Inputs.Unpack(input[4:])
+		if err != nil || len(args) < 2 {
+			return
+		}
+
+		recordBytes, ok := args[1].([]byte)
+		if !ok {
+			return
+		}
+
+		var record clerk.EventRecord
+		if err := rlp.DecodeBytes(recordBytes, &record); err == nil {
+			c.EventID = &record.ID
+		}
+	}
+}
@@ -0,0 +1,464 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/remotedb/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// batchWorkers is the number of batched key lookups in flight at once.
+const batchWorkers = 16
+
+var (
+	errNotFound = errors.New("not found")
+	errClosed   = errors.New("database closed")
+
+	// errKeyPair is returned when only one of the certificate and key is configured.
+	errKeyPair = errors.New("certificate and key must be set together")
+)
+
+// keyRequest is a pending key lookup, batched with the concurrent ones.
+type keyRequest struct {
+	key   []byte
+	value []byte
+	found bool
+	err   error
+	done  chan struct{}
+}
+
+// Client is a read-only database served by a remote Server over gRPC. Concurrent
+// key lookups are batched together, and iterators stream the items in chunks.
+type Client struct {
+	conn   *grpc.ClientConn
+	client proto.DatabaseClient
+
+	hasQueue chan *keyRequest
+	getQueue chan *keyRequest
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Dial connects to the database server listening at the given address, over
+// TLS if a configuration is given, in plain text otherwise.
+func Dial(addr string, config *tls.Config) (*Client, error) {
+	creds := insecure.NewCredentials()
+	if config != nil {
+		creds = credentials.NewTLS(config)
+	}
+
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	db := &Client{
+		conn:     conn,
+		client:   proto.NewDatabaseClient(conn),
+		hasQueue: make(chan *keyRequest),
+		getQueue: make(chan *keyRequest),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	db.wg.Add(2 * batchWorkers)
+
+	for i := 0; i < batchWorkers; i++ {
+		go db.batchLoop(db.hasQueue, db.fetchHas)
+		go db.batchLoop(db.getQueue, db.fetchGet)
+	}
+
+	return db, nil
+}
+
+// ClientTLSConfig returns the TLS configuration of a client, authenticating with
+// the certificate and key if any, and verifying the server with the certificate
+// authority if any, the system ones otherwise.
+func ClientTLSConfig(cert, key, ca string) (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if (cert == "") != (key == "") {
+		return nil, errKeyPair
+	}
+
+	if cert != "" {
+		pair, err := tls.LoadX509KeyPair(cert, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the client certificate: %w", err)
+		}
+
+		config.Certificates = []tls.Certificate{pair}
+	}
+
+	if ca != "" {
+		pool, err := loadCertPool(ca)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
+
+// loadCertPool loads the certificates of the given PEM file.
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificate found in %s", path)
+	}
+
+	return pool, nil
+}
+
+// batchLoop serves the lookups of a queue, sending the ones pending together in
+// a single request.
+func (db *Client) batchLoop(queue chan *keyRequest, fetch func([]*keyRequest) error) {
+	defer db.wg.Done()
+
+	for {
+		var batch []*keyRequest
+
+		select {
+		case req := <-queue:
+			batch = append(batch, req)
+		case <-db.ctx.Done():
+			return
+		}
+
+	drain:
+		for len(batch) < maxBatchKeys {
+			select {
+			case req := <-queue:
+				batch = append(batch, req)
+			default:
+				break drain
+			}
+		}
+
+		err := fetch(batch)
+
+		for _, req := range batch {
+			if err != nil {
+				req.err = err
+			}
+
+			close(req.done)
+		}
+	}
+}
+
+// lookup queues a key lookup and waits for its result.
+func (db *Client) lookup(queue chan *keyRequest, key []byte) *keyRequest {
+	req := &keyRequest{key: key, done: make(chan struct{})}
+
+	select {
+	case queue <- req:
+		<-req.done
+	case <-db.ctx.Done():
+		req.err = errClosed
+	}
+
+	return req
+}
+
+func (db *Client) fetchHas(batch []*keyRequest) error {
+	keys := make([][]byte, len(batch))
+	for i, req := range batch {
+		keys[i] = req.key
+	}
+
+	resp, err := db.client.Has(db.ctx, &proto.KeysRequest{Keys: keys})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Found) != len(batch) {
+		return fmt.Errorf("invalid response: %d results for %d keys", len(resp.Found), len(batch))
+	}
+
+	for i, req := range batch {
+		req.found = resp.Found[i]
+	}
+
+	return nil
+}
+
+func (db *Client) fetchGet(batch []*keyRequest) error {
+	keys := make([][]byte, len(batch))
+	for i, req := range batch {
+		keys[i] = req.key
+	}
+
+	resp, err := db.client.Get(db.ctx, &proto.KeysRequest{Keys: keys})
+	if err != nil {
+		return err
+	}
+
+	if len(resp.Values) != len(batch) {
+		return fmt.Errorf("invalid response: %d results for %d keys", len(resp.Values), len(batch))
+	}
+
+	for i, req := range batch {
+		req.found, req.value = resp.Values[i].Found, resp.Values[i].Data
+	}
+
+	return nil
+}
+
+func (db *Client) Has(key []byte) (bool, error) {
+	req := db.lookup(db.hasQueue, key)
+	return req.found, req.err
+}
+
+func (db *Client) Get(key []byte) ([]byte, error) {
+	req := db.lookup(db.getQueue, key)
+	if req.err != nil {
+		return nil, req.err
+	}
+
+	if !req.found {
+		return nil, errNotFound
+	}
+
+	return req.value, nil
+}
+
+func (db *Client) HasAncient(kind string, number uint64) (bool, error) {
+	if _, err := db.Ancient(kind, number); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (db *Client) Ancient(kind string, number uint64) ([]byte, error) {
+	values, err := db.AncientRange(kind, number, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) != 1 {
+		return nil, fmt.Errorf("invalid response: %d items for one", len(values))
+	}
+
+	return values[0], nil
+}
+
+func (db *Client) AncientRange(kind string, start, count, maxBytes uint64) ([][]byte, error) {
+	resp, err := db.client.AncientRange(db.ctx, &proto.AncientRangeRequest{Kind: kind, Start: start, Count: count, MaxBytes: maxBytes})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Values, nil
+}
+
+func (db *Client) Ancients() (uint64, error) {
+	resp, err := db.client.Ancients(db.ctx, &proto.AncientsRequest{})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Frozen, nil
+}
+
+func (db *Client) Tail() (uint64, error) {
+	resp, err := db.client.Ancients(db.ctx, &proto.AncientsRequest{})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Tail, nil
+}
+
+func (db *Client) AncientSize(kind string) (uint64, error) {
+	resp, err := db.client.AncientSize(db.ctx, &proto.AncientSizeRequest{Kind: kind})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Size, nil
+}
+
+func (db *Client) ReadAncients(fn func(op ethdb.AncientReaderOp) error) (err error) {
+	return fn(db)
+}
+
+func (db *Client) Put(key []byte, value []byte) error {
+	panic("not supported")
+}
+
+func (db *Client) Delete(key []byte) error {
+	panic("not supported")
+}
+
+func (db *Client) ModifyAncients(f func(ethdb.AncientWriteOp) error) (int64, error) {
+	panic("not supported")
+}
+
+func (db *Client) TruncateHead(n uint64) (uint64, error) {
+	panic("not supported")
+}
+
+func (db *Client) TruncateTail(n uint64) (uint64, error) {
+	panic("not supported")
+}
+
+func (db *Client) Sync() error {
+	return nil
+}
+
+func (db *Client) MigrateTable(s string, f func([]byte) ([]byte, error)) error {
+	panic("not supported")
+}
+
+func (db *Client) NewBatch() ethdb.Batch {
+	panic("not supported")
+}
+
+func (db *Client) NewBatchWithSize(size int) ethdb.Batch {
+	panic("not supported")
+}
+
+func (db *Client) NewIterator(prefix []byte, start []byte) ethdb.Iterator {
+	ctx, cancel := context.WithCancel(db.ctx)
+
+	stream, err := db.client.Iterate(ctx, &proto.IterateRequest{Prefix: prefix, Start: start})
+	if err != nil {
+		cancel()
+		return &iterator{err: err, done: true, cancel: cancel}
+	}
+
+	return &iterator{stream: stream, cancel: cancel}
+}
+
+func (db *Client) Stat(property string) (string, error) {
+	resp, err := db.client.Stat(db.ctx, &proto.StatRequest{Property: property})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Value, nil
+}
+
+func (db *Client) AncientDatadir() (string, error) {
+	panic("not supported")
+}
+
+func (db *Client) Compact(start []byte, limit []byte) error {
+	return nil
+}
+
+func (db *Client) NewSnapshot() (ethdb.Snapshot, error) {
+	panic("not supported")
+}
+
+func (db *Client) Close() error {
+	db.cancel()
+	db.wg.Wait()
+
+	return db.conn.Close()
+}
+
+// iterator walks the items streamed by the server, chunk by chunk.
+type iterator struct {
+	stream proto.Database_IterateClient
+	cancel context.CancelFunc
+
+	items []*proto.KeyValue
+	key   []byte
+	value []byte
+	err   error
+	done  bool
+}
+
+func (it *iterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	for len(it.items) == 0 {
+		resp, err := it.stream.Recv()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				it.err = err
+			}
+
+			it.key, it.value, it.done = nil, nil, true
+			it.cancel()
+
+			return false
+		}
+
+		it.items = resp.Items
+	}
+
+	it.key, it.value = it.items[0].Key, it.items[0].Value
+	it.items = it.items[1:]
+
+	return true
+}
+
+func (it *iterator) Error() error {
+	return it.err
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+func (it *iterator) Release() {
+	it.cancel()
+	it.items, it.key, it.value, it.done = nil, nil, nil, true
+}
@@ -0,0 +1,353 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// serve starts serving the given database, returning a client connected to it.
+func serve(t *testing.T, db ethdb.Database) *Client {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer()
+	NewServer(db).Register(srv)
+
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	client, err := Dial(lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+
+	t.Cleanup(func() { client.Close() })
+
+	return client
+}
+
+func TestClientKeyValue(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	// Store more items than streamed in a single iteration response
+	var keys [][]byte
+
+	for i := 0; i < 3*maxIterateItems; i++ {
+		key := binary.BigEndian.AppendUint32([]byte("a"), uint32(i))
+		if err := db.Put(key, key[1:]); err != nil {
+			t.Fatalf("failed to put: %v", err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := db.Put([]byte("b"), []byte("other")); err != nil {
+		t.Fatalf("failed to put: %v", err)
+	}
+
+	client := serve(t, db)
+
+	// Look up the keys concurrently, to be batched together
+	var (
+		wg     sync.WaitGroup
+		failed = make(chan error, 2*len(keys))
+	)
+
+	for _, key := range keys {
+		wg.Add(1)
+
+		go func(key []byte) {
+			defer wg.Done()
+
+			if value, err := client.Get(key); err != nil || !bytes.Equal(value, key[1:]) {
+				failed <- err
+			}
+
+			if has, err := client.Has(key); err != nil || !has {
+				failed <- err
+			}
+		}(key)
+	}
+
+	wg.Wait()
+	close(failed)
+
+	for err := range failed {
+		t.Fatalf("lookup failed: %v", err)
+	}
+
+	if _, err := client.Get([]byte("missing")); err == nil {
+		t.Fatalf("missing key found")
+	}
+
+	if has, err := client.Has([]byte("missing")); err != nil || has {
+		t.Fatalf("missing key found: %v %v", has, err)
+	}
+
+	// Iterate over a prefix from a start key
+	it := client.NewIterator([]byte("a"), keys[10][1:])
+
+	var count int
+
+	for it.Next() {
+		if key := keys[10+count]; !bytes.Equal(it.Key(), key) || !bytes.Equal(it.Value(), key[1:]) {
+			t.Fatalf("item %d mismatch: have %x, want %x", count, it.Key(), key)
+		}
+		count++
+	}
+
+	if err := it.Error(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+
+	it.Release()
+
+	if count != len(keys)-10 {
+		t.Fatalf("item count mismatch: have %d, want %d", count, len(keys)-10)
+	}
+
+	// Release an iterator midway
+	it = client.NewIterator(nil, nil)
+	if !it.Next() {
+		t.Fatalf("iteration failed: %v", it.Error())
+	}
+
+	it.Release()
+
+	if it.Next() {
+		t.Fatalf("released iterator moved on")
+	}
+}
+
+func TestClientAncients(t *testing.T) {
+	db, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	var blocks []*rawdb.AncientBlockRLP
+
+	for i := 0; i < 8; i++ {
+		blocks = append(blocks, &rawdb.AncientBlockRLP{
+			Hash:     common.Hash{byte(i)},
+			Header:   []byte{0xc1, byte(i)},
+			Body:     []byte{0xc0},
+			Receipts: []byte{0xc0},
+			Td:       []byte{byte(i + 1)},
+		})
+	}
+
+	if _, err := rawdb.WriteAncientBlocksRLP(db, 0, blocks); err != nil {
+		t.Fatalf("failed to write ancients: %v", err)
+	}
+
+	if _, err := db.TruncateTail(2); err != nil {
+		t.Fatalf("failed to truncate ancients: %v", err)
+	}
+
+	client := serve(t, db)
+
+	if frozen, err := client.Ancients(); err != nil || frozen != 8 {
+		t.Fatalf("ancients mismatch: have %d, want 8: %v", frozen, err)
+	}
+
+	if tail, err := client.Tail(); err != nil || tail != 2 {
+		t.Fatalf("tail mismatch: have %d, want 2: %v", tail, err)
+	}
+
+	if size, err := client.AncientSize("headers"); err != nil || size == 0 {
+		t.Fatalf("ancient size mismatch: have %d: %v", size, err)
+	}
+
+	if header, err := client.Ancient("headers", 5); err != nil || !bytes.Equal(header, blocks[5].Header) {
+		t.Fatalf("ancient mismatch: have %x, want %x: %v", header, blocks[5].Header, err)
+	}
+
+	if has, err := client.HasAncient("headers", 1); err != nil || has {
+		t.Fatalf("truncated ancient found: %v", err)
+	}
+
+	if has, err := client.HasAncient("headers", 8); err != nil || has {
+		t.Fatalf("unfrozen ancient found: %v", err)
+	}
+
+	if has, err := client.HasAncient("headers", 5); err != nil || !has {
+		t.Fatalf("ancient not found: %v", err)
+	}
+
+	tds, err := client.AncientRange("diffs", 3, 10, 0)
+	if err != nil {
+		t.Fatalf("failed to read ancient range: %v", err)
+	}
+
+	if len(tds) != 5 {
+		t.Fatalf("ancient range length mismatch: have %d, want 5", len(tds))
+	}
+
+	for i, td := range tds {
+		if !bytes.Equal(td, blocks[3+i].Td) {
+			t.Fatalf("ancient %d mismatch: have %x, want %x", 3+i, td, blocks[3+i].Td)
+		}
+	}
+
+	if _, err := client.Ancient("unknown", 5); err == nil {
+		t.Fatalf("unknown table read")
+	}
+
+	// Failing to reach the database isn't reported as a missing item
+	client.Close()
+
+	if _, err := client.HasAncient("headers", 5); err == nil {
+		t.Fatalf("closed client probed the ancients")
+	}
+}
+
+// writeCert writes a self-signed certificate for the loopback address, valid for
+// both servers and clients, and its key to the given directory.
+func writeCert(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "bor"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	return certFile, keyFile
+}
+
+// Tests that a server requiring client certificates only serves the clients
+// authenticating with one issued by its authority.
+func TestClientTLS(t *testing.T) {
+	certFile, keyFile := writeCert(t, t.TempDir())
+
+	if _, err := ServerTLSConfig("", "", certFile); err == nil {
+		t.Fatalf("server configured without certificate")
+	}
+
+	if _, err := ClientTLSConfig(certFile, "", certFile); err == nil {
+		t.Fatalf("client configured without certificate key")
+	}
+
+	config, err := ServerTLSConfig(certFile, keyFile, certFile)
+	if err != nil {
+		t.Fatalf("failed to configure server: %v", err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	srv := grpc.NewServer(grpc.Creds(credentials.NewTLS(config)))
+	NewServer(rawdb.NewMemoryDatabase()).Register(srv)
+
+	go srv.Serve(lis) //nolint:errcheck
+	t.Cleanup(srv.Stop)
+
+	dial := func(config *tls.Config) *Client {
+		client, err := Dial(lis.Addr().String(), config)
+		if err != nil {
+			t.Fatalf("failed to dial: %v", err)
+		}
+
+		t.Cleanup(func() { client.Close() })
+
+		return client
+	}
+
+	// Refused in plain text and without client certificate
+	if _, err := dial(nil).Has([]byte("a")); err == nil {
+		t.Fatalf("plain text client served")
+	}
+
+	anonymous, err := ClientTLSConfig("", "", certFile)
+	if err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+
+	if _, err := dial(anonymous).Has([]byte("a")); err == nil {
+		t.Fatalf("client without certificate served")
+	}
+
+	authenticated, err := ClientTLSConfig(certFile, keyFile, certFile)
+	if err != nil {
+		t.Fatalf("failed to configure client: %v", err)
+	}
+
+	if _, err := dial(authenticated).Has([]byte("a")); err != nil {
+		t.Fatalf("authenticated client refused: %v", err)
+	}
+}
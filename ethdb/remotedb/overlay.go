@@ -0,0 +1,325 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// errReadOnlyAncients is returned when modifying the ancients of an overlay.
+var errReadOnlyAncients = errors.New("ancients are read-only")
+
+// Overlay is a writable view of a read-only database. The key-value writes are
+// kept in memory, shadowing the items of the underlying database, which is never
+// modified. It lets a node run over a database served by another one, keeping
+// its own bookkeeping (chain config, shutdown markers, indexes) locally.
+//
+// The ancients are served as is by the underlying database, they can't be
+// modified.
+type Overlay struct {
+	ethdb.Database // Read-only database underneath
+
+	lock    sync.RWMutex
+	items   *memorydb.Database  // Items written locally
+	deleted map[string]struct{} // Items deleted locally, hidden from the underlying database
+}
+
+// NewOverlay creates a writable view of the given read-only database.
+func NewOverlay(db ethdb.Database) *Overlay {
+	return &Overlay{
+		Database: db,
+		items:    memorydb.New(),
+		deleted:  make(map[string]struct{}),
+	}
+}
+
+// Has retrieves if a key is present in the overlay or, unless deleted locally,
+// in the underlying database.
+func (db *Overlay) Has(key []byte) (bool, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if has, _ := db.items.Has(key); has {
+		return true, nil
+	}
+
+	if _, ok := db.deleted[string(key)]; ok {
+		return false, nil
+	}
+
+	return db.Database.Has(key)
+}
+
+// Get retrieves the given key from the overlay or, unless deleted locally, from
+// the underlying database.
+func (db *Overlay) Get(key []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if value, err := db.items.Get(key); err == nil {
+		return value, nil
+	}
+
+	if _, ok := db.deleted[string(key)]; ok {
+		return nil, errNotFound
+	}
+
+	return db.Database.Get(key)
+}
+
+// Put inserts the given value into the overlay.
+func (db *Overlay) Put(key []byte, value []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	delete(db.deleted, string(key))
+
+	return db.items.Put(key, value)
+}
+
+// Delete removes the key from the overlay, hiding it in the underlying database.
+func (db *Overlay) Delete(key []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	db.deleted[string(key)] = struct{}{}
+
+	return db.items.Delete(key)
+}
+
+// NewBatch creates a write-only batch buffering the changes until a final write
+// to the overlay.
+func (db *Overlay) NewBatch() ethdb.Batch {
+	return &overlayBatch{db: db}
+}
+
+// NewBatchWithSize creates a write-only batch with pre-allocated buffer.
+func (db *Overlay) NewBatchWithSize(size int) ethdb.Batch {
+	return &overlayBatch{db: db, writes: make([]overlayWrite, 0, size)}
+}
+
+// NewIterator creates an iterator over a subset of the items, the ones written
+// locally taking precedence over the ones of the underlying database.
+func (db *Overlay) NewIterator(prefix []byte, start []byte) ethdb.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	deleted := make(map[string]struct{}, len(db.deleted))
+	for key := range db.deleted {
+		deleted[key] = struct{}{}
+	}
+
+	it := &overlayIterator{
+		local:   db.items.NewIterator(prefix, start),
+		remote:  db.Database.NewIterator(prefix, start),
+		deleted: deleted,
+	}
+	it.localOk = it.local.Next()
+	it.remoteOk = it.nextRemote()
+
+	return it
+}
+
+// NewSnapshot is not supported by the overlay.
+func (db *Overlay) NewSnapshot() (ethdb.Snapshot, error) {
+	return nil, errors.New("snapshots not supported")
+}
+
+// Compact is a noop, the overlay lives in memory.
+func (db *Overlay) Compact(start []byte, limit []byte) error {
+	return nil
+}
+
+// ModifyAncients is not supported, the ancients are read-only.
+func (db *Overlay) ModifyAncients(func(ethdb.AncientWriteOp) error) (int64, error) {
+	return 0, errReadOnlyAncients
+}
+
+// TruncateHead is not supported, the ancients are read-only.
+func (db *Overlay) TruncateHead(n uint64) (uint64, error) {
+	return 0, errReadOnlyAncients
+}
+
+// TruncateTail is not supported, the ancients are read-only.
+func (db *Overlay) TruncateTail(n uint64) (uint64, error) {
+	return 0, errReadOnlyAncients
+}
+
+// MigrateTable is not supported, the ancients are read-only.
+func (db *Overlay) MigrateTable(string, func([]byte) ([]byte, error)) error {
+	return errReadOnlyAncients
+}
+
+// AncientDatadir is not supported, the ancients are served remotely.
+func (db *Overlay) AncientDatadir() (string, error) {
+	return "", errReadOnlyAncients
+}
+
+// Close drops the items written locally and closes the underlying database.
+func (db *Overlay) Close() error {
+	db.items.Close()
+	return db.Database.Close()
+}
+
+// overlayWrite is a write buffered by a batch.
+type overlayWrite struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// overlayBatch is a write-only batch committing its writes to an overlay.
+type overlayBatch struct {
+	db     *Overlay
+	writes []overlayWrite
+	size   int
+}
+
+// Put inserts the given value into the batch for later committing.
+func (b *overlayBatch) Put(key, value []byte) error {
+	b.writes = append(b.writes, overlayWrite{common.CopyBytes(key), common.CopyBytes(value), false})
+	b.size += len(key) + len(value)
+
+	return nil
+}
+
+// Delete inserts the key removal into the batch for later committing.
+func (b *overlayBatch) Delete(key []byte) error {
+	b.writes = append(b.writes, overlayWrite{common.CopyBytes(key), nil, true})
+	b.size += len(key)
+
+	return nil
+}
+
+// ValueSize retrieves the amount of data queued up for writing.
+func (b *overlayBatch) ValueSize() int {
+	return b.size
+}
+
+// Write flushes the batched writes into the overlay.
+func (b *overlayBatch) Write() error {
+	return b.Replay(b.db)
+}
+
+// Reset resets the batch for reuse.
+func (b *overlayBatch) Reset() {
+	b.writes = b.writes[:0]
+	b.size = 0
+}
+
+// Replay replays the batch contents.
+func (b *overlayBatch) Replay(w ethdb.KeyValueWriter) error {
+	for _, write := range b.writes {
+		if write.delete {
+			if err := w.Delete(write.key); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := w.Put(write.key, write.value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// overlayIterator merges the items written locally with the ones of the
+// underlying database, in key order.
+type overlayIterator struct {
+	local    ethdb.Iterator
+	remote   ethdb.Iterator
+	localOk  bool
+	remoteOk bool
+	deleted  map[string]struct{}
+
+	key   []byte
+	value []byte
+}
+
+// nextRemote moves the underlying database iterator to the next item not
+// deleted locally.
+func (it *overlayIterator) nextRemote() bool {
+	for it.remote.Next() {
+		if _, ok := it.deleted[string(it.remote.Key())]; !ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Next moves the iterator to the next key/value pair, returning whether the
+// iterator is exhausted.
+func (it *overlayIterator) Next() bool {
+	switch {
+	case it.localOk && it.remoteOk:
+		switch cmp := bytes.Compare(it.local.Key(), it.remote.Key()); {
+		case cmp < 0:
+			it.key, it.value = it.local.Key(), it.local.Value()
+			it.localOk = it.local.Next()
+		case cmp > 0:
+			it.key, it.value = common.CopyBytes(it.remote.Key()), common.CopyBytes(it.remote.Value())
+			it.remoteOk = it.nextRemote()
+		default:
+			// The item written locally shadows the one underneath
+			it.key, it.value = it.local.Key(), it.local.Value()
+			it.localOk = it.local.Next()
+			it.remoteOk = it.nextRemote()
+		}
+	case it.localOk:
+		it.key, it.value = it.local.Key(), it.local.Value()
+		it.localOk = it.local.Next()
+	case it.remoteOk:
+		it.key, it.value = common.CopyBytes(it.remote.Key()), common.CopyBytes(it.remote.Value())
+		it.remoteOk = it.nextRemote()
+	default:
+		it.key, it.value = nil, nil
+		return false
+	}
+
+	return true
+}
+
+// Error returns any accumulated error of the underlying database iterator.
+func (it *overlayIterator) Error() error {
+	return it.remote.Error()
+}
+
+// Key returns the key of the current key/value pair, or nil if done.
+func (it *overlayIterator) Key() []byte {
+	return it.key
+}
+
+// Value returns the value of the current key/value pair, or nil if done.
+func (it *overlayIterator) Value() []byte {
+	return it.value
+}
+
+// Release releases associated resources.
+func (it *overlayIterator) Release() {
+	it.local.Release()
+	it.remote.Release()
+}
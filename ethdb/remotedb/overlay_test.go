@@ -0,0 +1,101 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// Tests that the writes to an overlay shadow the items of the database served
+// underneath, which is left untouched.
+func TestOverlay(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+
+	for _, key := range []string{"a1", "a2", "a3", "b1"} {
+		if err := db.Put([]byte(key), []byte("remote-"+key)); err != nil {
+			t.Fatalf("failed to put: %v", err)
+		}
+	}
+
+	overlay := NewOverlay(serve(t, db))
+
+	// Overwrite, delete and add items, through a batch too
+	if err := overlay.Put([]byte("a2"), []byte("local-a2")); err != nil {
+		t.Fatalf("failed to put: %v", err)
+	}
+
+	batch := overlay.NewBatch()
+	batch.Delete([]byte("a3"))
+	batch.Put([]byte("a0"), []byte("local-a0"))
+	batch.Put([]byte("a4"), []byte("local-a4"))
+
+	if err := batch.Write(); err != nil {
+		t.Fatalf("failed to write batch: %v", err)
+	}
+
+	want := map[string]string{"a0": "local-a0", "a1": "remote-a1", "a2": "local-a2", "a4": "local-a4", "b1": "remote-b1"}
+	for key, value := range want {
+		if have, err := overlay.Get([]byte(key)); err != nil || string(have) != value {
+			t.Fatalf("item %s mismatch: have %q (%v), want %q", key, have, err, value)
+		}
+	}
+
+	if has, err := overlay.Has([]byte("a3")); err != nil || has {
+		t.Fatalf("deleted item found: %v %v", has, err)
+	}
+
+	// Iterate the merged items in order
+	var (
+		keys []string
+		it   = overlay.NewIterator([]byte("a"), nil)
+	)
+
+	for it.Next() {
+		if string(it.Value()) != want[string(it.Key())] {
+			t.Fatalf("iterated item %s mismatch: have %q, want %q", it.Key(), it.Value(), want[string(it.Key())])
+		}
+
+		keys = append(keys, string(it.Key()))
+	}
+
+	it.Release()
+
+	if err := it.Error(); err != nil {
+		t.Fatalf("iteration failed: %v", err)
+	}
+
+	if len(keys) != 4 || keys[0] != "a0" || keys[1] != "a1" || keys[2] != "a2" || keys[3] != "a4" {
+		t.Fatalf("iterated keys mismatch: %v", keys)
+	}
+
+	// The database underneath is left untouched
+	for _, key := range []string{"a1", "a2", "a3", "b1"} {
+		if have, _ := db.Get([]byte(key)); string(have) != "remote-"+key {
+			t.Fatalf("served item %s modified: %q", key, have)
+		}
+	}
+
+	if has, _ := db.Has([]byte("a0")); has {
+		t.Fatalf("local item written to the served database")
+	}
+
+	if _, err := overlay.TruncateTail(1); err == nil {
+		t.Fatalf("ancients modified through the overlay")
+	}
+}
@@ -0,0 +1,384 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: ethdb/remotedb/proto/remotedb.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// DatabaseClient is the client API for Database service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DatabaseClient interface {
+	Has(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*HasResponse, error)
+	Get(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Iterate(ctx context.Context, in *IterateRequest, opts ...grpc.CallOption) (Database_IterateClient, error)
+	Ancients(ctx context.Context, in *AncientsRequest, opts ...grpc.CallOption) (*AncientsResponse, error)
+	AncientRange(ctx context.Context, in *AncientRangeRequest, opts ...grpc.CallOption) (*AncientRangeResponse, error)
+	AncientSize(ctx context.Context, in *AncientSizeRequest, opts ...grpc.CallOption) (*AncientSizeResponse, error)
+	Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error)
+}
+
+type databaseClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDatabaseClient(cc grpc.ClientConnInterface) DatabaseClient {
+	return &databaseClient{cc}
+}
+
+func (c *databaseClient) Has(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*HasResponse, error) {
+	out := new(HasResponse)
+
+	err := c.cc.Invoke(ctx, "/remotedb.Database/Has", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *databaseClient) Get(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+
+	err := c.cc.Invoke(ctx, "/remotedb.Database/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *databaseClient) Iterate(ctx context.Context, in *IterateRequest, opts ...grpc.CallOption) (Database_IterateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[0], "/remotedb.Database/Iterate", opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	x := &databaseIterateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+type Database_IterateClient interface {
+	Recv() (*IterateResponse, error)
+	grpc.ClientStream
+}
+
+type databaseIterateClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseIterateClient) Recv() (*IterateResponse, error) {
+	m := new(IterateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (c *databaseClient) Ancients(ctx context.Context, in *AncientsRequest, opts ...grpc.CallOption) (*AncientsResponse, error) {
+	out := new(AncientsResponse)
+
+	err := c.cc.Invoke(ctx, "/remotedb.Database/Ancients", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *databaseClient) AncientRange(ctx context.Context, in *AncientRangeRequest, opts ...grpc.CallOption) (*AncientRangeResponse, error) {
+	out := new(AncientRangeResponse)
+
+	err := c.cc.Invoke(ctx, "/remotedb.Database/AncientRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *databaseClient) AncientSize(ctx context.Context, in *AncientSizeRequest, opts ...grpc.CallOption) (*AncientSizeResponse, error) {
+	out := new(AncientSizeResponse)
+
+	err := c.cc.Invoke(ctx, "/remotedb.Database/AncientSize", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *databaseClient) Stat(ctx context.Context, in *StatRequest, opts ...grpc.CallOption) (*StatResponse, error) {
+	out := new(StatResponse)
+
+	err := c.cc.Invoke(ctx, "/remotedb.Database/Stat", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// DatabaseServer is the server API for Database service.
+// All implementations must embed UnimplementedDatabaseServer
+// for forward compatibility
+type DatabaseServer interface {
+	Has(context.Context, *KeysRequest) (*HasResponse, error)
+	Get(context.Context, *KeysRequest) (*GetResponse, error)
+	Iterate(*IterateRequest, Database_IterateServer) error
+	Ancients(context.Context, *AncientsRequest) (*AncientsResponse, error)
+	AncientRange(context.Context, *AncientRangeRequest) (*AncientRangeResponse, error)
+	AncientSize(context.Context, *AncientSizeRequest) (*AncientSizeResponse, error)
+	Stat(context.Context, *StatRequest) (*StatResponse, error)
+	mustEmbedUnimplementedDatabaseServer()
+}
+
+// UnimplementedDatabaseServer must be embedded to have forward compatible implementations.
+type UnimplementedDatabaseServer struct {
+}
+
+func (UnimplementedDatabaseServer) Has(context.Context, *KeysRequest) (*HasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Has not implemented")
+}
+func (UnimplementedDatabaseServer) Get(context.Context, *KeysRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedDatabaseServer) Iterate(*IterateRequest, Database_IterateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Iterate not implemented")
+}
+func (UnimplementedDatabaseServer) Ancients(context.Context, *AncientsRequest) (*AncientsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ancients not implemented")
+}
+func (UnimplementedDatabaseServer) AncientRange(context.Context, *AncientRangeRequest) (*AncientRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AncientRange not implemented")
+}
+func (UnimplementedDatabaseServer) AncientSize(context.Context, *AncientSizeRequest) (*AncientSizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AncientSize not implemented")
+}
+func (UnimplementedDatabaseServer) Stat(context.Context, *StatRequest) (*StatResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stat not implemented")
+}
+func (UnimplementedDatabaseServer) mustEmbedUnimplementedDatabaseServer() {}
+
+// UnsafeDatabaseServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DatabaseServer will
+// result in compilation errors.
+type UnsafeDatabaseServer interface {
+	mustEmbedUnimplementedDatabaseServer()
+}
+
+func RegisterDatabaseServer(s grpc.ServiceRegistrar, srv DatabaseServer) {
+	s.RegisterService(&Database_ServiceDesc, srv)
+}
+
+func _Database_Has_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(DatabaseServer).Has(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotedb.Database/Has",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).Has(ctx, req.(*KeysRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(DatabaseServer).Get(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotedb.Database/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).Get(ctx, req.(*KeysRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_Iterate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IterateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	return srv.(DatabaseServer).Iterate(m, &databaseIterateServer{stream})
+}
+
+type Database_IterateServer interface {
+	Send(*IterateResponse) error
+	grpc.ServerStream
+}
+
+type databaseIterateServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseIterateServer) Send(m *IterateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Database_Ancients_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AncientsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(DatabaseServer).Ancients(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotedb.Database/Ancients",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).Ancients(ctx, req.(*AncientsRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_AncientRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AncientRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(DatabaseServer).AncientRange(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotedb.Database/AncientRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).AncientRange(ctx, req.(*AncientRangeRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_AncientSize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AncientSizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(DatabaseServer).AncientSize(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotedb.Database/AncientSize",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).AncientSize(ctx, req.(*AncientSizeRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_Stat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(DatabaseServer).Stat(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remotedb.Database/Stat",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).Stat(ctx, req.(*StatRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+// Database_ServiceDesc is the grpc.ServiceDesc for Database service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Database_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "remotedb.Database",
+	HandlerType: (*DatabaseServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Has",
+			Handler:    _Database_Has_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Database_Get_Handler,
+		},
+		{
+			MethodName: "Ancients",
+			Handler:    _Database_Ancients_Handler,
+		},
+		{
+			MethodName: "AncientRange",
+			Handler:    _Database_AncientRange_Handler,
+		},
+		{
+			MethodName: "AncientSize",
+			Handler:    _Database_AncientSize_Handler,
+		},
+		{
+			MethodName: "Stat",
+			Handler:    _Database_Stat_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Iterate",
+			Handler:       _Database_Iterate_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "ethdb/remotedb/proto/remotedb.proto",
+}
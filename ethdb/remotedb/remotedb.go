@@ -19,6 +19,10 @@
 // read-only database.
 // There really are no guarantees in this database, since the local geth does not
 // exclusive access, but it can be used for basic diagnostics of a remote node.
+//
+// The package also implements a read-only database server over gRPC, along with
+// its Client, batching the key lookups and streaming the iterations, for nodes
+// serving queries off the database of another one.
 package remotedb
 
 import (
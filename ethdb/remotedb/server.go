@@ -0,0 +1,246 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package remotedb
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/remotedb/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxBatchKeys is the maximum number of keys looked up in a single request.
+	maxBatchKeys = 256
+
+	// maxIterateItems is the maximum number of items streamed in a single
+	// iteration response.
+	maxIterateItems = 1024
+
+	// maxIterateBytes is the soft limit of the size of the items streamed in
+	// a single iteration response.
+	maxIterateBytes = 1024 * 1024
+
+	// maxAncientBytes is the soft limit of the size of the items returned for
+	// an ancient range request.
+	maxAncientBytes = 8 * 1024 * 1024
+
+	// maxMessageSize is the maximum size of the messages received by clients.
+	maxMessageSize = 128 * 1024 * 1024
+)
+
+// Server serves a read-only view of a database, its key-value store and its
+// ancients, over gRPC. Clients access it through Dial.
+type Server struct {
+	proto.UnimplementedDatabaseServer
+
+	db ethdb.Database
+}
+
+// NewServer creates a server for the given database.
+func NewServer(db ethdb.Database) *Server {
+	return &Server{db: db}
+}
+
+// ServerTLSConfig returns the TLS configuration of a server presenting the given
+// certificate. If a certificate authority is given, the clients must present a
+// certificate issued by it.
+func ServerTLSConfig(cert, key, ca string) (*tls.Config, error) {
+	if cert == "" || key == "" {
+		return nil, errKeyPair
+	}
+
+	pair, err := tls.LoadX509KeyPair(cert, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the server certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{pair},
+	}
+
+	if ca != "" {
+		pool, err := loadCertPool(ca)
+		if err != nil {
+			return nil, err
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+// Register registers the database service on the given gRPC server.
+func (s *Server) Register(srv *grpc.Server) {
+	proto.RegisterDatabaseServer(srv, s)
+}
+
+func (s *Server) Has(ctx context.Context, req *proto.KeysRequest) (*proto.HasResponse, error) {
+	if len(req.Keys) > maxBatchKeys {
+		return nil, status.Errorf(codes.InvalidArgument, "too many keys: %d > %d", len(req.Keys), maxBatchKeys)
+	}
+
+	resp := &proto.HasResponse{Found: make([]bool, len(req.Keys))}
+
+	for i, key := range req.Keys {
+		has, err := s.db.Has(key)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Found[i] = has
+	}
+
+	return resp, nil
+}
+
+func (s *Server) Get(ctx context.Context, req *proto.KeysRequest) (*proto.GetResponse, error) {
+	if len(req.Keys) > maxBatchKeys {
+		return nil, status.Errorf(codes.InvalidArgument, "too many keys: %d > %d", len(req.Keys), maxBatchKeys)
+	}
+
+	resp := &proto.GetResponse{Values: make([]*proto.Value, len(req.Keys))}
+
+	for i, key := range req.Keys {
+		data, err := s.db.Get(key)
+		if err != nil {
+			// The backends report missing keys with their own errors, tell
+			// them apart from the failures
+			if has, herr := s.db.Has(key); herr != nil || has {
+				return nil, err
+			}
+
+			resp.Values[i] = &proto.Value{}
+
+			continue
+		}
+
+		resp.Values[i] = &proto.Value{Found: true, Data: data}
+	}
+
+	return resp, nil
+}
+
+func (s *Server) Iterate(req *proto.IterateRequest, stream proto.Database_IterateServer) error {
+	limit := int(req.Batch)
+	if limit == 0 || limit > maxIterateItems {
+		limit = maxIterateItems
+	}
+
+	it := s.db.NewIterator(req.Prefix, req.Start)
+	defer it.Release()
+
+	var (
+		items []*proto.KeyValue
+		size  int
+	)
+
+	for it.Next() {
+		// The iterator reuses its buffers, copy the items before moving on
+		items = append(items, &proto.KeyValue{Key: common.CopyBytes(it.Key()), Value: common.CopyBytes(it.Value())})
+		size += len(it.Key()) + len(it.Value())
+
+		if len(items) >= limit || size >= maxIterateBytes {
+			if err := stream.Send(&proto.IterateResponse{Items: items}); err != nil {
+				return err
+			}
+
+			items, size = nil, 0
+		}
+	}
+
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if len(items) > 0 {
+		return stream.Send(&proto.IterateResponse{Items: items})
+	}
+
+	return nil
+}
+
+func (s *Server) Ancients(ctx context.Context, req *proto.AncientsRequest) (*proto.AncientsResponse, error) {
+	frozen, err := s.db.Ancients()
+	if err != nil {
+		return nil, err
+	}
+
+	tail, err := s.db.Tail()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AncientsResponse{Frozen: frozen, Tail: tail}, nil
+}
+
+func (s *Server) AncientRange(ctx context.Context, req *proto.AncientRangeRequest) (*proto.AncientRangeResponse, error) {
+	// Single items are read directly, the range reads preallocate their limit
+	if req.Count == 1 {
+		data, err := s.db.Ancient(req.Kind, req.Start)
+		if err != nil {
+			// Tell the missing items apart for the clients probing them
+			if has, hasErr := s.db.HasAncient(req.Kind, req.Start); hasErr == nil && !has {
+				return nil, status.Error(codes.NotFound, err.Error())
+			}
+
+			return nil, err
+		}
+
+		return &proto.AncientRangeResponse{Values: [][]byte{data}}, nil
+	}
+
+	maxBytes := req.MaxBytes
+	if maxBytes == 0 || maxBytes > maxAncientBytes {
+		maxBytes = maxAncientBytes
+	}
+
+	values, err := s.db.AncientRange(req.Kind, req.Start, req.Count, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AncientRangeResponse{Values: values}, nil
+}
+
+func (s *Server) AncientSize(ctx context.Context, req *proto.AncientSizeRequest) (*proto.AncientSizeResponse, error) {
+	size, err := s.db.AncientSize(req.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.AncientSizeResponse{Size: size}, nil
+}
+
+func (s *Server) Stat(ctx context.Context, req *proto.StatRequest) (*proto.StatResponse, error) {
+	value, err := s.db.Stat(req.Property)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.StatResponse{Value: value}, nil
+}
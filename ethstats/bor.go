@@ -0,0 +1,179 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethstats
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// heimdallTimeout is the time allowed to Heimdall to answer the connectivity
+// check of a stats report.
+const heimdallTimeout = 5 * time.Second
+
+// borBackend encompasses the functionality needed to report Bor specific data,
+// available to both full and light nodes.
+type borBackend interface {
+	ChainConfig() *params.ChainConfig
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	GetWhitelistedCheckpoint() (bool, uint64, common.Hash)
+	GetWhitelistedMilestone() (bool, uint64, common.Hash)
+}
+
+// blockSTMBackend encompasses the functionality needed to report the usage of
+// the parallel state processor, available to full nodes only.
+type blockSTMBackend interface {
+	BlockExecutionStats() (bool, uint64, uint64)
+}
+
+// borBlockStats is the Bor specific information to report about a block.
+type borBlockStats struct {
+	Signer     common.Address `json:"signer"`
+	InTurn     bool           `json:"inTurn"`
+	Succession int            `json:"succession"`
+}
+
+// borNodeStats is the Bor specific information to report about the local node.
+type borNodeStats struct {
+	Span       uint64         `json:"span"`
+	Checkpoint uint64         `json:"checkpoint"`
+	Milestone  uint64         `json:"milestone"`
+	Heimdall   bool           `json:"heimdall"`
+	BlockSTM   *blockSTMStats `json:"blockSTM,omitempty"`
+}
+
+// blockSTMStats is the information to report about the usage of the parallel
+// state processor.
+type blockSTMStats struct {
+	Enabled        bool   `json:"enabled"`
+	ParallelBlocks uint64 `json:"parallelBlocks"`
+	SerialBlocks   uint64 `json:"serialBlocks"`
+}
+
+// chainReader exposes the headers of the backend to the consensus engine.
+type chainReader struct {
+	backend backend
+}
+
+func (r *chainReader) Config() *params.ChainConfig {
+	return r.backend.(borBackend).ChainConfig()
+}
+
+func (r *chainReader) CurrentHeader() *types.Header {
+	return r.backend.CurrentHeader()
+}
+
+func (r *chainReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header := r.GetHeaderByHash(hash)
+	if header == nil || header.Number.Uint64() != number {
+		return nil
+	}
+
+	return header
+}
+
+func (r *chainReader) GetHeaderByNumber(number uint64) *types.Header {
+	header, _ := r.backend.HeaderByNumber(context.Background(), rpc.BlockNumber(number))
+	return header
+}
+
+func (r *chainReader) GetHeaderByHash(hash common.Hash) *types.Header {
+	header, _ := r.backend.(borBackend).HeaderByHash(context.Background(), hash)
+	return header
+}
+
+func (r *chainReader) GetTd(hash common.Hash, number uint64) *big.Int {
+	return r.backend.GetTd(context.Background(), hash)
+}
+
+// assembleBorBlockStats retrieves the signer of a block and whether it was signed
+// in turn. It returns nil if not running Bor, or if the signer can't be resolved.
+func (s *Service) assembleBorBlockStats(header *types.Header) *borBlockStats {
+	engine, ok := s.engine.(*bor.Bor)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := s.backend.(borBackend); !ok {
+		return nil
+	}
+
+	signer, succession, err := engine.GetSignerSuccession(&chainReader{s.backend}, header)
+	if err != nil {
+		log.Debug("Failed to resolve block signer for ethstats", "number", header.Number, "err", err)
+		return nil
+	}
+
+	return &borBlockStats{
+		Signer:     signer,
+		InTurn:     succession == 0,
+		Succession: succession,
+	}
+}
+
+// assembleBorNodeStats retrieves the span, finality and Heimdall connectivity of
+// the local node, along with its block-STM usage.
+func (s *Service) assembleBorNodeStats() *borNodeStats {
+	stats := new(borNodeStats)
+
+	if backend, ok := s.backend.(borBackend); ok {
+		if exists, number, _ := backend.GetWhitelistedCheckpoint(); exists {
+			stats.Checkpoint = number
+		}
+
+		if exists, number, _ := backend.GetWhitelistedMilestone(); exists {
+			stats.Milestone = number
+		}
+	}
+
+	if engine, ok := s.engine.(*bor.Bor); ok {
+		if spanner := engine.GetSpanner(); spanner != nil {
+			if span, err := spanner.GetCurrentSpan(context.Background(), s.backend.CurrentHeader().Hash()); err == nil {
+				stats.Span = span.ID
+			} else {
+				log.Debug("Failed to retrieve current span for ethstats", "err", err)
+			}
+		}
+
+		if engine.HeimdallClient != nil {
+			ctx, cancel := context.WithTimeout(context.Background(), heimdallTimeout)
+			defer cancel()
+
+			_, err := engine.HeimdallClient.FetchCheckpointCount(ctx)
+			stats.Heimdall = err == nil
+		}
+	}
+
+	if backend, ok := s.backend.(blockSTMBackend); ok {
+		enabled, parallel, serial := backend.BlockExecutionStats()
+		stats.BlockSTM = &blockSTMStats{
+			Enabled:        enabled,
+			ParallelBlocks: parallel,
+			SerialBlocks:   serial,
+		}
+	}
+
+	return stats
+}
@@ -112,6 +112,7 @@ type Service struct {
 	node string // Name of the node to display on the monitoring page
 	pass string // Password to authorize access to the monitoring page
 	host string // Remote address of the monitoring service
+	bor  bool   // Whether to report Bor specific data
 
 	pongCh chan struct{} // Pong notifications are fed into this channel
 	histCh chan []uint64 // History request block numbers are fed into this channel
@@ -201,6 +202,18 @@ func parseEthstatsURL(url string) (parts []string, err error) {
 
 // New returns a monitoring service ready for stats reporting.
 func New(node *node.Node, backend backend, engine consensus.Engine, url string) error {
+	return newService(node, backend, engine, url, false)
+}
+
+// NewWithBorStats returns a monitoring service ready for stats reporting, which
+// also reports Bor specific data: the signers of the blocks and whether they
+// were in turn, the current span, the whitelisted checkpoint and milestone, the
+// Heimdall connectivity and the block-STM usage.
+func NewWithBorStats(node *node.Node, backend backend, engine consensus.Engine, url string) error {
+	return newService(node, backend, engine, url, true)
+}
+
+func newService(node *node.Node, backend backend, engine consensus.Engine, url string, bor bool) error {
 	parts, err := parseEthstatsURL(url)
 	if err != nil {
 		return err
@@ -213,6 +226,7 @@ func New(node *node.Node, backend backend, engine consensus.Engine, url string)
 		node:    parts[0],
 		pass:    parts[1],
 		host:    parts[2],
+		bor:     bor,
 		pongCh:  make(chan struct{}),
 		histCh:  make(chan []uint64, 1),
 	}
@@ -660,6 +674,7 @@ type blockStats struct {
 	TxHash     common.Hash    `json:"transactionsRoot"`
 	Root       common.Hash    `json:"stateRoot"`
 	Uncles     uncleStats     `json:"uncles"`
+	Bor        *borBlockStats `json:"bor,omitempty"`
 }
 
 // txStats is the information to report about individual transactions.
@@ -740,6 +755,11 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 	// Assemble and return the block stats
 	author, _ := s.engine.Author(header)
 
+	var borStats *borBlockStats
+	if s.bor {
+		borStats = s.assembleBorBlockStats(header)
+	}
+
 	return &blockStats{
 		Number:     header.Number,
 		Hash:       header.Hash(),
@@ -754,6 +774,7 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 		TxHash:     header.TxHash,
 		Root:       header.Root,
 		Uncles:     uncles,
+		Bor:        borStats,
 	}
 }
 
@@ -901,6 +922,8 @@ type nodeStats struct {
 	Peers    int  `json:"peers"`
 	GasPrice int  `json:"gasPrice"`
 	Uptime   int  `json:"uptime"`
+
+	Bor *borNodeStats `json:"bor,omitempty"`
 }
 
 // reportStats retrieves various stats about the node at the networking and
@@ -932,6 +955,12 @@ func (s *Service) reportStats(conn *connWrapper) error {
 		sync := s.backend.SyncProgress()
 		syncing = s.backend.CurrentHeader().Number.Uint64() >= sync.HighestBlock
 	}
+
+	var borStats *borNodeStats
+	if s.bor {
+		borStats = s.assembleBorNodeStats()
+	}
+
 	// Assemble the node stats and send it to the server
 	log.Trace("Sending node details to ethstats")
 
@@ -945,6 +974,7 @@ func (s *Service) reportStats(conn *connWrapper) error {
 			GasPrice: gasprice,
 			Syncing:  syncing,
 			Uptime:   100,
+			Bor:      borStats,
 		},
 	}
 	report := map[string][]interface{}{
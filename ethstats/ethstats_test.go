@@ -17,8 +17,25 @@
 package ethstats
 
 import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"reflect"
 	"strconv"
 	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	ethproto "github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 func TestParseEthstatsURL(t *testing.T) {
@@ -83,3 +100,157 @@ func TestParseEthstatsURL(t *testing.T) {
 		}
 	}
 }
+
+// testBackend is a light node backend serving a single header, along with the
+// whitelisted finality and block-STM usage reported to ethstats by Bor nodes.
+type testBackend struct {
+	header    *types.Header
+	headFeed  event.Feed
+	txFeed    event.Feed
+	head2Feed event.Feed
+}
+
+func (b *testBackend) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return b.headFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeNewTxsEvent(ch chan<- core.NewTxsEvent) event.Subscription {
+	return b.txFeed.Subscribe(ch)
+}
+
+func (b *testBackend) SubscribeChain2HeadEvent(ch chan<- core.Chain2HeadEvent) event.Subscription {
+	return b.head2Feed.Subscribe(ch)
+}
+
+func (b *testBackend) CurrentHeader() *types.Header { return b.header }
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	return b.header, nil
+}
+
+func (b *testBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return b.header, nil
+}
+
+func (b *testBackend) GetTd(ctx context.Context, hash common.Hash) *big.Int { return big.NewInt(1) }
+func (b *testBackend) Stats() (int, int)                                    { return 0, 0 }
+func (b *testBackend) SyncProgress() ethereum.SyncProgress                  { return ethereum.SyncProgress{} }
+func (b *testBackend) ChainConfig() *params.ChainConfig                     { return params.TestChainConfig }
+
+func (b *testBackend) GetWhitelistedCheckpoint() (bool, uint64, common.Hash) {
+	return true, 256, common.Hash{}
+}
+
+func (b *testBackend) GetWhitelistedMilestone() (bool, uint64, common.Hash) {
+	return true, 300, common.Hash{}
+}
+
+func (b *testBackend) BlockExecutionStats() (bool, uint64, uint64) { return true, 10, 2 }
+
+// Tests that a node logs into an ethstats server and reports its stats, along
+// with the Bor specific data if enabled.
+func TestMockServerReports(t *testing.T) {
+	server, err := NewMockServer("secret")
+	if err != nil {
+		t.Fatalf("failed to start mock server: %v", err)
+	}
+	defer server.Close()
+
+	stack, err := node.New(&node.Config{
+		P2P: p2p.Config{NoDiscovery: true, MaxPeers: 0},
+	})
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	stack.RegisterProtocols([]p2p.Protocol{{
+		Name:     "eth",
+		Version:  68,
+		NodeInfo: func() interface{} { return &ethproto.NodeInfo{Network: 137} },
+	}})
+
+	backend := &testBackend{header: &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1)}}
+	if err := NewWithBorStats(stack, backend, ethash.NewFaker(), "node:secret@"+server.Addr()); err != nil {
+		t.Fatalf("failed to create ethstats service: %v", err)
+	}
+
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+
+	var (
+		hello   *authMsg
+		stats   *nodeStats
+		block   *blockStats
+		timeout = time.After(10 * time.Second)
+	)
+
+	for hello == nil || stats == nil || block == nil {
+		select {
+		case report := <-server.Reports():
+			if report.Node != "node" {
+				t.Fatalf("report node mismatch: have %s, want node", report.Node)
+			}
+
+			var err error
+
+			switch report.Kind {
+			case "hello":
+				hello = new(authMsg)
+				err = json.Unmarshal(report.Data, hello)
+			case "stats":
+				var msg struct {
+					Stats *nodeStats `json:"stats"`
+				}
+				err = json.Unmarshal(report.Data, &msg)
+				stats = msg.Stats
+			case "block":
+				var msg struct {
+					Block *blockStats `json:"block"`
+				}
+				err = json.Unmarshal(report.Data, &msg)
+				block = msg.Block
+			}
+
+			if err != nil {
+				t.Fatalf("failed to decode %s report: %v", report.Kind, err)
+			}
+		case <-timeout:
+			t.Fatalf("reports missing: hello %v, stats %v, block %v", hello != nil, stats != nil, block != nil)
+		}
+	}
+
+	if hello.Info.Network != "137" {
+		t.Errorf("network mismatch: have %s, want 137", hello.Info.Network)
+	}
+
+	if block.Number.Uint64() != 1 {
+		t.Errorf("block number mismatch: have %d, want 1", block.Number)
+	}
+
+	want := &borNodeStats{
+		Checkpoint: 256,
+		Milestone:  300,
+		BlockSTM:   &blockSTMStats{Enabled: true, ParallelBlocks: 10, SerialBlocks: 2},
+	}
+	if !reflect.DeepEqual(stats.Bor, want) {
+		t.Errorf("bor stats mismatch: have %+v, want %+v", stats.Bor, want)
+	}
+
+	// History requests get answered
+	if err := server.RequestHistory("node", []uint64{1}); err != nil {
+		t.Fatalf("failed to request history: %v", err)
+	}
+
+	for {
+		select {
+		case report := <-server.Reports():
+			if report.Kind == "history" {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("history not reported")
+		}
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethstats
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// errUnknownNode is returned when requesting the history of a node not connected
+// to the mock server.
+var errUnknownNode = errors.New("unknown node")
+
+// MockReport is a message reported by a node to the mock server.
+type MockReport struct {
+	Node string          // Name of the reporting node
+	Kind string          // Type of the report (hello, block, pending, stats, history, latency, headEvent)
+	Data json.RawMessage // Payload of the report, as sent by the node
+}
+
+// MockServer is a local ethstats server speaking just enough of the protocol to
+// accept nodes and collect their reports, meant for testing.
+type MockServer struct {
+	secret   string
+	listener net.Listener
+	server   *http.Server
+	upgrader websocket.Upgrader
+
+	reports chan MockReport
+	conns   map[string]*connWrapper
+	lock    sync.Mutex
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewMockServer starts a mock ethstats server on a random local port, accepting
+// the nodes authenticating with the given secret.
+func NewMockServer(secret string) (*MockServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &MockServer{
+		secret:   secret,
+		listener: listener,
+		reports:  make(chan MockReport, 256),
+		conns:    make(map[string]*connWrapper),
+		quit:     make(chan struct{}),
+	}
+	// Nodes announce themselves as originating from localhost, accept anything
+	s.upgrader.CheckOrigin = func(r *http.Request) bool { return true }
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", s.handle)
+
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go s.server.Serve(listener)
+
+	return s, nil
+}
+
+// Addr returns the address the server listens on, to be used as the host of an
+// ethstats URL.
+func (s *MockServer) Addr() string {
+	return "ws://" + s.listener.Addr().String()
+}
+
+// Reports returns the channel the reports of all the nodes are delivered on.
+func (s *MockServer) Reports() <-chan MockReport {
+	return s.reports
+}
+
+// RequestHistory asks a connected node to report the given blocks.
+func (s *MockServer) RequestHistory(node string, numbers []uint64) error {
+	s.lock.Lock()
+	conn := s.conns[node]
+	s.lock.Unlock()
+
+	if conn == nil {
+		return errUnknownNode
+	}
+
+	return conn.WriteJSON(map[string][]interface{}{
+		"emit": {"history", map[string]interface{}{"list": numbers}},
+	})
+}
+
+// Close disconnects all the nodes and stops the server.
+func (s *MockServer) Close() error {
+	close(s.quit)
+	err := s.server.Close()
+
+	s.lock.Lock()
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.lock.Unlock()
+
+	s.wg.Wait()
+
+	return err
+}
+
+// handle authenticates a node connecting to the server, and then serves it until
+// the connection breaks.
+func (s *MockServer) handle(w http.ResponseWriter, r *http.Request) {
+	c, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	s.wg.Add(1)
+	defer s.wg.Done()
+
+	conn := newConnectionWrapper(c)
+	defer conn.Close()
+
+	// Authenticate the node with its login message
+	var login struct {
+		Emit []json.RawMessage `json:"emit"`
+	}
+	if err := conn.ReadJSON(&login); err != nil || len(login.Emit) != 2 {
+		return
+	}
+
+	var (
+		kind string
+		auth authMsg
+	)
+	if err := json.Unmarshal(login.Emit[0], &kind); err != nil || kind != "hello" {
+		return
+	}
+
+	if err := json.Unmarshal(login.Emit[1], &auth); err != nil || auth.Secret != s.secret {
+		return
+	}
+
+	if err := conn.WriteJSON(map[string][]string{"emit": {"ready"}}); err != nil {
+		return
+	}
+
+	s.lock.Lock()
+	s.conns[auth.ID] = conn
+	s.lock.Unlock()
+
+	defer func() {
+		s.lock.Lock()
+		if s.conns[auth.ID] == conn {
+			delete(s.conns, auth.ID)
+		}
+		s.lock.Unlock()
+	}()
+
+	if !s.deliver(MockReport{Node: auth.ID, Kind: kind, Data: login.Emit[1]}) {
+		return
+	}
+
+	// Collect the reports of the node, answering its pings
+	for {
+		var msg struct {
+			Emit []json.RawMessage `json:"emit"`
+		}
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+
+		if len(msg.Emit) != 2 || json.Unmarshal(msg.Emit[0], &kind) != nil {
+			continue
+		}
+
+		if kind == "node-ping" {
+			pong := map[string][]interface{}{
+				"emit": {"node-pong", map[string]interface{}{
+					"id":         auth.ID,
+					"serverTime": time.Now().UnixMilli(),
+				}},
+			}
+			if err := conn.WriteJSON(pong); err != nil {
+				return
+			}
+
+			continue
+		}
+
+		if !s.deliver(MockReport{Node: auth.ID, Kind: kind, Data: msg.Emit[1]}) {
+			return
+		}
+	}
+}
+
+// deliver hands a report over to the reader, returning false if the server is
+// closing.
+func (s *MockServer) deliver(report MockReport) bool {
+	select {
+	case s.reports <- report:
+		return true
+	case <-s.quit:
+		return false
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package graphql
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/contract"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Finality statuses of a Bor block.
+const (
+	finalityCheckpointed = "CHECKPOINTED"
+	finalityMilestoned   = "MILESTONED"
+	finalityUnfinalized  = "UNFINALIZED"
+)
+
+// BorReceipt represents the receipt of the synthetic transaction committing
+// the state-sync events of a Bor block.
+type BorReceipt struct {
+	r       *Resolver
+	receipt *types.Receipt
+}
+
+func (r *BorReceipt) TransactionHash(ctx context.Context) common.Hash {
+	return r.receipt.TxHash
+}
+
+func (r *BorReceipt) TransactionIndex(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(r.receipt.TransactionIndex)
+}
+
+func (r *BorReceipt) Status(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(r.receipt.Status)
+}
+
+func (r *BorReceipt) LogsBloom(ctx context.Context) hexutil.Bytes {
+	return r.receipt.Bloom.Bytes()
+}
+
+func (r *BorReceipt) Logs(ctx context.Context) []*BorLog {
+	ret := make([]*BorLog, 0, len(r.receipt.Logs))
+	for _, log := range r.receipt.Logs {
+		ret = append(ret, &BorLog{r: r.r, log: log})
+	}
+	return ret
+}
+
+// BorLog represents a log emitted while committing the state-sync events of a
+// Bor block. Unlike a Log, it isn't generated by a regular transaction.
+type BorLog struct {
+	r   *Resolver
+	log *types.Log
+}
+
+func (l *BorLog) Index(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(l.log.Index)
+}
+
+func (l *BorLog) Account(ctx context.Context, args BlockNumberArgs) *Account {
+	return &Account{
+		r:             l.r,
+		address:       l.log.Address,
+		blockNrOrHash: args.NumberOrLatest(),
+	}
+}
+
+func (l *BorLog) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+func (l *BorLog) Data(ctx context.Context) hexutil.Bytes {
+	return l.log.Data
+}
+
+// StateSyncEvent represents a state-sync event from the root chain, committed
+// in a Bor block.
+type StateSyncEvent struct {
+	event *types.StateSyncData
+}
+
+func (e *StateSyncEvent) ID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(e.event.ID)
+}
+
+func (e *StateSyncEvent) Contract(ctx context.Context) common.Address {
+	return e.event.Contract
+}
+
+func (e *StateSyncEvent) Data(ctx context.Context) (hexutil.Bytes, error) {
+	return hex.DecodeString(e.event.Data)
+}
+
+func (e *StateSyncEvent) TransactionHash(ctx context.Context) common.Hash {
+	return e.event.TxHash
+}
+
+// Span represents a Bor span, the range of blocks produced by a validator set.
+type Span struct {
+	id         uint64
+	startBlock uint64
+	endBlock   uint64
+}
+
+func (s *Span) ID(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(s.id)
+}
+
+func (s *Span) StartBlock(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(s.startBlock)
+}
+
+func (s *Span) EndBlock(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(s.endBlock)
+}
+
+// Validator represents a member of the validator set of a Bor span.
+type Validator struct {
+	address          common.Address
+	votingPower      int64
+	proposerPriority int64
+}
+
+func (v *Validator) Address(ctx context.Context) common.Address {
+	return v.address
+}
+
+func (v *Validator) VotingPower(ctx context.Context) hexutil.Uint64 {
+	return hexutil.Uint64(v.votingPower)
+}
+
+func (v *Validator) ProposerPriority(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*big.NewInt(v.proposerPriority))
+}
+
+func (b *Block) Author(ctx context.Context) (common.Address, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return b.r.backend.Engine().Author(header)
+}
+
+func (b *Block) BorReceipt(ctx context.Context) (*BorReceipt, error) {
+	if _, err := b.resolveHeader(ctx); err != nil {
+		return nil, err
+	}
+	receipt, err := b.r.backend.GetBorBlockReceipt(ctx, b.hash)
+	if err != nil {
+		// Blocks without state syncs have no receipt, but the pruned ones fail
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, nil
+	}
+	return &BorReceipt{r: b.r, receipt: receipt}, nil
+}
+
+func (b *Block) StateSyncEvents(ctx context.Context) ([]*StateSyncEvent, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := rawdb.ReadBorStateSyncs(b.r.backend.ChainDb(), b.hash, header.Number.Uint64())
+
+	ret := make([]*StateSyncEvent, 0, len(events))
+	for _, event := range events {
+		ret = append(ret, &StateSyncEvent{event: event})
+	}
+	return ret, nil
+}
+
+// Span returns the span the block belongs to, as recorded by the validator set
+// contract. Null is returned on chains not running Bor.
+func (b *Block) Span(ctx context.Context) (*Span, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := b.r.backend.Engine().(*bor.Bor); !ok {
+		return nil, nil
+	}
+	var (
+		validatorSet  = contract.ValidatorSet()
+		to            = common.HexToAddress(b.r.backend.ChainConfig().Bor.ValidatorContract)
+		blockNrOrHash = rpc.BlockNumberOrHashWithHash(b.hash, false)
+	)
+	call := func(method string, args ...interface{}) ([]interface{}, error) {
+		data, err := validatorSet.Pack(method, args...)
+		if err != nil {
+			return nil, err
+		}
+		input := hexutil.Bytes(data)
+		result, err := ethapi.DoCall(ctx, b.r.backend, ethapi.TransactionArgs{To: &to, Data: &input}, blockNrOrHash, nil, nil, nil, b.r.backend.RPCEVMTimeout(), b.r.backend.RPCGasCap())
+		if err != nil {
+			return nil, err
+		}
+		if err := result.Err; err != nil {
+			return nil, err
+		}
+		return validatorSet.Unpack(method, result.Return())
+	}
+	ret, err := call("getSpanByBlock", header.Number)
+	if err != nil {
+		return nil, err
+	}
+	id := ret[0].(*big.Int)
+
+	if ret, err = call("getSpan", id); err != nil {
+		return nil, err
+	}
+	return &Span{
+		id:         id.Uint64(),
+		startBlock: ret[1].(*big.Int).Uint64(),
+		endBlock:   ret[2].(*big.Int).Uint64(),
+	}, nil
+}
+
+// Validators returns the validator set producing the block. Null is returned on
+// chains not running Bor.
+func (b *Block) Validators(ctx context.Context) (*[]*Validator, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	engine, ok := b.r.backend.Engine().(*bor.Bor)
+	if !ok {
+		return nil, nil
+	}
+	validators, err := engine.GetCurrentValidators(ctx, b.hash, header.Number.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*Validator, 0, len(validators))
+	for _, v := range validators {
+		ret = append(ret, &Validator{
+			address:          v.Address,
+			votingPower:      v.VotingPower,
+			proposerPriority: v.ProposerPriority,
+		})
+	}
+	return &ret, nil
+}
+
+// Finality returns whether the block is covered by the latest whitelisted
+// checkpoint or milestone. Blocks off the canonical chain are never final.
+func (b *Block) Finality(ctx context.Context) (string, error) {
+	header, err := b.resolveHeader(ctx)
+	if err != nil {
+		return "", err
+	}
+	number := header.Number.Uint64()
+
+	canonical, err := b.r.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return "", err
+	}
+	if canonical == nil || canonical.Hash() != b.hash {
+		return finalityUnfinalized, nil
+	}
+	if ok, checkpoint, _ := b.r.backend.GetWhitelistedCheckpoint(); ok && number <= checkpoint {
+		return finalityCheckpointed, nil
+	}
+	if ok, milestone, _ := b.r.backend.GetWhitelistedMilestone(); ok && number <= milestone {
+		return finalityMilestoned, nil
+	}
+	return finalityUnfinalized, nil
+}
@@ -430,6 +430,55 @@ func TestWithdrawals(t *testing.T) {
 	}
 }
 
+// Tests that the Bor specific block fields resolve gracefully on a chain which
+// isn't running Bor.
+func TestGraphQLBorBlockFields(t *testing.T) {
+	var (
+		coinbase = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		genesis  = &core.Genesis{
+			Config:     params.AllEthashProtocolChanges,
+			GasLimit:   11500000,
+			Difficulty: big.NewInt(1048576),
+		}
+		stack = createNode(t)
+	)
+	defer stack.Close()
+
+	handler, _ := newGQLService(t, stack, false, genesis, 2, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(coinbase)
+	})
+	// start node
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: "{block(number: 1) { author finality } }",
+			want: `{"block":{"author":"0x1111111111111111111111111111111111111111","finality":"UNFINALIZED"}}`,
+		},
+		{
+			body: "{block(number: 2) { borReceipt { status } stateSyncEvents { id } span { id } validators { address } } }",
+			want: `{"block":{"borReceipt":null,"stateSyncEvents":[],"span":null,"validators":null}}`,
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.body, "", map[string]interface{}{})
+		if res.Errors != nil {
+			t.Fatalf("failed to execute query for testcase #%d: %v", i, res.Errors)
+		}
+		have, err := json.Marshal(res.Data)
+		if err != nil {
+			t.Fatalf("failed to encode graphql response for testcase #%d: %s", i, err)
+		}
+		if string(have) != tt.want {
+			t.Errorf("response unmatch for testcase #%d.\nhave:\n%s\nwant:\n%s", i, have, tt.want)
+		}
+	}
+}
+
 func createNode(t *testing.T) *node.Node {
 	t.Helper()
 	stack, err := node.New(&node.Config{
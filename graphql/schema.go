@@ -267,6 +267,102 @@ const schema string = `
         # Withdrawals is a list of withdrawals associated with this block. If
         # withdrawals are unavailable for this block, this field will be null.
         withdrawals: [Withdrawal!]
+        # Author is the account that sealed this block, recovered from its
+        # signature on Bor.
+        author: Address!
+        # BorReceipt is the receipt of the state-sync transaction of this
+        # block. If the block commits no state-sync events, this field will be
+        # null.
+        borReceipt: BorReceipt
+        # StateSyncEvents is a list of the state-sync events committed in this
+        # block.
+        stateSyncEvents: [StateSyncEvent!]!
+        # Span is the span this block belongs to. If the chain is not running
+        # Bor, this field will be null.
+        span: Span
+        # Validators is the validator set producing this block. If the chain is
+        # not running Bor, this field will be null.
+        validators: [Validator!]
+        # Finality is the finality status of this block, according to the
+        # latest whitelisted checkpoint and milestone.
+        finality: Finality!
+    }
+
+    # BorReceipt is the receipt of the synthetic transaction committing the
+    # state-sync events of a Bor block.
+    type BorReceipt {
+        # TransactionHash is the hash of the state-sync transaction, derived
+        # from the block number and hash.
+        transactionHash: Bytes32!
+        # TransactionIndex is the index of the state-sync transaction in the
+        # block, following all the regular transactions.
+        transactionIndex: Long!
+        # Status is the result of the state-sync transaction - 1 for success.
+        status: Long!
+        # LogsBloom is a bloom filter of the logs emitted by the state-sync
+        # transaction.
+        logsBloom: Bytes!
+        # Logs is a list of log entries emitted while committing the state-sync
+        # events.
+        logs: [BorLog!]!
+    }
+
+    # BorLog is a log entry emitted while committing the state-sync events of
+    # a Bor block.
+    type BorLog {
+        # Index is the index of this log in the block.
+        index: Long!
+        # Account is the account which generated this log - this will always
+        # be a contract account.
+        account(block: Long): Account!
+        # Topics is a list of 0-4 indexed topics for the log.
+        topics: [Bytes32!]!
+        # Data is unindexed data for this log.
+        data: Bytes!
+    }
+
+    # StateSyncEvent is an event from the root chain committed in a Bor block.
+    type StateSyncEvent {
+        # ID is the sequential identifier of the event.
+        id: Long!
+        # Contract is the receiver of the event on the Bor chain.
+        contract: Address!
+        # Data is the payload of the event.
+        data: Bytes!
+        # TransactionHash is the hash of the root chain transaction emitting
+        # the event.
+        transactionHash: Bytes32!
+    }
+
+    # Span is a range of Bor blocks produced by a single validator set.
+    type Span {
+        # ID is the sequential identifier of the span.
+        id: Long!
+        # StartBlock is the first block of the span.
+        startBlock: Long!
+        # EndBlock is the last block of the span.
+        endBlock: Long!
+    }
+
+    # Validator is a member of the validator set of a Bor span.
+    type Validator {
+        # Address is the signer address of the validator.
+        address: Address!
+        # VotingPower is the stake weighted power of the validator.
+        votingPower: Long!
+        # ProposerPriority is the priority of the validator to propose the next
+        # block.
+        proposerPriority: BigInt!
+    }
+
+    # Finality is the finality status of a Bor block.
+    enum Finality {
+        # CHECKPOINTED blocks are covered by a checkpoint submitted to the root chain.
+        CHECKPOINTED
+        # MILESTONED blocks are covered by a milestone agreed on by the validators.
+        MILESTONED
+        # UNFINALIZED blocks may still be reorganised.
+        UNFINALIZED
     }
 
     # CallData represents the data associated with a local contract call.
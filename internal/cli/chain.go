@@ -18,6 +18,9 @@ func (c *ChainCommand) MarkDown() string {
 		"The ```chain``` command groups actions to interact with the blockchain in the client:",
 		"- [```chain sethead```](./chain_sethead.md): Set the current chain to a certain block.",
 		"- [```chain watch```](./chain_watch.md): Watch the chainHead, reorg and fork events in real-time.",
+		"- [```chain export```](./chain_export.md): Export the chain history of a stopped node into archives.",
+		"- [```chain import```](./chain_import.md): Import the chain history of archives into a stopped node.",
+		"- [```chain forks```](./chain_forks.md): Print the Bor consensus parameters of the chain.",
 	}
 
 	return strings.Join(items, "\n\n")
@@ -31,7 +34,15 @@ func (c *ChainCommand) Help() string {
 	
   Set the new head of the chain:
   
-    $ bor chain sethead <number>`
+    $ bor chain sethead <number>
+
+  Export the chain history into archives:
+
+    $ bor chain export <dir>
+
+  Print the Bor consensus parameters of the chain:
+
+    $ bor chain forks [number]`
 }
 
 // Synopsis implements the cli.Command interface
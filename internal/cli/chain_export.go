@@ -0,0 +1,314 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ChainExportCommand is the command to export the chain history into archives
+type ChainExportCommand struct {
+	*Meta
+
+	datadirAncient string
+	remoteDB       server.RemoteDBConfig
+	first          uint64
+	last           uint64
+	blocks         uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainExportCommand) MarkDown() string {
+	items := []string{
+		"# Chain export",
+		"The ```chain export <dir>``` command exports the chain history of a stopped node into archive files. Each archive holds the headers, bodies, receipts and bor receipts of a fixed range of blocks, along with a checksum and the checkpoint root hash of its headers.",
+		"## Arguments",
+		"- ```dir```: The directory to write the archives into.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainExportCommand) Help() string {
+	return `Usage: bor chain export <dir>
+
+  This command exports the chain history into archive files` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainExportCommand) Synopsis() string {
+	return "Export the chain history into archives"
+}
+
+func (c *ChainExportCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("chain export")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &c.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	remoteDBFlags(flags, &c.remoteDB)
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "first",
+		Usage:   "Number of the first block to export, must be a multiple of the archive size",
+		Value:   &c.first,
+		Default: 0,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "last",
+		Usage:   "Number of the last block to export (0 = current head)",
+		Value:   &c.last,
+		Default: 0,
+	})
+
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "blocks",
+		Usage:   "Number of blocks in each archive",
+		Value:   &c.blocks,
+		Default: era.DefaultBlocks,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *ChainExportCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("No directory provided")
+		return 1
+	}
+
+	dir := args[0]
+
+	if c.blocks == 0 || c.blocks > era.MaxBlocks {
+		c.UI.Error(fmt.Sprintf("Archive size must be between 1 and %d blocks", era.MaxBlocks))
+		return 1
+	}
+
+	if c.first%c.blocks != 0 {
+		c.UI.Error(fmt.Sprintf("First block must be a multiple of the archive size %d", c.blocks))
+		return 1
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	closer, db, err := openReadOnlyChainDB(c.dataDir, c.datadirAncient, &c.remoteDB)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer closer.Close()
+
+	head := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadBlockHash(db))
+	if head == nil {
+		c.UI.Error("Head block not found")
+		return 1
+	}
+
+	last := c.last
+	if last == 0 || last > *head {
+		last = *head
+	}
+
+	if c.first > last {
+		c.UI.Error(fmt.Sprintf("First block #%d is past the last block #%d", c.first, last))
+		return 1
+	}
+
+	network := networkName(rawdb.ReadCanonicalHash(db, 0))
+
+	for start := c.first; start <= last; start += c.blocks {
+		end := start + c.blocks - 1
+		if end > last {
+			end = last
+		}
+
+		name, err := exportArchive(db, dir, network, start/c.blocks, start, end)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		c.UI.Output(fmt.Sprintf("Exported blocks #%d-#%d to %s", start, end, name))
+	}
+
+	return 0
+}
+
+// exportArchive writes the blocks in the given range into the archive of the
+// given epoch, returning its file name.
+func exportArchive(db ethdb.Database, dir string, network string, epoch uint64, first uint64, last uint64) (string, error) {
+	f, err := os.CreateTemp(dir, "*.era.tmp")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		f.Close()
+		os.Remove(f.Name())
+	}()
+
+	var (
+		w       = bufio.NewWriter(f)
+		builder = era.NewBuilder(w)
+	)
+
+	for number := first; number <= last; number++ {
+		hash := rawdb.ReadCanonicalHash(db, number)
+		if hash == (common.Hash{}) {
+			return "", fmt.Errorf("block #%d not found, history may be pruned", number)
+		}
+
+		block := &era.Block{
+			Header:     rawdb.ReadHeaderRLP(db, hash, number),
+			Body:       rawdb.ReadBodyRLP(db, hash, number),
+			Receipts:   rawdb.ReadReceiptsRLP(db, hash, number),
+			BorReceipt: rawdb.ReadBorReceiptRLP(db, hash, number),
+			Td:         rawdb.ReadTdRLP(db, hash, number),
+		}
+		if len(block.Header) == 0 || len(block.Body) == 0 || len(block.Receipts) == 0 || len(block.Td) == 0 {
+			return "", fmt.Errorf("block #%d is incomplete, history may be pruned", number)
+		}
+
+		if err := builder.Add(block); err != nil {
+			return "", fmt.Errorf("failed to add block #%d: %w", number, err)
+		}
+	}
+
+	accumulator, err := builder.Finalize()
+	if err != nil {
+		return "", err
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	if err := f.Sync(); err != nil {
+		return "", err
+	}
+
+	name := filepath.Join(dir, era.Filename(network, epoch, accumulator))
+	if err := os.Rename(f.Name(), name); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// networkName returns the name of the network with the given genesis, used to
+// prefix the archive files.
+func networkName(genesis common.Hash) string {
+	switch genesis {
+	case params.BorMainnetGenesisHash:
+		return "mainnet"
+	case params.MumbaiGenesisHash:
+		return "mumbai"
+	default:
+		return "bor"
+	}
+}
+
+// openChainDB opens the chain database of the node at the given datadir. The
+// returned node must be closed once done with the database.
+func openChainDB(datadir string, ancient string, readonly bool) (*node.Node, ethdb.Database, error) {
+	if datadir == "" {
+		datadir = server.DefaultDataDir()
+	}
+
+	stack, err := node.New(&node.Config{
+		DataDir: datadir,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dbHandles, err := server.MakeDatabaseHandles(0)
+	if err != nil {
+		stack.Close()
+		return nil, nil, err
+	}
+
+	db, err := stack.OpenDatabaseWithFreezer(chaindataPath, 512, dbHandles, ancient, "", readonly, rawdb.ExtraDBConfig{})
+	if err != nil {
+		stack.Close()
+		return nil, nil, err
+	}
+
+	return stack, db, nil
+}
+
+// remoteDBFlags adds the flags reading a database served with 'bor db serve'
+// instead of the local one.
+func remoteDBFlags(flags *flagset.Flagset, config *server.RemoteDBConfig) {
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "db.remote",
+		Value: &config.Addr,
+		Usage: "Address of a database served with 'bor db serve' to read instead of the local one",
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "db.remote.cert",
+		Value: &config.Cert,
+		Usage: "Client certificate authenticating to the database server over TLS",
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "db.remote.key",
+		Value: &config.Key,
+		Usage: "Key of the client certificate of the database server",
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "db.remote.ca",
+		Value: &config.CA,
+		Usage: "Certificate authority of the database server, the system ones if empty",
+	})
+}
+
+// openReadOnlyChainDB opens the chain database read-only, the one served with
+// 'bor db serve' if configured, the local one of the node at the given datadir
+// otherwise. The returned closer must be closed once done.
+func openReadOnlyChainDB(datadir string, ancient string, remote *server.RemoteDBConfig) (io.Closer, ethdb.Database, error) {
+	if remote.Addr != "" {
+		db, err := remote.Dial()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return db, db, nil
+	}
+
+	stack, db, err := openChainDB(datadir, ancient, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stack, db, nil
+}
@@ -0,0 +1,219 @@
+package cli
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests that the chain history exported into archives can be imported into the
+// ancient store of another node.
+func TestChainExportImport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &core.Genesis{Config: params.TestChainConfig, Alloc: core.GenesisAlloc{address: {Balance: big.NewInt(1000000000000000000)}}}
+		signer  = types.LatestSigner(gspec.Config)
+	)
+
+	_, blocks, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 20, func(i int, block *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{0x01}, big.NewInt(1000), params.TxGas, block.BaseFee(), nil), signer, key)
+		require.NoError(t, err)
+		block.AddTx(tx)
+	})
+
+	// Populate the exporting database, with a bor receipt in every fourth block
+	src := rawdb.NewMemoryDatabase()
+	genesis := gspec.MustCommit(src)
+
+	td := new(big.Int).Set(genesis.Difficulty())
+
+	for i, block := range blocks {
+		td.Add(td, block.Difficulty())
+
+		rawdb.WriteBlock(src, block)
+		rawdb.WriteReceipts(src, block.Hash(), block.NumberU64(), receipts[i])
+		rawdb.WriteTd(src, block.Hash(), block.NumberU64(), td)
+		rawdb.WriteCanonicalHash(src, block.Hash(), block.NumberU64())
+
+		if i%4 == 0 {
+			rawdb.WriteBorReceipt(src, block.Hash(), block.NumberU64(), &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}})
+		}
+	}
+
+	dir := t.TempDir()
+
+	first, err := exportArchive(src, dir, "bor", 0, 0, 7)
+	require.NoError(t, err)
+
+	second, err := exportArchive(src, dir, "bor", 1, 8, 20)
+	require.NoError(t, err)
+
+	files, err := archiveFiles([]string{dir})
+	require.NoError(t, err)
+	require.Equal(t, []string{first, second}, files)
+	require.Equal(t, "bor-00000-", filepath.Base(first)[:10])
+
+	// Import the archives into a node holding the genesis only
+	dst, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	require.NoError(t, err)
+
+	defer dst.Close()
+
+	gspec.MustCommit(dst)
+
+	for _, file := range files {
+		_, err := verifyArchive(file)
+		require.NoError(t, err)
+
+		_, err = importArchive(dst, file)
+		require.NoError(t, err)
+	}
+
+	// Importing again is a noop
+	imported, err := importArchive(dst, first)
+	require.NoError(t, err)
+	require.Zero(t, imported)
+
+	frozen, err := dst.Ancients()
+	require.NoError(t, err)
+	require.Equal(t, uint64(21), frozen)
+
+	for i, block := range blocks {
+		number := block.NumberU64()
+
+		require.Equal(t, block.Hash(), rawdb.ReadCanonicalHash(dst, number))
+		require.Equal(t, block.Hash(), rawdb.ReadBlock(dst, block.Hash(), number).Hash())
+		require.Equal(t, rawdb.ReadReceiptsRLP(src, block.Hash(), number), rawdb.ReadReceiptsRLP(dst, block.Hash(), number))
+		require.Equal(t, rawdb.ReadBorReceiptRLP(src, block.Hash(), number), rawdb.ReadBorReceiptRLP(dst, block.Hash(), number))
+		require.Equal(t, rawdb.ReadTd(src, block.Hash(), number), rawdb.ReadTd(dst, block.Hash(), number))
+		require.NotNil(t, rawdb.ReadTxLookupEntry(dst, block.Transactions()[0].Hash()))
+		require.Equal(t, i%4 == 0, rawdb.ReadBorTxLookupEntry(dst, types.GetDerivedBorTxHash(types.BorReceiptKey(number, block.Hash()))) != nil)
+	}
+
+	require.Equal(t, blocks[len(blocks)-1].Hash(), rawdb.ReadHeadHeaderHash(dst))
+	require.Equal(t, blocks[len(blocks)-1].Hash(), rawdb.ReadHeadFastBlockHash(dst))
+
+	// The blocks still held in the key-value store are left to the freezer
+	synced, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	require.NoError(t, err)
+
+	defer synced.Close()
+
+	gspec.MustCommit(synced)
+
+	for _, block := range blocks {
+		rawdb.WriteBlock(synced, block)
+		rawdb.WriteCanonicalHash(synced, block.Hash(), block.NumberU64())
+	}
+
+	imported, err = importArchive(synced, first)
+	require.NoError(t, err)
+	require.Equal(t, 1, imported)
+
+	frozen, err = synced.Ancients()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), frozen)
+
+	// The archives of another chain don't extend the ancient store
+	forked, err := rawdb.NewDatabaseWithFreezer(rawdb.NewMemoryDatabase(), t.TempDir(), "", false)
+	require.NoError(t, err)
+
+	defer forked.Close()
+
+	gspec.MustCommit(forked)
+
+	_, err = importArchive(forked, first)
+	require.NoError(t, err)
+
+	_, forks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 20, func(i int, block *core.BlockGen) {
+		block.SetExtra([]byte("fork"))
+	})
+
+	for _, block := range forks[7:] {
+		rawdb.WriteBlock(src, block)
+		rawdb.WriteReceipts(src, block.Hash(), block.NumberU64(), types.Receipts{})
+		rawdb.WriteTd(src, block.Hash(), block.NumberU64(), block.Difficulty())
+		rawdb.WriteCanonicalHash(src, block.Hash(), block.NumberU64())
+	}
+
+	other, err := exportArchive(src, t.TempDir(), "bor", 1, 8, 20)
+	require.NoError(t, err)
+
+	_, err = importArchive(forked, other)
+	require.ErrorContains(t, err, "parent mismatch")
+}
+
+// testCheckpointFetcher is a CheckpointFetcher serving a fixed list of
+// checkpoints.
+type testCheckpointFetcher []*checkpoint.Checkpoint
+
+func (f testCheckpointFetcher) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	return f[number-1], nil
+}
+
+func (f testCheckpointFetcher) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return int64(len(f)), nil
+}
+
+// Tests that the checkpoints straddling two archives are verified, and that the
+// blocks no checkpoint covers are reported.
+func TestCheckpointVerifier(t *testing.T) {
+	t.Parallel()
+
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 20, nil)
+
+	headers := []*types.Header{gspec.ToBlock().Header()}
+	for _, block := range blocks {
+		headers = append(headers, block.Header())
+	}
+
+	checkpointOf := func(start, end uint64) *checkpoint.Checkpoint {
+		root, err := bor.ComputeRootHash(headers[start : end+1])
+		require.NoError(t, err)
+
+		return &checkpoint.Checkpoint{
+			StartBlock: new(big.Int).SetUint64(start),
+			EndBlock:   new(big.Int).SetUint64(end),
+			RootHash:   common.BytesToHash(root),
+		}
+	}
+
+	fetcher := testCheckpointFetcher{checkpointOf(0, 5), checkpointOf(6, 11), checkpointOf(12, 16)}
+
+	// The second checkpoint straddles the archives, the last blocks aren't covered
+	verifier := &checkpointVerifier{fetcher: fetcher}
+	require.NoError(t, verifier.verify(headers[:8]))
+	require.NoError(t, verifier.verify(headers[8:]))
+	require.Equal(t, [][2]uint64{{17, 20}}, verifier.finish())
+	require.Equal(t, 3, verifier.checkpoints)
+
+	// The blocks preceding the first checkpoint aren't covered either
+	verifier = &checkpointVerifier{fetcher: fetcher}
+	require.NoError(t, verifier.verify(headers[8:]))
+	require.Equal(t, [][2]uint64{{8, 11}, {17, 20}}, verifier.finish())
+	require.Equal(t, 1, verifier.checkpoints)
+
+	// A mismatching straddling checkpoint is detected
+	fetcher[1].RootHash = common.Hash{0x01}
+
+	verifier = &checkpointVerifier{fetcher: fetcher}
+	require.NoError(t, verifier.verify(headers[:8]))
+	require.Error(t, verifier.verify(headers[8:]))
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/mitchellh/cli"
+)
+
+// ChainForksCommand is the command to print the Bor consensus parameters
+type ChainForksCommand struct {
+	UI cli.Ui
+
+	chain string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainForksCommand) MarkDown() string {
+	items := []string{
+		"# Chain forks",
+		"The ```chain forks [number]``` command validates the Bor config of a chain and prints its effective consensus parameters at each scheduled change, or at the given block.",
+		"## Arguments",
+		"- ```number```: The block number to print the parameters at.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainForksCommand) Help() string {
+	return `Usage: bor chain forks [number] [--chain <name or genesis file>]
+
+  This command prints the effective Bor consensus parameters of a chain`
+}
+
+func (c *ChainForksCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("chain forks")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "chain",
+		Usage:   "Name of the chain or path to its genesis file",
+		Default: "mainnet",
+		Value:   &c.chain,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainForksCommand) Synopsis() string {
+	return "Print the Bor consensus parameters of the chain"
+}
+
+// Run implements the cli.Command interface
+func (c *ChainForksCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		c.UI.Error("No more than one argument expected")
+		return 1
+	}
+
+	chain, err := chains.GetChain(c.chain)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	config := chain.Genesis.Config
+	if config.Bor == nil {
+		c.UI.Error(fmt.Sprintf("Chain %s has no Bor config", c.chain))
+		return 1
+	}
+
+	if err := config.CheckBorConfig(); err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid Bor config:\n%v", err))
+		return 1
+	}
+
+	if len(args) == 1 {
+		number, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Invalid block number: %v", err))
+			return 1
+		}
+
+		c.UI.Output(formatBorParams(config.Bor.ParamsAt(number)))
+
+		return 0
+	}
+
+	rows := []string{"Block|Period|Producer delay|Sprint|Backup multiplier|Burnt contract|State-sync delay|Forks|Block alloc"}
+
+	for _, number := range config.Bor.Schedule() {
+		p := config.Bor.ParamsAt(number)
+		rows = append(rows, fmt.Sprintf("%d|%d|%d|%d|%d|%s|%d|%s|%t",
+			p.Number,
+			p.Period,
+			p.ProducerDelay,
+			p.Sprint,
+			p.BackupMultiplier,
+			p.BurntContract,
+			p.StateSyncDelay,
+			borForks(p),
+			p.BlockAlloc,
+		))
+	}
+
+	c.UI.Output(formatList(rows))
+
+	return 0
+}
+
+// borForks returns the names of the Bor forks enabled in the given parameters.
+func borForks(p *params.BorParams) string {
+	var forks []string
+
+	for _, fork := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"jaipur", p.Jaipur},
+		{"delhi", p.Delhi},
+		{"indore", p.Indore},
+	} {
+		if fork.enabled {
+			forks = append(forks, fork.name)
+		}
+	}
+
+	return strings.Join(forks, ",")
+}
+
+func formatBorParams(p *params.BorParams) string {
+	return formatKV([]string{
+		fmt.Sprintf("Block|%d", p.Number),
+		fmt.Sprintf("Period|%d", p.Period),
+		fmt.Sprintf("Producer delay|%d", p.ProducerDelay),
+		fmt.Sprintf("Sprint|%d", p.Sprint),
+		fmt.Sprintf("Backup multiplier|%d", p.BackupMultiplier),
+		fmt.Sprintf("Burnt contract|%s", p.BurntContract),
+		fmt.Sprintf("State-sync delay|%d", p.StateSyncDelay),
+		fmt.Sprintf("Forks|%s", borForks(p)),
+		fmt.Sprintf("Block alloc|%t", p.BlockAlloc),
+	})
+}
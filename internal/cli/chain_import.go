@@ -0,0 +1,449 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/era"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// importBatchSize is the number of blocks appended to the ancient store at once
+	importBatchSize = 1024
+
+	// maxCheckpointLength bounds the headers carried over between archives to
+	// verify a straddling checkpoint, way above the length of any checkpoint
+	maxCheckpointLength = 1 << 16
+)
+
+// ChainImportCommand is the command to import the chain history from archives
+type ChainImportCommand struct {
+	*Meta
+
+	datadirAncient string
+	heimdallURL    string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ChainImportCommand) MarkDown() string {
+	items := []string{
+		"# Chain import",
+		"The ```chain import <path>...``` command imports the chain history of archive files into the ancient store of a stopped node. Every archive is verified before being imported: the blocks must match their headers and link to each other, and the checksum and the accumulator must match the content. The checkpoints covering the archives are verified against Heimdall if its url is given, the import is refused if none does and the blocks no checkpoint covers are reported. Blocks still held in the key-value store are left to the freezer. The state isn't regenerated.",
+		"## Arguments",
+		"- ```path```: An archive file, or a directory holding archive files.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ChainImportCommand) Help() string {
+	return `Usage: bor chain import <path>...
+
+  This command imports the chain history from archive files` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ChainImportCommand) Synopsis() string {
+	return "Import the chain history from archives"
+}
+
+func (c *ChainImportCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("chain import")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &c.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "bor.heimdall",
+		Value:   &c.heimdallURL,
+		Usage:   "URL of Heimdall service to verify the archives against its checkpoints (optional)",
+		Default: "",
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *ChainImportCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) == 0 {
+		c.UI.Error("No archive provided")
+		return 1
+	}
+
+	files, err := archiveFiles(args)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	// Verify all the archives before importing any, a checkpoint may straddle
+	// two of them
+	var verifier *checkpointVerifier
+
+	if c.heimdallURL != "" {
+		client := heimdall.NewHeimdallClient(c.heimdallURL)
+		defer client.Close()
+
+		verifier = &checkpointVerifier{fetcher: client}
+	}
+
+	spans := make([]string, len(files))
+
+	for i, file := range files {
+		headers, err := verifyArchive(file)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Invalid archive %s: %v", file, err))
+			return 1
+		}
+
+		if verifier != nil {
+			if err := verifier.verify(headers); err != nil {
+				c.UI.Error(fmt.Sprintf("Invalid archive %s: %v", file, err))
+				return 1
+			}
+		}
+
+		spans[i] = fmt.Sprintf("#%d-#%d", headers[0].Number, headers[len(headers)-1].Number)
+	}
+
+	if verifier != nil {
+		unanchored := verifier.finish()
+
+		if verifier.checkpoints == 0 {
+			c.UI.Error("No checkpoint covers the archives")
+			return 1
+		}
+
+		for _, blocks := range unanchored {
+			c.UI.Warn(fmt.Sprintf("Blocks #%d-#%d aren't covered by a checkpoint", blocks[0], blocks[1]))
+		}
+	}
+
+	stack, db, err := openChainDB(c.dataDir, c.datadirAncient, false)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer stack.Close()
+
+	for i, file := range files {
+		imported, err := importArchive(db, file)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to import archive %s: %v", file, err))
+			return 1
+		}
+
+		c.UI.Output(fmt.Sprintf("Imported %d blocks of %s from %s", imported, spans[i], file))
+	}
+
+	if err := db.Sync(); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// archiveFiles resolves the archive files from the given paths, sorted in chain
+// order.
+func archiveFiles(paths []string) ([]string, error) {
+	var files []string
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(path, "*.era"))
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+
+	return files, nil
+}
+
+// verifyArchive reads a whole archive, verifying its content. The headers of the
+// archive are returned.
+func verifyArchive(file string) ([]*types.Header, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader, err := era.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, _, err := reader.Next(); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return reader.Headers(), nil
+}
+
+// checkpointVerifier verifies the headers of consecutive archives against the
+// checkpoints covering them, carrying over the headers of a checkpoint
+// straddling two archives.
+type checkpointVerifier struct {
+	fetcher era.CheckpointFetcher
+
+	pending     []*types.Header // Headers following the last anchored block
+	checkpoints int             // Number of checkpoints verified
+	unanchored  [][2]uint64     // Block ranges no verified checkpoint covers
+}
+
+// verify checks the headers of the next archive, along with the pending ones,
+// against the checkpoints covering them.
+func (v *checkpointVerifier) verify(headers []*types.Header) error {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	// An archive not following the pending headers leaves them unanchored
+	if n := len(v.pending); n > 0 && v.pending[n-1].Number.Uint64()+1 != headers[0].Number.Uint64() {
+		v.skip(v.pending)
+		v.pending = nil
+	}
+
+	headers = append(append([]*types.Header{}, v.pending...), headers...)
+
+	coverage, err := era.VerifyCheckpoints(context.Background(), v.fetcher, headers)
+	if err != nil {
+		return err
+	}
+
+	if coverage.Checkpoints == 0 {
+		v.pending = headers
+	} else {
+		// The blocks preceding the first checkpoint can't be anchored anymore
+		first := headers[0].Number.Uint64()
+
+		v.skip(headers[:coverage.First-first])
+
+		v.checkpoints += coverage.Checkpoints
+		v.pending = headers[coverage.Last-first+1:]
+	}
+
+	if n := len(v.pending); n > maxCheckpointLength {
+		v.skip(v.pending[:n-maxCheckpointLength])
+		v.pending = v.pending[n-maxCheckpointLength:]
+	}
+
+	return nil
+}
+
+// skip records the blocks of the given headers as unanchored.
+func (v *checkpointVerifier) skip(headers []*types.Header) {
+	if len(headers) == 0 {
+		return
+	}
+
+	first, last := headers[0].Number.Uint64(), headers[len(headers)-1].Number.Uint64()
+
+	if k := len(v.unanchored); k > 0 && v.unanchored[k-1][1]+1 == first {
+		v.unanchored[k-1][1] = last
+	} else {
+		v.unanchored = append(v.unanchored, [2]uint64{first, last})
+	}
+}
+
+// finish gives up anchoring the pending headers, returning the block ranges no
+// verified checkpoint covers.
+func (v *checkpointVerifier) finish() [][2]uint64 {
+	v.skip(v.pending)
+	v.pending = nil
+
+	return v.unanchored
+}
+
+// importArchive appends the blocks of an archive to the ancient store, along
+// with their hash to number mappings and transaction lookup entries. Blocks
+// already present, in the ancient store or still in the key-value store, are
+// checked to be identical and skipped. The number of imported blocks is returned.
+func importArchive(db ethdb.Database, file string) (int, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	reader, err := era.NewReader(bufio.NewReader(f))
+	if err != nil {
+		return 0, err
+	}
+
+	frozen, err := db.Ancients()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		batch    = db.NewBatch()
+		blocks   []*rawdb.AncientBlockRLP
+		last     *types.Header
+		imported int
+	)
+
+	flush := func() error {
+		if len(blocks) == 0 {
+			return nil
+		}
+
+		if _, err := rawdb.WriteAncientBlocksRLP(db, frozen, blocks); err != nil {
+			return err
+		}
+
+		if err := batch.Write(); err != nil {
+			return err
+		}
+
+		frozen += uint64(len(blocks))
+		imported += len(blocks)
+
+		blocks = blocks[:0]
+		batch.Reset()
+
+		return nil
+	}
+
+	for {
+		header, block, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return imported, err
+		}
+
+		var (
+			number = header.Number.Uint64()
+			hash   = header.Hash()
+		)
+
+		canonical := rawdb.ReadCanonicalHash(db, number)
+		if canonical != (common.Hash{}) && canonical != hash {
+			return imported, fmt.Errorf("block #%d mismatch: have %x, local %x", number, hash, canonical)
+		}
+
+		if number < frozen {
+			continue
+		}
+
+		// Blocks past the ancient store are left to the freezer if held in the
+		// key-value store, unlike the genesis which is kept there once frozen
+		if number > 0 && canonical != (common.Hash{}) {
+			continue
+		}
+
+		if number != frozen+uint64(len(blocks)) {
+			return imported, fmt.Errorf("block #%d doesn't extend the ancient store at #%d", number, frozen+uint64(len(blocks)))
+		}
+
+		// The block must be the child of the last one imported, or of the last
+		// one of the ancient store
+		var parent common.Hash
+
+		switch {
+		case last != nil:
+			parent = last.Hash()
+		case number > 0:
+			parent = rawdb.ReadCanonicalHash(db, number-1)
+		}
+
+		if number > 0 && header.ParentHash != parent {
+			return imported, fmt.Errorf("block #%d parent mismatch: have %x, local %x", number, header.ParentHash, parent)
+		}
+
+		var body types.Body
+		if err := rlp.DecodeBytes(block.Body, &body); err != nil {
+			return imported, err
+		}
+
+		hashes := make([]common.Hash, 0, len(body.Transactions))
+		for _, tx := range body.Transactions {
+			hashes = append(hashes, tx.Hash())
+		}
+
+		rawdb.WriteCanonicalHash(batch, hash, number)
+		rawdb.WriteHeaderNumber(batch, hash, number)
+		rawdb.WriteTxLookupEntries(batch, number, hashes)
+
+		if len(block.BorReceipt) > 0 {
+			rawdb.WriteBorTxLookupEntry(batch, hash, number)
+		}
+
+		blocks = append(blocks, &rawdb.AncientBlockRLP{
+			Hash:       hash,
+			Header:     block.Header,
+			Body:       block.Body,
+			Receipts:   block.Receipts,
+			Td:         block.Td,
+			BorReceipt: block.BorReceipt,
+		})
+		last = header
+
+		if len(blocks) == importBatchSize {
+			if err := flush(); err != nil {
+				return imported, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return imported, err
+	}
+
+	// Forward the header and snap sync heads, the state isn't available
+	if last != nil {
+		if head := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadHeaderHash(db)); head == nil || *head < last.Number.Uint64() {
+			rawdb.WriteHeadHeaderHash(db, last.Hash())
+			rawdb.WriteHeadFastBlockHash(db, last.Hash())
+		}
+	}
+
+	return imported, nil
+}
@@ -103,6 +103,11 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"debug replay-build": func() (MarkDownCommand, error) {
+			return &DebugReplayBuildCommand{
+				Meta: meta,
+			}, nil
+		},
 		"chain": func() (MarkDownCommand, error) {
 			return &ChainCommand{
 				UI: ui,
@@ -118,6 +123,21 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"chain forks": func() (MarkDownCommand, error) {
+			return &ChainForksCommand{
+				UI: ui,
+			}, nil
+		},
+		"chain export": func() (MarkDownCommand, error) {
+			return &ChainExportCommand{
+				Meta: meta,
+			}, nil
+		},
+		"chain import": func() (MarkDownCommand, error) {
+			return &ChainImportCommand{
+				Meta: meta,
+			}, nil
+		},
 		"account": func() (MarkDownCommand, error) {
 			return &Account{
 				UI: ui,
@@ -163,6 +183,16 @@ func Commands() map[string]MarkDownCommandFactory {
 				Meta2: meta2,
 			}, nil
 		},
+		"peers ban": func() (MarkDownCommand, error) {
+			return &PeersBanCommand{
+				Meta2: meta2,
+			}, nil
+		},
+		"peers unban": func() (MarkDownCommand, error) {
+			return &PeersUnbanCommand{
+				Meta2: meta2,
+			}, nil
+		},
 		"status": func() (MarkDownCommand, error) {
 			return &StatusCommand{
 				Meta2: meta2,
@@ -185,6 +215,56 @@ func Commands() map[string]MarkDownCommandFactory {
 				UI: ui,
 			}, nil
 		},
+		"devnet": func() (MarkDownCommand, error) {
+			return &DevnetCommand{
+				UI: ui,
+			}, nil
+		},
+		"sim": func() (MarkDownCommand, error) {
+			return &SimCommand{
+				UI: ui,
+			}, nil
+		},
+		"sim producers": func() (MarkDownCommand, error) {
+			return &SimProducersCommand{
+				UI: ui,
+			}, nil
+		},
+		"fork": func() (MarkDownCommand, error) {
+			return &ForkCommand{
+				UI: ui,
+			}, nil
+		},
+		"fork prepare-alloc": func() (MarkDownCommand, error) {
+			return &ForkPrepareAllocCommand{
+				Meta: meta,
+			}, nil
+		},
+		"protection": func() (MarkDownCommand, error) {
+			return &ProtectionCommand{
+				UI: ui,
+			}, nil
+		},
+		"protection export": func() (MarkDownCommand, error) {
+			return &ProtectionExportCommand{
+				Meta: meta,
+			}, nil
+		},
+		"protection import": func() (MarkDownCommand, error) {
+			return &ProtectionImportCommand{
+				Meta: meta,
+			}, nil
+		},
+		"db": func() (MarkDownCommand, error) {
+			return &DBCommand{
+				UI: ui,
+			}, nil
+		},
+		"db serve": func() (MarkDownCommand, error) {
+			return &DBServeCommand{
+				Meta: meta,
+			}, nil
+		},
 		"removedb": func() (MarkDownCommand, error) {
 			return &RemoveDBCommand{
 				Meta2: meta2,
@@ -0,0 +1,210 @@
+// Database related commands
+
+package cli
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/ethdb/remotedb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+
+	"github.com/mitchellh/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// DBCommand is the command to group the database commands
+type DBCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DBCommand) MarkDown() string {
+	items := []string{
+		"# DB",
+		"The ```db``` command groups database related actions:",
+		"- [```db serve```](./db_serve.md): Serve the database of a node read-only over gRPC.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DBCommand) Help() string {
+	return `Usage: bor db <subcommand>
+
+  This command groups database related actions.
+
+  Serve the database read-only:
+
+    $ bor db serve`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DBCommand) Synopsis() string {
+	return "Database related commands"
+}
+
+// Run implements the cli.Command interface
+func (c *DBCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// DBServeCommand is the command to serve a database read-only over gRPC
+type DBServeCommand struct {
+	*Meta
+
+	datadirAncient string
+	addr           string
+	tlsCert        string
+	tlsKey         string
+	tlsCA          string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DBServeCommand) MarkDown() string {
+	items := []string{
+		"# DB serve",
+		"The ```db serve``` command serves the key-value store and the ancients of a node read-only over gRPC. Concurrent key lookups are batched together and iterations are streamed in chunks. Nodes run RPC-only over it with ```bor server --db.remote <addr>```, and the read-only tools, ```chain export```, ```fork prepare-alloc``` and ```debug replay-build```, read it instead of the local database with ```--db.remote <addr>```. The database is opened read-only, it can be served while the node owning it is stopped, or from a copy of it. Without TLS it is served unauthenticated in plain text, only on loopback addresses. With ```--tls.cert``` and ```--tls.key``` it is served over TLS, and with ```--tls.ca``` only to the clients presenting a certificate issued by that authority, configured with ```--db.remote.cert``` and ```--db.remote.key```.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DBServeCommand) Help() string {
+	return `Usage: bor db serve
+
+  This command serves the database read-only over gRPC` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DBServeCommand) Synopsis() string {
+	return "Serve the database read-only over gRPC"
+}
+
+func (c *DBServeCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("db serve")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "datadir.ancient",
+		Value:   &c.datadirAncient,
+		Usage:   "Path of the ancient data directory to store information",
+		Default: "",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "addr",
+		Value:   &c.addr,
+		Usage:   "Address and port to serve the database on",
+		Default: "127.0.0.1:3133",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "tls.cert",
+		Value:   &c.tlsCert,
+		Usage:   "Certificate to serve the database over TLS with, required for non-loopback addresses",
+		Default: "",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "tls.key",
+		Value:   &c.tlsKey,
+		Usage:   "Key of the TLS certificate",
+		Default: "",
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "tls.ca",
+		Value:   &c.tlsCA,
+		Usage:   "Certificate authority the clients must present a certificate of, any client is served if empty",
+		Default: "",
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *DBServeCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	// The database is served unauthenticated in plain text without TLS, only
+	// allow it on the local host then
+	var options []grpc.ServerOption
+
+	if c.tlsCert != "" || c.tlsKey != "" || c.tlsCA != "" {
+		config, err := remotedb.ServerTLSConfig(c.tlsCert, c.tlsKey, c.tlsCA)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		options = append(options, grpc.Creds(credentials.NewTLS(config)))
+	} else if !isLoopbackAddr(c.addr) {
+		c.UI.Error(fmt.Sprintf("Refusing to serve the database on non-loopback address %s without TLS", c.addr))
+		return 1
+	}
+
+	stack, db, err := openChainDB(c.dataDir, c.datadirAncient, true)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer stack.Close()
+
+	lis, err := net.Listen("tcp", c.addr)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	srv := grpc.NewServer(options...)
+	remotedb.NewServer(db).Register(srv)
+
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			c.UI.Error(fmt.Sprintf("Failed to serve the database: %v", err))
+		}
+	}()
+
+	c.UI.Output(fmt.Sprintf("Serving the database on %s", lis.Addr()))
+
+	signalCh := make(chan os.Signal, 4)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	sig := <-signalCh
+
+	c.UI.Output(fmt.Sprintf("Caught signal: %v", sig))
+
+	// Streaming iterations can run for long, don't wait for them
+	srv.Stop()
+
+	return 0
+}
+
+// isLoopbackAddr reports whether the given listening address is restricted to
+// the local host.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
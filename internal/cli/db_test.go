@@ -0,0 +1,24 @@
+package cli
+
+import "testing"
+
+func TestIsLoopbackAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"127.0.0.1:3133": true,
+		"localhost:3133": true,
+		"[::1]:3133":     true,
+		":3133":          false,
+		"0.0.0.0:3133":   false,
+		"10.0.0.1:3133":  false,
+		"example.com:80": false,
+		"127.0.0.1":      false,
+	}
+
+	for addr, want := range tests {
+		if have := isLoopbackAddr(addr); have != want {
+			t.Errorf("%s: loopback mismatch: have %v, want %v", addr, have, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/miner"
+)
+
+// DebugReplayBuildCommand is the command to replay a recorded block building
+type DebugReplayBuildCommand struct {
+	*Meta
+
+	datadirAncient string
+	remoteDB       server.RemoteDBConfig
+	tx             string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *DebugReplayBuildCommand) MarkDown() string {
+	items := []string{
+		"# Debug replay-build",
+		"The ```debug replay-build``` command replays offline the building of a block sealed by the local validator, as recorded with ```--miner.recordbuilds``` in the ```buildrecords``` directory of the datadir. " +
+			"The pending transactions of the pool at the time of the building are applied again on top of the state of the parent block, in the order of the worker, and the commit interrupts are reproduced where the worker hit them.",
+		"Every transaction is listed with its outcome and the reason of its exclusion: failed, nonce too low, fee cap below the base fee, interrupted at the commit deadline, or never reached because the block was full, the building was interrupted or an earlier transaction of the sender was excluded. " +
+			"An outcome different from the one recorded by the worker is reported next to the replayed one. The node must be stopped, or the command run on a copy of the datadir.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *DebugReplayBuildCommand) Help() string {
+	return `Usage: bor debug replay-build <record>
+
+  Replay a recorded block building and explain the inclusion or exclusion of each transaction.
+
+    $ bor debug replay-build --datadir /var/lib/bor /var/lib/bor/bor/buildrecords/0041234567-0a1b2c3d4e5f6a7b.json
+
+  ` + c.Flags().Help()
+}
+
+func (c *DebugReplayBuildCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("debug replay-build")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "datadir.ancient",
+		Value: &c.datadirAncient,
+		Usage: "Path of the ancient data directory to store information",
+	})
+	remoteDBFlags(flags, &c.remoteDB)
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "tx",
+		Value: &c.tx,
+		Usage: "Hash of the only transaction to explain",
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DebugReplayBuildCommand) Synopsis() string {
+	return "Replay a recorded block building"
+}
+
+// Run implements the cli.Command interface
+func (c *DebugReplayBuildCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("Expected one argument")
+		return 1
+	}
+
+	record, err := miner.ReadBuildRecord(args[0])
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	closer, db, err := openReadOnlyChainDB(c.dataDir, c.datadirAncient, &c.remoteDB)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer closer.Close()
+
+	parent := rawdb.ReadHeader(db, record.ParentHash, record.Number-1)
+	if parent == nil {
+		c.UI.Error(fmt.Sprintf("Parent block %s of block #%d not found", record.ParentHash, record.Number))
+		return 1
+	}
+
+	config, statedb, err := openStateAt(db, parent)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	report, err := miner.ReplayBuild(&dbChainContext{db}, config, statedb, record)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to replay the building: %v", err))
+		return 1
+	}
+
+	canonical := rawdb.ReadCanonicalHash(db, record.Number) == record.Hash
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Block|#%d", record.Number),
+		fmt.Sprintf("Hash|%s", record.Hash),
+		fmt.Sprintf("Canonical|%t", canonical),
+		fmt.Sprintf("Started|%s", record.Started.Format(time.RFC3339Nano)),
+		fmt.Sprintf("Filling|%s", common.PrettyDuration(record.Filled.Sub(record.Started))),
+		fmt.Sprintf("Sealed after|%s", common.PrettyDuration(record.Sealed.Sub(record.Started))),
+		fmt.Sprintf("Presealed empty block|%t", record.Presealed),
+		fmt.Sprintf("Interrupt|%s", record.Interrupt),
+		fmt.Sprintf("Pending|%d", len(record.Pending)),
+		fmt.Sprintf("Included|%d", len(report.Included)),
+		fmt.Sprintf("Gas used|%d / %d", report.GasUsed, record.Header.GasLimit),
+		fmt.Sprintf("Divergent|%d", report.Divergent),
+	}))
+
+	phases := []string{"Phase|Tried|Exit"}
+	for _, phase := range record.Phases {
+		phases = append(phases, fmt.Sprintf("%s|%d|%s", phase.Name, len(phase.Steps), phase.Exit))
+	}
+
+	c.UI.Output("")
+	c.UI.Output(formatList(phases))
+
+	txs := []string{"Hash|From|Nonce|Phase|Outcome|Reason"}
+
+	for _, tx := range report.Txs {
+		if c.tx != "" && tx.Hash != common.HexToHash(c.tx) {
+			continue
+		}
+
+		outcome := tx.Outcome
+		if tx.Recorded != "" {
+			outcome = fmt.Sprintf("%s (recorded %s)", tx.Outcome, tx.Recorded)
+		}
+
+		txs = append(txs, fmt.Sprintf("%s|%s|%d|%s|%s|%s", tx.Hash, tx.From, tx.Nonce, tx.Phase, outcome, tx.Reason))
+	}
+
+	c.UI.Output("")
+	c.UI.Output(formatList(txs))
+
+	return 0
+}
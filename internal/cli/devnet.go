@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/internal/devnet"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/mitchellh/cli"
+)
+
+// DevnetCommand is the command to run a local network of Bor validators
+type DevnetCommand struct {
+	UI cli.Ui
+
+	validators        int
+	period            uint64
+	gasLimit          uint64
+	milestoneInterval uint64
+	verbosity         int
+}
+
+// MarkDown implements cli.MarkDown interface
+func (d *DevnetCommand) MarkDown() string {
+	items := []string{
+		"# Devnet",
+		"The ```devnet``` command runs a local network of Bor validators in a single process, connected over simulated p2p and sharing an in-process Heimdall.",
+		d.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (d *DevnetCommand) Help() string {
+	return `Usage: bor devnet
+
+  Run a local network of Bor validators in a single process`
+}
+
+// Synopsis implements the cli.Command interface
+func (d *DevnetCommand) Synopsis() string {
+	return "Run a local network of Bor validators"
+}
+
+func (d *DevnetCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("devnet")
+
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "validators",
+		Usage:   "Number of validators of the network",
+		Value:   &d.validators,
+		Default: devnet.DefaultConfig.Validators,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "period",
+		Usage:   "Block period, in seconds",
+		Value:   &d.period,
+		Default: devnet.DefaultConfig.Period,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "gas-limit",
+		Usage:   "Gas limit of the genesis block",
+		Value:   &d.gasLimit,
+		Default: devnet.DefaultConfig.GasLimit,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "milestone-interval",
+		Usage:   "Number of blocks between two milestones, 0 to disable milestones",
+		Value:   &d.milestoneInterval,
+		Default: 16,
+	})
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "verbosity",
+		Usage:   "Logging verbosity (5=trace|4=debug|3=info|2=warn|1=error|0=crit)",
+		Value:   &d.verbosity,
+		Default: 3,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (d *DevnetCommand) Run(args []string) int {
+	flags := d.Flags()
+	if err := flags.Parse(args); err != nil {
+		d.UI.Error(err.Error())
+		return 1
+	}
+
+	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+
+	lvl, err := log.LvlFromString(server.VerbosityIntToString(d.verbosity))
+	if err != nil {
+		lvl = log.LvlInfo
+	}
+
+	glogger.Verbosity(lvl)
+	log.Root().SetHandler(glogger)
+
+	network, err := devnet.New(devnet.Config{
+		Validators: d.validators,
+		Period:     d.period,
+		GasLimit:   d.gasLimit,
+	})
+	if err != nil {
+		d.UI.Error(fmt.Sprintf("Failed to create devnet: %v", err))
+		return 1
+	}
+
+	if err := network.Start(); err != nil {
+		network.Stop()
+		d.UI.Error(fmt.Sprintf("Failed to start devnet: %v", err))
+
+		return 1
+	}
+
+	defer network.Stop()
+
+	for _, v := range network.Validators {
+		d.UI.Output(fmt.Sprintf("Validator %d: %s", v.Index, v.Address))
+	}
+
+	signalCh := make(chan os.Signal, 4)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	ticker := time.NewTicker(time.Duration(network.Genesis.Config.Bor.CalculatePeriod(0)) * time.Second)
+	defer ticker.Stop()
+
+	var milestoneEnd uint64
+
+	for {
+		select {
+		case <-ticker.C:
+			if d.milestoneInterval == 0 {
+				continue
+			}
+
+			// Propose a milestone from the chain of the first validator
+			if head := network.Validators[0].Head(); head >= milestoneEnd+d.milestoneInterval {
+				if _, err := network.Milestone(0, head); err != nil {
+					log.Warn("Failed to add devnet milestone", "err", err)
+					continue
+				}
+
+				milestoneEnd = head
+			}
+		case sig := <-signalCh:
+			d.UI.Output(fmt.Sprintf("Caught signal: %v", sig))
+			d.UI.Output("Gracefully shutting down devnet...")
+
+			return 0
+		}
+	}
+}
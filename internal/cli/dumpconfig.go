@@ -1,12 +1,14 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
 	"github.com/BurntSushi/toml"
 
 	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
 )
 
 // DumpconfigCommand is for exporting user provided flags into a config file
@@ -18,7 +20,7 @@ type DumpconfigCommand struct {
 func (p *DumpconfigCommand) MarkDown() string {
 	items := []string{
 		"# Dumpconfig",
-		"The ```bor dumpconfig <your-favourite-flags>``` command will export the user provided flags into a configuration file",
+		"The ```bor dumpconfig <your-favourite-flags>``` command will export the user provided flags into a configuration file. It fails if the Bor config of the selected chain is invalid.",
 	}
 
 	return strings.Join(items, "\n\n")
@@ -51,6 +53,18 @@ func (c *DumpconfigCommand) Run(args []string) int {
 
 	userConfig := command.GetConfig()
 
+	// validate the consensus parameters of the chain the config runs
+	chain, err := chains.GetChain(userConfig.Chain)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if err := chain.Genesis.Config.CheckBorConfig(); err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid Bor config of chain %s:\n%v", userConfig.Chain, err))
+		return 1
+	}
+
 	// convert the big.Int and time.Duration fields to their corresponding Raw fields
 	userConfig.JsonRPC.RPCEVMTimeoutRaw = userConfig.JsonRPC.RPCEVMTimeout.String()
 	userConfig.JsonRPC.HttpTimeout.ReadTimeoutRaw = userConfig.JsonRPC.HttpTimeout.ReadTimeout.String()
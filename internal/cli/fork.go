@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// ForkCommand is the command to group the fork preparation commands
+type ForkCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ForkCommand) MarkDown() string {
+	items := []string{
+		"# Fork",
+		"The ```fork``` command groups the tools preparing the Bor forks:",
+		"- [```fork prepare-alloc```](./fork_prepare-alloc.md): Prepare the block alloc entry upgrading a system contract.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ForkCommand) Help() string {
+	return `Usage: bor fork <subcommand>
+
+  This command groups the tools preparing the Bor forks.
+
+  Prepare the block alloc entry upgrading a system contract:
+
+    $ bor fork prepare-alloc --address 0x0000000000000000000000000000000000001000 --block 50000000 --code ValidatorSet.json`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ForkCommand) Synopsis() string {
+	return "Prepare the Bor forks"
+}
+
+// Run implements the cli.Command interface
+func (c *ForkCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,837 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/asm"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/trie/triedb/pathdb"
+)
+
+// smokeCallGas is the gas given to the smoke-test calls without an explicit limit
+const smokeCallGas = 50_000_000
+
+// ForkPrepareAllocCommand is the command to prepare the block alloc entry
+// upgrading a system contract
+type ForkPrepareAllocCommand struct {
+	*Meta
+
+	datadirAncient string
+	remoteDB       server.RemoteDBConfig
+	address        string
+	block          uint64
+	at             uint64
+	code           string
+	source         string
+	name           string
+	solc           string
+	layout         string
+	calls          string
+	out            string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ForkPrepareAllocCommand) MarkDown() string {
+	items := []string{
+		"# Fork prepare-alloc",
+		"The ```fork prepare-alloc``` command prepares the ```blockAlloc``` entry of the Bor config replacing the code of a system contract at a fork block. It reads the new runtime code from a file, either as hex or as a compiler artifact, or compiles it with ```solc```, and compares it with the code of the contract in the local state of a stopped node.",
+		"The storage layout of the new code is checked against the one of the current code, given as a compiler artifact or a layout JSON. The variables of the current layout must keep their slot, offset and type, and the new ones must be appended after them.",
+		"The smoke-test calls, listed in a JSON file as objects with the ```name```, ```from```, ```to``` (defaults to the contract), ```data```, ```gas```, ```value```, ```expect``` and ```revert``` fields, are run in order on a copy of the local state, before and after the code is replaced. The command fails if any of them reverts unexpectedly or doesn't return the expected output once upgraded.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ForkPrepareAllocCommand) Help() string {
+	return `Usage: bor fork prepare-alloc --address <address> --block <number> [--code <file> | --source <file>]
+
+  This command prepares the block alloc entry upgrading a system contract` + c.Flags().Help()
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ForkPrepareAllocCommand) Synopsis() string {
+	return "Prepare the block alloc entry upgrading a system contract"
+}
+
+func (c *ForkPrepareAllocCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("fork prepare-alloc")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "datadir.ancient",
+		Value: &c.datadirAncient,
+		Usage: "Path of the ancient data directory to store information",
+	})
+	remoteDBFlags(flags, &c.remoteDB)
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "address",
+		Usage: "Address of the system contract to upgrade",
+		Value: &c.address,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "block",
+		Usage: "Block the new code is activated at",
+		Value: &c.block,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "at",
+		Usage: "Block to read the current code and state at (0 = current head)",
+		Value: &c.at,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "code",
+		Usage: "File holding the new runtime code, as hex or as a compiler artifact",
+		Value: &c.code,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "source",
+		Usage: "Solidity file to compile the new code from, instead of loading it",
+		Value: &c.source,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "name",
+		Usage: "Name of the contract to compile, if the source defines several",
+		Value: &c.name,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "solc",
+		Usage:   "Solidity compiler to compile the source with",
+		Default: "solc",
+		Value:   &c.solc,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "layout",
+		Usage: "Storage layout of the current code, as a compiler artifact or a layout JSON",
+		Value: &c.layout,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "calls",
+		Usage: "JSON file listing the smoke-test calls to run on a copy of the local state",
+		Value: &c.calls,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "out",
+		Usage: "File to write the block alloc entry to, instead of the standard output",
+		Value: &c.out,
+	})
+
+	return flags
+}
+
+// Run implements the cli.Command interface
+func (c *ForkPrepareAllocCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if !common.IsHexAddress(c.address) {
+		c.UI.Error(fmt.Sprintf("Invalid contract address %q", c.address))
+		return 1
+	}
+
+	address := common.HexToAddress(c.address)
+
+	if c.block == 0 {
+		c.UI.Error("The fork block is required")
+		return 1
+	}
+
+	if (c.code == "") == (c.source == "") {
+		c.UI.Error("Exactly one of the code file or the source file is required")
+		return 1
+	}
+
+	// Load or compile the new code
+	var (
+		upgrade *contractArtifact
+		err     error
+	)
+
+	if c.code != "" {
+		upgrade, err = loadArtifact(c.code)
+	} else {
+		upgrade, err = compileContract(c.solc, c.source, c.name)
+	}
+
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if len(upgrade.Code) == 0 {
+		c.UI.Error("The new runtime code is empty")
+		return 1
+	}
+
+	closer, db, err := openReadOnlyChainDB(c.dataDir, c.datadirAncient, &c.remoteDB)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer closer.Close()
+
+	header, config, statedb, err := openState(db, c.at)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.block <= header.Number.Uint64() {
+		c.UI.Error(fmt.Sprintf("The fork block %d must be after block %d the code is read at", c.block, header.Number.Uint64()))
+		return 1
+	}
+
+	if err := checkBlockAlloc(config, c.block, address); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	diff := diffCode(statedb.GetCode(address), upgrade.Code)
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Contract|%s", address),
+		fmt.Sprintf("Read at block|%d", header.Number.Uint64()),
+		fmt.Sprintf("Current code|%d bytes, hash %s", diff.OldSize, diff.OldHash),
+		fmt.Sprintf("New code|%d bytes, hash %s", diff.NewSize, diff.NewHash),
+		fmt.Sprintf("Metadata only|%t", diff.MetadataOnly),
+		fmt.Sprintf("Added selectors|%s", strings.Join(diff.Added, ",")),
+		fmt.Sprintf("Removed selectors|%s", strings.Join(diff.Removed, ",")),
+	}))
+	c.UI.Output("")
+
+	if diff.OldHash == diff.NewHash {
+		c.UI.Error("The new code is identical to the current one")
+		return 1
+	}
+
+	// Check the storage layouts, if both are known
+	switch {
+	case c.layout == "":
+		c.UI.Warn("Storage layout not checked, the layout of the current code is not given")
+	case upgrade.Layout == nil:
+		c.UI.Warn("Storage layout not checked, the layout of the new code is unknown")
+	default:
+		current, err := loadArtifact(c.layout)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		if current.Layout == nil {
+			c.UI.Error(fmt.Sprintf("No storage layout in %s", c.layout))
+			return 1
+		}
+
+		if problems := compareLayouts(current.Layout, upgrade.Layout); len(problems) > 0 {
+			c.UI.Error(fmt.Sprintf("Incompatible storage layouts:\n%s", strings.Join(problems, "\n")))
+			return 1
+		}
+
+		c.UI.Output("Storage layouts are compatible")
+		c.UI.Output("")
+	}
+
+	// Dry run the smoke-test calls on a copy of the local state
+	if c.calls != "" {
+		calls, err := loadSmokeCalls(c.calls)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		results := runSmokeCalls(&dbChainContext{db}, config, header, statedb, address, upgrade.Code, calls)
+
+		rows := []string{"Call|Before|After|Result"}
+		failed := false
+
+		for _, r := range results {
+			rows = append(rows, fmt.Sprintf("%s|%s|%s|%s", r.Name, r.Before, r.After, r.Result()))
+			failed = failed || r.Err != nil
+		}
+
+		c.UI.Output(formatList(rows))
+		c.UI.Output("")
+
+		if failed {
+			c.UI.Error("Smoke-test calls failed")
+			return 1
+		}
+	}
+
+	entry, err := json.MarshalIndent(blockAllocEntry(c.block, address, upgrade.Code), "", "  ")
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.out == "" {
+		c.UI.Output(string(entry))
+		return 0
+	}
+
+	if err := os.WriteFile(c.out, append(entry, '\n'), 0600); err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to write block alloc entry: %v", err))
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Block alloc entry written to %s", c.out))
+
+	return 0
+}
+
+// contractArtifact is the runtime code of a contract and its storage layout, if known.
+type contractArtifact struct {
+	Code   []byte
+	Layout *storageLayout
+}
+
+// storageLayout is the storage layout of a contract, as output by solc.
+type storageLayout struct {
+	Storage []storageVariable      `json:"storage"`
+	Types   map[string]storageType `json:"types"`
+}
+
+type storageVariable struct {
+	Label  string `json:"label"`
+	Offset uint64 `json:"offset"`
+	Slot   string `json:"slot"`
+	Type   string `json:"type"`
+}
+
+type storageType struct {
+	Label         string `json:"label"`
+	NumberOfBytes string `json:"numberOfBytes"`
+}
+
+// loadArtifact loads a contract from a file holding its runtime code as hex,
+// a compiler artifact or a bare storage layout.
+func loadArtifact(path string) (*contractArtifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	data = bytes.TrimSpace(data)
+
+	if len(data) == 0 || data[0] != '{' {
+		code, err := hex.DecodeString(strings.TrimPrefix(string(data), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid code in %s: %v", path, err)
+		}
+
+		return &contractArtifact{Code: code}, nil
+	}
+
+	// The artifacts of solc, truffle, hardhat and foundry place the runtime
+	// code differently
+	var artifact struct {
+		DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+		BinRuntime       string          `json:"bin-runtime"`
+		EVM              struct {
+			DeployedBytecode struct {
+				Object string `json:"object"`
+			} `json:"deployedBytecode"`
+		} `json:"evm"`
+		StorageLayout *storageLayout  `json:"storageLayout"`
+		Storage       json.RawMessage `json:"storage"`
+	}
+
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return nil, fmt.Errorf("invalid artifact %s: %v", path, err)
+	}
+
+	result := &contractArtifact{Layout: artifact.StorageLayout}
+
+	if artifact.Storage != nil {
+		result.Layout = new(storageLayout)
+		if err := json.Unmarshal(data, result.Layout); err != nil {
+			return nil, fmt.Errorf("invalid storage layout %s: %v", path, err)
+		}
+	}
+
+	code := artifact.EVM.DeployedBytecode.Object
+	if code == "" {
+		code = artifact.BinRuntime
+	}
+
+	if len(artifact.DeployedBytecode) > 0 {
+		var object struct {
+			Object string `json:"object"`
+		}
+
+		if err := json.Unmarshal(artifact.DeployedBytecode, &code); err != nil {
+			if err := json.Unmarshal(artifact.DeployedBytecode, &object); err != nil {
+				return nil, fmt.Errorf("invalid deployed bytecode in %s: %v", path, err)
+			}
+
+			code = object.Object
+		}
+	}
+
+	if result.Code, err = hex.DecodeString(strings.TrimPrefix(code, "0x")); err != nil {
+		return nil, fmt.Errorf("invalid code in %s: %v", path, err)
+	}
+
+	return result, nil
+}
+
+// compileContract compiles the given contract of the source file with solc,
+// returning its runtime code and storage layout.
+func compileContract(solc string, source string, name string) (*contractArtifact, error) {
+	cmd := exec.Command(solc, "--combined-json", "bin-runtime,storage-layout", source)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile %s: %v\n%s", source, err, stderr.String())
+	}
+
+	var output struct {
+		Contracts map[string]struct {
+			BinRuntime    string          `json:"bin-runtime"`
+			StorageLayout json.RawMessage `json:"storage-layout"`
+		} `json:"contracts"`
+	}
+
+	if err := json.Unmarshal(out, &output); err != nil {
+		return nil, fmt.Errorf("invalid compiler output: %v", err)
+	}
+
+	var matches []string
+
+	for key := range output.Contracts {
+		if name == "" || key[strings.LastIndex(key, ":")+1:] == name {
+			matches = append(matches, key)
+		}
+	}
+
+	if len(matches) != 1 {
+		sort.Strings(matches)
+		return nil, fmt.Errorf("expected one contract named %q in %s, found %d: %s", name, source, len(matches), strings.Join(matches, ", "))
+	}
+
+	contract := output.Contracts[matches[0]]
+
+	code, err := hex.DecodeString(contract.BinRuntime)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compiled code: %v", err)
+	}
+
+	result := &contractArtifact{Code: code}
+
+	// The older compilers encode the nested outputs as strings
+	layout := contract.StorageLayout
+
+	var encoded string
+	if err := json.Unmarshal(layout, &encoded); err == nil {
+		layout = []byte(encoded)
+	}
+
+	if len(layout) > 0 {
+		result.Layout = new(storageLayout)
+		if err := json.Unmarshal(layout, result.Layout); err != nil {
+			return nil, fmt.Errorf("invalid compiled storage layout: %v", err)
+		}
+	}
+
+	return result, nil
+}
+
+// openState opens the state of the given block, or of the head block if zero,
+// along with the stored chain config.
+func openState(db ethdb.Database, number uint64) (*types.Header, *params.ChainConfig, *state.StateDB, error) {
+	if number == 0 {
+		head := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadBlockHash(db))
+		if head == nil {
+			return nil, nil, nil, errors.New("head block not found")
+		}
+
+		number = *head
+	}
+
+	header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, number), number)
+	if header == nil {
+		return nil, nil, nil, fmt.Errorf("block #%d not found", number)
+	}
+
+	config, statedb, err := openStateAt(db, header)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return header, config, statedb, nil
+}
+
+// openStateAt reads the chain config and the state of the given block.
+func openStateAt(db ethdb.Database, header *types.Header) (*params.ChainConfig, *state.StateDB, error) {
+	config := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+	if config == nil {
+		return nil, nil, errors.New("chain config not found")
+	}
+
+	trieConfig := &trie.Config{}
+	if rawdb.ReadStateScheme(db) == rawdb.PathScheme {
+		trieConfig.PathDB = &pathdb.Config{ReadOnly: true}
+	}
+
+	statedb, err := state.New(header.Root, state.NewDatabaseWithConfig(db, trieConfig), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("state of block #%d not available, it may be pruned: %v", header.Number, err)
+	}
+
+	return config, statedb, nil
+}
+
+// checkBlockAlloc checks that the chain doesn't already replace the code of
+// the contract at the fork block.
+func checkBlockAlloc(config *params.ChainConfig, block uint64, address common.Address) error {
+	if config.Bor == nil {
+		return errors.New("the chain has no Bor config")
+	}
+
+	for key, alloc := range config.Bor.BlockAlloc {
+		number, err := strconv.ParseUint(key, 10, 64)
+		if err != nil || number != block {
+			continue
+		}
+
+		data, err := json.Marshal(alloc)
+		if err != nil {
+			return err
+		}
+
+		var existing core.GenesisAlloc
+		if err := json.Unmarshal(data, &existing); err != nil {
+			return fmt.Errorf("invalid block alloc at block %d: %v", block, err)
+		}
+
+		if _, ok := existing[address]; ok {
+			return fmt.Errorf("the block alloc at block %d already replaces the code of %s", block, address)
+		}
+	}
+
+	return nil
+}
+
+// codeDiff is the comparison of the current code of a contract with its new code.
+type codeDiff struct {
+	OldHash, NewHash common.Hash
+	OldSize, NewSize int
+
+	// MetadataOnly is set if the codes only differ by their metadata hash
+	MetadataOnly bool
+
+	// Added and Removed are the function selectors dispatched by one code only
+	Added, Removed []string
+}
+
+func diffCode(current []byte, upgrade []byte) *codeDiff {
+	diff := &codeDiff{
+		OldHash: crypto.Keccak256Hash(current),
+		NewHash: crypto.Keccak256Hash(upgrade),
+		OldSize: len(current),
+		NewSize: len(upgrade),
+	}
+
+	diff.MetadataOnly = diff.OldHash != diff.NewHash && bytes.Equal(stripMetadata(current), stripMetadata(upgrade))
+
+	oldSelectors, newSelectors := selectors(current), selectors(upgrade)
+
+	for s := range newSelectors {
+		if !oldSelectors[s] {
+			diff.Added = append(diff.Added, s)
+		}
+	}
+
+	for s := range oldSelectors {
+		if !newSelectors[s] {
+			diff.Removed = append(diff.Removed, s)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+
+	return diff
+}
+
+// stripMetadata removes the CBOR encoded metadata appended by solc to the code.
+func stripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+
+	n := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	if n+2 > len(code) {
+		return code
+	}
+
+	// The metadata is a CBOR map, of up to 23 entries
+	if start := len(code) - 2 - n; code[start]&0xe0 == 0xa0 {
+		return code[:start]
+	}
+
+	return code
+}
+
+// selectors returns the function selectors compared against by the dispatcher
+// of the code, pushed right before an EQ or a DUP and an EQ.
+func selectors(code []byte) map[string]bool {
+	var ops []vm.OpCode
+
+	var args [][]byte
+
+	for it := asm.NewInstructionIterator(code); it.Next(); {
+		ops = append(ops, it.Op())
+		args = append(args, it.Arg())
+	}
+
+	found := make(map[string]bool)
+
+	for i, op := range ops {
+		if op != vm.PUSH4 || i+1 >= len(ops) {
+			continue
+		}
+
+		next := ops[i+1]
+		if next == vm.EQ || (next >= vm.DUP1 && next <= vm.DUP16 && i+2 < len(ops) && ops[i+2] == vm.EQ) {
+			found[hexutil.Encode(args[i])] = true
+		}
+	}
+
+	return found
+}
+
+// compareLayouts checks that the new storage layout keeps the variables of the
+// current one in place, and only appends variables after them.
+func compareLayouts(current *storageLayout, upgrade *storageLayout) []string {
+	var problems []string
+
+	type position struct {
+		slot   string
+		offset uint64
+	}
+
+	describe := func(layout *storageLayout, v storageVariable) (string, uint64) {
+		t := layout.Types[v.Type]
+		size, _ := strconv.ParseUint(t.NumberOfBytes, 10, 64)
+
+		return fmt.Sprintf("%s %s", t.Label, v.Label), size
+	}
+
+	end := new(big.Int)
+	upgraded := make(map[position]storageVariable, len(upgrade.Storage))
+
+	for _, v := range upgrade.Storage {
+		upgraded[position{v.Slot, v.Offset}] = v
+	}
+
+	for _, v := range current.Storage {
+		old, oldSize := describe(current, v)
+
+		slot, ok := new(big.Int).SetString(v.Slot, 10)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("invalid slot %q of %s", v.Slot, old))
+			continue
+		}
+
+		if e := new(big.Int).Add(new(big.Int).Lsh(slot, 5), new(big.Int).SetUint64(v.Offset+oldSize)); e.Cmp(end) > 0 {
+			end = e
+		}
+
+		n, ok := upgraded[position{v.Slot, v.Offset}]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("slot %s offset %d: %s removed", v.Slot, v.Offset, old))
+			continue
+		}
+
+		if updated, size := describe(upgrade, n); current.Types[v.Type].Label != upgrade.Types[n.Type].Label || size != oldSize {
+			problems = append(problems, fmt.Sprintf("slot %s offset %d: %s replaced by %s", v.Slot, v.Offset, old, updated))
+		}
+
+		delete(upgraded, position{v.Slot, v.Offset})
+	}
+
+	// The remaining variables are new, and must not overlap the current ones
+	for _, v := range upgrade.Storage {
+		if _, ok := upgraded[position{v.Slot, v.Offset}]; !ok {
+			continue
+		}
+
+		slot, ok := new(big.Int).SetString(v.Slot, 10)
+		if !ok {
+			continue
+		}
+
+		if start := new(big.Int).Add(new(big.Int).Lsh(slot, 5), new(big.Int).SetUint64(v.Offset)); start.Cmp(end) < 0 {
+			label, _ := describe(upgrade, v)
+			problems = append(problems, fmt.Sprintf("slot %s offset %d: %s inserted before the end of the current storage", v.Slot, v.Offset, label))
+		}
+	}
+
+	return problems
+}
+
+// smokeCall is a call run on the state before and after the upgrade.
+type smokeCall struct {
+	Name   string          `json:"name"`
+	From   common.Address  `json:"from"`
+	To     *common.Address `json:"to"` // Defaults to the upgraded contract
+	Data   hexutil.Bytes   `json:"data"`
+	Gas    uint64          `json:"gas"`
+	Value  *hexutil.Big    `json:"value"`
+	Expect *hexutil.Bytes  `json:"expect"`
+	Revert bool            `json:"revert"`
+}
+
+// smokeResult is the outcome of a smoke-test call.
+type smokeResult struct {
+	Name          string
+	Before, After string
+	Err           error
+}
+
+// Result describes the outcome of the call after the upgrade.
+func (r *smokeResult) Result() string {
+	if r.Err != nil {
+		return fmt.Sprintf("FAIL: %v", r.Err)
+	}
+
+	return "ok"
+}
+
+func loadSmokeCalls(path string) ([]*smokeCall, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read calls file: %v", err)
+	}
+
+	var calls []*smokeCall
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("failed to parse calls file: %v", err)
+	}
+
+	return calls, nil
+}
+
+// runSmokeCalls runs the calls in order on two copies of the state, one of them
+// with the code of the contract replaced.
+func runSmokeCalls(chain core.ChainContext, config *params.ChainConfig, header *types.Header, statedb *state.StateDB, address common.Address, code []byte, calls []*smokeCall) []*smokeResult {
+	before, after := statedb.Copy(), statedb.Copy()
+	after.SetCode(address, code)
+
+	blockContext := core.NewEVMBlockContext(header, chain, &header.Coinbase)
+	results := make([]*smokeResult, 0, len(calls))
+
+	call := func(statedb *state.StateDB, c *smokeCall) ([]byte, error) {
+		to := address
+		if c.To != nil {
+			to = *c.To
+		}
+
+		gas := c.Gas
+		if gas == 0 {
+			gas = smokeCallGas
+		}
+
+		value := new(big.Int)
+		if c.Value != nil {
+			value = c.Value.ToInt()
+		}
+
+		evm := vm.NewEVM(blockContext, vm.TxContext{Origin: c.From, GasPrice: new(big.Int)}, statedb, config, vm.Config{NoBaseFee: true})
+		ret, _, err := evm.Call(vm.AccountRef(c.From), to, c.Data, gas, value, nil)
+		statedb.Finalise(true)
+
+		return ret, err
+	}
+
+	for i, c := range calls {
+		r := &smokeResult{Name: c.Name}
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("#%d", i)
+		}
+
+		ret, err := call(before, c)
+		r.Before = formatSmokeOutput(ret, err)
+
+		ret, err = call(after, c)
+		r.After = formatSmokeOutput(ret, err)
+
+		switch {
+		case err != nil && !c.Revert:
+			r.Err = fmt.Errorf("unexpected error: %v", err)
+		case err == nil && c.Revert:
+			r.Err = errors.New("expected to revert")
+		case err == nil && c.Expect != nil && !bytes.Equal(ret, *c.Expect):
+			r.Err = fmt.Errorf("expected %s", c.Expect)
+		}
+
+		results = append(results, r)
+	}
+
+	return results
+}
+
+func formatSmokeOutput(ret []byte, err error) string {
+	if err != nil {
+		return fmt.Sprintf("error: %v", err)
+	}
+
+	if len(ret) > 36 {
+		return fmt.Sprintf("%s... (%d bytes)", hexutil.Encode(ret[:32]), len(ret))
+	}
+
+	return hexutil.Encode(ret)
+}
+
+// blockAllocEntry returns the block alloc entry replacing the code of the
+// contract at the given block, in the format of the Bor config.
+func blockAllocEntry(block uint64, address common.Address, code []byte) map[string]core.GenesisAlloc {
+	return map[string]core.GenesisAlloc{
+		strconv.FormatUint(block, 10): {
+			address: {Balance: new(big.Int), Code: code},
+		},
+	}
+}
+
+// dbChainContext serves the headers of a database to the EVM.
+type dbChainContext struct {
+	db ethdb.Reader
+}
+
+func (c *dbChainContext) Engine() consensus.Engine {
+	return nil
+}
+
+func (c *dbChainContext) GetHeader(hash common.Hash, number uint64) *types.Header {
+	return rawdb.ReadHeader(c.db, hash, number)
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	// Codes returning 1 and 2, as a 32 bytes word
+	testOldCode = common.FromHex("0x600160005260206000f3")
+	testNewCode = common.FromHex("0x600260005260206000f3")
+
+	testLayout = `{"storage":[` +
+		`{"label":"owner","offset":0,"slot":"0","type":"t_address"},` +
+		`{"label":"count","offset":0,"slot":"1","type":"t_uint256"}],` +
+		`"types":{"t_address":{"label":"address","numberOfBytes":"20"},"t_uint256":{"label":"uint256","numberOfBytes":"32"}}}`
+)
+
+func TestLoadArtifact(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"hex":      "0x600260005260206000f3\n",
+		"hardhat":  `{"deployedBytecode":"0x600260005260206000f3"}`,
+		"foundry":  `{"deployedBytecode":{"object":"0x600260005260206000f3"},"storageLayout":` + testLayout + `}`,
+		"solc":     `{"evm":{"deployedBytecode":{"object":"600260005260206000f3"}}}`,
+		"combined": `{"bin-runtime":"600260005260206000f3"}`,
+	} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		artifact, err := loadArtifact(path)
+		require.NoError(t, err, name)
+		require.Equal(t, testNewCode, artifact.Code, name)
+		require.Equal(t, name == "foundry", artifact.Layout != nil, name)
+	}
+
+	// A bare storage layout has no code
+	path := filepath.Join(dir, "layout")
+	require.NoError(t, os.WriteFile(path, []byte(testLayout), 0600))
+
+	artifact, err := loadArtifact(path)
+	require.NoError(t, err)
+	require.Empty(t, artifact.Code)
+	require.Len(t, artifact.Layout.Storage, 2)
+	require.Equal(t, "uint256", artifact.Layout.Types["t_uint256"].Label)
+}
+
+func TestCompareLayouts(t *testing.T) {
+	t.Parallel()
+
+	parse := func(layout string) *storageLayout {
+		var l storageLayout
+		require.NoError(t, json.Unmarshal([]byte(layout), &l))
+
+		return &l
+	}
+
+	current := parse(testLayout)
+
+	// Appending a variable is compatible
+	require.Empty(t, compareLayouts(current, parse(`{"storage":[`+
+		`{"label":"owner","offset":0,"slot":"0","type":"t_address"},`+
+		`{"label":"count","offset":0,"slot":"1","type":"t_uint256"},`+
+		`{"label":"extra","offset":0,"slot":"2","type":"t_uint256"}],`+
+		`"types":{"t_address":{"label":"address","numberOfBytes":"20"},"t_uint256":{"label":"uint256","numberOfBytes":"32"}}}`)))
+
+	require.Equal(t, []string{
+		"slot 0 offset 0: address owner replaced by uint256 owner",
+		"slot 1 offset 0: uint256 count removed",
+		"slot 0 offset 20: bool paused inserted before the end of the current storage",
+	}, compareLayouts(current, parse(`{"storage":[`+
+		`{"label":"owner","offset":0,"slot":"0","type":"t_uint256"},`+
+		`{"label":"paused","offset":20,"slot":"0","type":"t_bool"}],`+
+		`"types":{"t_bool":{"label":"bool","numberOfBytes":"1"},"t_uint256":{"label":"uint256","numberOfBytes":"32"}}}`)))
+}
+
+func TestDiffCode(t *testing.T) {
+	t.Parallel()
+
+	// Dispatchers of owner() and of 0x12345678, then of owner() only
+	current := common.FromHex("0x80638da5cb5b1461001057631234567881146100105700")
+	upgrade := common.FromHex("0x80638da5cb5b146100105763abcdef0181146100105700")
+
+	diff := diffCode(current, upgrade)
+	require.False(t, diff.MetadataOnly)
+	require.Equal(t, []string{"0xabcdef01"}, diff.Added)
+	require.Equal(t, []string{"0x12345678"}, diff.Removed)
+
+	// Codes differing by their metadata only
+	diff = diffCode(append(testOldCode, 0xa1, 0x01, 0x00, 0x02), append(testOldCode, 0xa1, 0x02, 0x00, 0x02))
+	require.True(t, diff.MetadataOnly)
+	require.Empty(t, diff.Added)
+	require.Empty(t, diff.Removed)
+}
+
+func TestForkPrepareAllocDryRun(t *testing.T) {
+	t.Parallel()
+
+	contract := common.HexToAddress("0x0000000000000000000000000000000000001000")
+
+	config := *params.TestChainConfig
+	config.Bor = &params.BorConfig{
+		BurntContract: params.TestChainConfig.Bor.BurntContract,
+		BlockAlloc: map[string]interface{}{
+			"10": map[string]interface{}{
+				contract.Hex(): map[string]interface{}{"balance": "0x0", "code": "0x00"},
+			},
+		},
+	}
+
+	db := rawdb.NewMemoryDatabase()
+	gspec := &core.Genesis{Config: &config, Alloc: core.GenesisAlloc{contract: {Balance: new(big.Int), Code: testOldCode}}}
+	gspec.MustCommit(db)
+
+	header, stored, statedb, err := openState(db, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), header.Number.Uint64())
+	require.Equal(t, testOldCode, statedb.GetCode(contract))
+
+	require.ErrorContains(t, checkBlockAlloc(stored, 10, contract), "already replaces the code")
+	require.NoError(t, checkBlockAlloc(stored, 11, contract))
+
+	one, two := hexutil.Bytes(common.LeftPadBytes([]byte{1}, 32)), hexutil.Bytes(common.LeftPadBytes([]byte{2}, 32))
+	calls := []*smokeCall{
+		{Name: "upgraded", Expect: &two},
+		{Name: "unchanged", Expect: &one},
+		{Name: "revert", Revert: true},
+	}
+
+	results := runSmokeCalls(&dbChainContext{db}, stored, header, statedb, contract, testNewCode, calls)
+	require.Len(t, results, 3)
+
+	require.NoError(t, results[0].Err)
+	require.Equal(t, one.String(), results[0].Before)
+	require.Equal(t, two.String(), results[0].After)
+	require.ErrorContains(t, results[1].Err, "expected "+one.String())
+	require.ErrorContains(t, results[2].Err, "expected to revert")
+
+	// The local state is left untouched
+	require.Equal(t, testOldCode, statedb.GetCode(contract))
+
+	// The entry decodes as the block alloc of the Bor config
+	data, err := json.Marshal(blockAllocEntry(11, contract, testNewCode))
+	require.NoError(t, err)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &entry))
+
+	config.Bor.BlockAlloc["11"] = entry["11"]
+	require.NoError(t, config.CheckBorConfig())
+
+	data, err = json.Marshal(entry["11"])
+	require.NoError(t, err)
+
+	var alloc core.GenesisAlloc
+	require.NoError(t, json.Unmarshal(data, &alloc))
+	require.Equal(t, testNewCode, []byte(alloc[contract].Code))
+}
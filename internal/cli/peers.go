@@ -17,9 +17,11 @@ func (a *PeersCommand) MarkDown() string {
 		"# Peers",
 		"The ```peers``` command groups actions to interact with peers:",
 		"- [```peers add```](./peers_add.md): Joins the local client to another remote peer.",
+		"- [```peers ban```](./peers_ban.md): Bans a peer for a while, or lists the banned peers.",
 		"- [```peers list```](./peers_list.md): Lists the connected peers to the Bor client.",
 		"- [```peers remove```](./peers_remove.md): Disconnects the local client from a connected peer if exists.",
 		"- [```peers status```](./peers_status.md): Display the status of a peer by its id.",
+		"- [```peers unban```](./peers_unban.md): Lifts the ban of a peer.",
 	}
 
 	return strings.Join(items, "\n\n")
@@ -45,7 +47,15 @@ func (c *PeersCommand) Help() string {
 
   Display information about a peer:
 
-    $ bor peers status <peer id>`
+    $ bor peers status <peer id>
+
+  Ban a peer, or list the banned peers:
+
+    $ bor peers ban [<enode|peer id>]
+
+  Lift the ban of a peer:
+
+    $ bor peers unban <enode|peer id>`
 }
 
 // Synopsis implements the cli.Command interface
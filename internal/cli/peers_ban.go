@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// PeersBanCommand is the command to ban a peer
+type PeersBanCommand struct {
+	*Meta2
+
+	duration time.Duration
+	reason   string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (p *PeersBanCommand) MarkDown() string {
+	items := []string{
+		"# Peers ban",
+		"The ```peers ban <enode|peer id>``` command disconnects a peer and rejects its connections for a while. Peers are also banned automatically when their reputation drops, like when they serve a chain contradicting the whitelisted checkpoints or milestones, except the trusted, static and sentry peers. Bans are persisted across restarts. Without arguments, the command lists the banned peers.",
+		p.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (p *PeersBanCommand) Help() string {
+	return `Usage: bor peers ban [<enode|peer id>]
+
+  Bans a peer for a while, or lists the banned peers.
+
+  ` + p.Flags().Help()
+}
+
+func (p *PeersBanCommand) Flags() *flagset.Flagset {
+	flags := p.NewFlagSet("peers ban")
+
+	flags.DurationFlag(&flagset.DurationFlag{
+		Name:    "duration",
+		Usage:   "Duration of the ban",
+		Value:   &p.duration,
+		Default: 24 * time.Hour,
+	})
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "reason",
+		Usage: "Reason of the ban",
+		Value: &p.reason,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *PeersBanCommand) Synopsis() string {
+	return "Bans a peer, or lists the banned peers"
+}
+
+// Run implements the cli.Command interface
+func (c *PeersBanCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		c.UI.Error("Too many arguments")
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	req := &proto.PeersBanRequest{
+		Seconds: int64(c.duration / time.Second),
+		Reason:  c.reason,
+	}
+	if len(args) == 1 {
+		req.Enode = args[0]
+	}
+
+	resp, err := borClt.PeersBan(context.Background(), req)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatPeerBans(resp.Bans))
+
+	return 0
+}
+
+func formatPeerBans(bans []*proto.PeerBan) string {
+	if len(bans) == 0 {
+		return "No banned peers"
+	}
+
+	rows := make([]string, len(bans)+1)
+	rows[0] = "ID|Until|Reason"
+
+	for i, ban := range bans {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s",
+			ban.Id,
+			time.Unix(ban.Until, 0).UTC().Format(time.RFC3339),
+			ban.Reason,
+		)
+	}
+
+	return formatList(rows)
+}
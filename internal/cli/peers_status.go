@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/internal/cli/flagset"
 	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
@@ -18,7 +19,7 @@ type PeersStatusCommand struct {
 func (p *PeersStatusCommand) MarkDown() string {
 	items := []string{
 		"# Peers status",
-		"The ```peers status <peer id>``` command displays the status of a peer by its id.",
+		"The ```peers status <peer id>``` command displays the status of a peer by its id, along with the announcements and blocks it relayed: how many were first seen from it, duplicated or late, their lag behind their first sighting and their delay after their timestamp, overall and, once imported, by producer.",
 		p.Flags().MarkDown(),
 	}
 
@@ -91,5 +92,42 @@ func formatPeer(peer *proto.Peer) string {
 		fmt.Sprintf("Trusted|%v", peer.Trusted),
 	})
 
+	if prop := peer.Propagation; prop != nil {
+		base += "\n\nBlock propagation:\n" + formatKV([]string{
+			fmt.Sprintf("Announces|%d (%d first)", prop.Announces, prop.FirstAnnounces),
+			fmt.Sprintf("Announce lag|%dms", prop.AnnounceLagMs),
+			fmt.Sprintf("Blocks|%d (%d first)", prop.Blocks, prop.FirstBlocks),
+			fmt.Sprintf("Duplicate blocks|%d", prop.Duplicates),
+			fmt.Sprintf("Late blocks|%d", prop.Late),
+			fmt.Sprintf("Block lag|%dms", prop.BlockLagMs),
+			fmt.Sprintf("Block delay|%dms mean, %dms max", prop.MeanDelayMs, prop.MaxDelayMs),
+		})
+
+		if len(prop.Producers) > 0 {
+			producers := []string{"Producer|Blocks|First|Mean delay"}
+			for _, producer := range prop.Producers {
+				producers = append(producers, fmt.Sprintf("%s|%d|%d|%dms", producer.Address, producer.Blocks, producer.FirstBlocks, producer.MeanDelayMs))
+			}
+
+			base += "\n\n" + formatList(producers)
+		}
+	}
+
+	if rep := peer.Reputation; rep != nil {
+		items := []string{
+			fmt.Sprintf("Score|%d", rep.Score),
+			fmt.Sprintf("Banned|%v", rep.Banned),
+		}
+
+		if rep.Banned {
+			items = append(items,
+				fmt.Sprintf("Banned until|%s", time.Unix(rep.BannedUntil, 0).UTC().Format(time.RFC3339)),
+				fmt.Sprintf("Reason|%s", rep.Reason),
+			)
+		}
+
+		base += "\n\nReputation:\n" + formatKV(items)
+	}
+
 	return base
 }
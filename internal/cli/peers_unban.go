@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/proto"
+)
+
+// PeersUnbanCommand is the command to lift the ban of a peer
+type PeersUnbanCommand struct {
+	*Meta2
+}
+
+// MarkDown implements cli.MarkDown interface
+func (p *PeersUnbanCommand) MarkDown() string {
+	items := []string{
+		"# Peers unban",
+		"The ```peers unban <enode|peer id>``` command lifts the ban of a peer and resets its reputation.",
+		p.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (p *PeersUnbanCommand) Help() string {
+	return `Usage: bor peers unban <enode|peer id>
+
+  Lifts the ban of a peer.
+
+  ` + p.Flags().Help()
+}
+
+func (p *PeersUnbanCommand) Flags() *flagset.Flagset {
+	return p.NewFlagSet("peers unban")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *PeersUnbanCommand) Synopsis() string {
+	return "Lifts the ban of a peer"
+}
+
+// Run implements the cli.Command interface
+func (c *PeersUnbanCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("No enode address provided")
+		return 1
+	}
+
+	borClt, err := c.BorConn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := borClt.PeersUnban(context.Background(), &proto.PeersUnbanRequest{Enode: args[0]})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if !resp.Banned {
+		c.UI.Output("Peer not banned")
+	}
+
+	return 0
+}
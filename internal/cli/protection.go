@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
+	"github.com/ethereum/go-ethereum/internal/cli/server"
+	"github.com/ethereum/go-ethereum/node"
+)
+
+// ProtectionCommand is the command to group the signing protection commands
+type ProtectionCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ProtectionCommand) MarkDown() string {
+	items := []string{
+		"# Protection",
+		"The ```protection``` command groups the actions on the signing protection records of a validator, the last block it signed, refusing to sign a conflicting block:",
+		"- [```protection export```](./protection_export.md): Export the signing protection records.",
+		"- [```protection import```](./protection_import.md): Import signing protection records.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ProtectionCommand) Help() string {
+	return `Usage: bor protection <subcommand>
+
+  This command groups the actions on the signing protection records of a validator.
+
+  Move the records to another host before running the validator there:
+
+    $ bor protection export --datadir /var/lib/bor > protection.json
+
+    $ bor protection import --datadir /var/lib/bor protection.json`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ProtectionCommand) Synopsis() string {
+	return "Manage the signing protection records"
+}
+
+// Run implements the cli.Command interface
+func (c *ProtectionCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// openProtection opens the signing protection store of the node at the given datadir.
+func openProtection(datadir string) (*protection.Store, string, error) {
+	if datadir == "" {
+		datadir = server.DefaultDataDir()
+	}
+
+	path := (&node.Config{DataDir: datadir, Name: "bor"}).ResolvePath(protection.FileName)
+
+	store, err := protection.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return store, path, nil
+}
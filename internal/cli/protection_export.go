@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// ProtectionExportCommand is the command to export the signing protection records
+type ProtectionExportCommand struct {
+	*Meta
+
+	out string
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ProtectionExportCommand) MarkDown() string {
+	items := []string{
+		"# Protection export",
+		"The ```protection export``` command exports the signing protection records of the Bor data directory, the last block signed by each validator key.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ProtectionExportCommand) Help() string {
+	return `Usage: bor protection export
+
+  Export the signing protection records of the data directory.
+
+    $ bor protection export --datadir /var/lib/bor --out protection.json
+
+  ` + c.Flags().Help()
+}
+
+func (c *ProtectionExportCommand) Flags() *flagset.Flagset {
+	flags := c.NewFlagSet("protection export")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "out",
+		Usage: "File to write the records to, standard output if empty",
+		Value: &c.out,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ProtectionExportCommand) Synopsis() string {
+	return "Export the signing protection records"
+}
+
+// Run implements the cli.Command interface
+func (c *ProtectionExportCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	store, _, err := openProtection(c.dataDir)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to open the signing protection records: %v", err))
+		return 1
+	}
+
+	data, err := store.Export()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.out == "" {
+		c.UI.Output(string(data))
+		return 0
+	}
+
+	if err := os.WriteFile(c.out, data, 0600); err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to write the records: %v", err))
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+)
+
+// ProtectionImportCommand is the command to import signing protection records
+type ProtectionImportCommand struct {
+	*Meta
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *ProtectionImportCommand) MarkDown() string {
+	items := []string{
+		"# Protection import",
+		"The ```protection import``` command merges signing protection records, as exported by ```protection export```, into the Bor data directory. " +
+			"The highest block of each validator is kept, and nothing is imported if a record conflicts with a different block at the same height. " +
+			"The node must be stopped, as it would overwrite the imported records.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *ProtectionImportCommand) Help() string {
+	return `Usage: bor protection import <file>
+
+  Merge signing protection records into the data directory, with the node stopped.
+
+    $ bor protection import --datadir /var/lib/bor protection.json
+
+  ` + c.Flags().Help()
+}
+
+func (c *ProtectionImportCommand) Flags() *flagset.Flagset {
+	return c.NewFlagSet("protection import")
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ProtectionImportCommand) Synopsis() string {
+	return "Import signing protection records"
+}
+
+// Run implements the cli.Command interface
+func (c *ProtectionImportCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("Expected one argument")
+		return 1
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to read the records: %v", err))
+		return 1
+	}
+
+	store, path, err := openProtection(c.dataDir)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to open the signing protection records: %v", err))
+		return 1
+	}
+
+	imported, err := store.Import(data)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to import the records: %v", err))
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Imported %d records into %s", imported, path))
+
+	return 0
+}
@@ -1,7 +1,13 @@
 package chains
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestChain_ImportFromFile(t *testing.T) {
@@ -52,3 +58,36 @@ func TestChain_ImportFromFile(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeveloperBorChain(t *testing.T) {
+	t.Parallel()
+
+	developer := common.HexToAddress("0xdeadbeef")
+
+	chain := GetDeveloperBorChain(0, 11500000, developer)
+
+	// Bor can't seal on demand
+	require.Equal(t, uint64(1), chain.Genesis.Config.Bor.CalculatePeriod(0))
+
+	// The developer replaces the validator hardcoded in the validator contract
+	code := chain.Genesis.Alloc[common.HexToAddress(chain.Genesis.Config.Bor.ValidatorContract)].Code
+	require.True(t, bytes.Contains(code, developer[:]))
+	require.False(t, bytes.Contains(code, developerBorValidator[:]))
+
+	require.Contains(t, chain.Genesis.Alloc, common.HexToAddress(chain.Genesis.Config.Bor.StateReceiverContract))
+	require.Contains(t, chain.Genesis.Alloc, developer)
+}
+
+func TestReplaceDeveloperValidator(t *testing.T) {
+	t.Parallel()
+
+	developer := common.HexToAddress("0xdeadbeef")
+	push := append([]byte{byte(vm.PUSH20)}, developerBorValidator[:]...)
+
+	code := replaceDeveloperValidator(append([]byte{byte(vm.STOP)}, push...), developer)
+	require.Equal(t, append([]byte{byte(vm.STOP), byte(vm.PUSH20)}, developer[:]...), code)
+
+	// The validator must be pushed exactly once
+	require.Panics(t, func() { replaceDeveloperValidator([]byte{byte(vm.STOP)}, developer) })
+	require.Panics(t, func() { replaceDeveloperValidator(append(push, push...), developer) })
+}
@@ -1,10 +1,13 @@
 package chains
 
 import (
+	"bytes"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 )
@@ -45,3 +48,98 @@ func GetDeveloperChain(period uint64, gasLimitt uint64, faucet common.Address) *
 		Bootnodes: []string{},
 	}
 }
+
+// developerBorValidator is the validator hardcoded in the validator contract of
+// the developer allocation, replaced with the developer account.
+var developerBorValidator = common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7")
+
+// replaceDeveloperValidator replaces the validator pushed by the bytecode of the
+// validator contract with the developer account. The bytecode must push the
+// hardcoded validator exactly once, otherwise the chain would run without the
+// developer as validator.
+func replaceDeveloperValidator(code []byte, developer common.Address) []byte {
+	push := func(address common.Address) []byte {
+		return append([]byte{byte(vm.PUSH20)}, address[:]...)
+	}
+
+	if n := bytes.Count(code, push(developerBorValidator)); n != 1 {
+		panic(fmt.Sprintf("Validator contract pushes the developer validator %d times, want once", n))
+	}
+
+	return bytes.Replace(code, push(developerBorValidator), push(developer), 1)
+}
+
+// GetDeveloperBorChain returns the developer mode configs running the bor
+// consensus, with the developer account as the only validator.
+func GetDeveloperBorChain(period uint64, gasLimit uint64, developer common.Address) *Chain {
+	// Bor can't seal on demand, so fall back to the shortest period
+	if period == 0 {
+		period = 1
+	}
+
+	alloc := readPrealloc("allocs/developer.json")
+
+	// The initial validators are pushed by the bytecode of the validator contract
+	validatorContract := common.HexToAddress("0x0000000000000000000000000000000000001000")
+
+	account := alloc[validatorContract]
+	account.Code = replaceDeveloperValidator(account.Code, developer)
+	alloc[validatorContract] = account
+
+	alloc[developer] = core.GenesisAccount{Balance: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(9))}
+
+	return &Chain{
+		Hash:      common.Hash{},
+		NetworkId: 1337,
+		Genesis: &core.Genesis{
+			Config: &params.ChainConfig{
+				ChainID:             big.NewInt(1337),
+				HomesteadBlock:      big.NewInt(0),
+				EIP150Block:         big.NewInt(0),
+				EIP155Block:         big.NewInt(0),
+				EIP158Block:         big.NewInt(0),
+				ByzantiumBlock:      big.NewInt(0),
+				ConstantinopleBlock: big.NewInt(0),
+				PetersburgBlock:     big.NewInt(0),
+				IstanbulBlock:       big.NewInt(0),
+				MuirGlacierBlock:    big.NewInt(0),
+				BerlinBlock:         big.NewInt(0),
+				LondonBlock:         big.NewInt(0),
+				ShanghaiBlock:       big.NewInt(0),
+				Bor: &params.BorConfig{
+					JaipurBlock: big.NewInt(0),
+					DelhiBlock:  big.NewInt(0),
+					IndoreBlock: big.NewInt(0),
+					StateSyncConfirmationDelay: map[string]uint64{
+						"0": 0,
+					},
+					Period: map[string]uint64{
+						"0": period,
+					},
+					ProducerDelay: map[string]uint64{
+						"0": period,
+					},
+					// The span 0 of the validator contract ends at block 7, the next
+					// span must be committed by the first sprint to take over
+					Sprint: map[string]uint64{
+						"0": 4,
+					},
+					BackupMultiplier: map[string]uint64{
+						"0": 2,
+					},
+					ValidatorContract:     validatorContract.Hex(),
+					StateReceiverContract: "0x0000000000000000000000000000000000001001",
+					BurntContract: map[string]string{
+						"0": "0x0000000000000000000000000000000000000000",
+					},
+				},
+			},
+			ExtraData:  make([]byte, 32+crypto.SignatureLength),
+			GasLimit:   gasLimit,
+			BaseFee:    big.NewInt(params.InitialBaseFee),
+			Difficulty: big.NewInt(1),
+			Alloc:      alloc,
+		},
+		Bootnodes: []string{},
+	}
+}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"crypto/ecdsa"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -24,10 +26,12 @@ import (
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/fdlimit"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/ethdb/remotedb"
 	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
@@ -84,6 +88,9 @@ type Config struct {
 	// GcMode selects the garbage collection mode for the trie
 	GcMode string `hcl:"gcmode,optional" toml:"gcmode,optional"`
 
+	// StateScheme selects the scheme used to store the state ("hash" or "path")
+	StateScheme string `hcl:"state.scheme,optional" toml:"state.scheme,optional"`
+
 	// Snapshot enables the snapshot database mode
 	Snapshot bool `hcl:"snapshot,optional" toml:"snapshot,optional"`
 
@@ -93,6 +100,9 @@ type Config struct {
 	// Ethstats is the address of the ethstats server to send telemetry
 	Ethstats string `hcl:"ethstats,optional" toml:"ethstats,optional"`
 
+	// EthstatsBor enables the reporting of Bor specific data to ethstats
+	EthstatsBor bool `hcl:"ethstats.bor,optional" toml:"ethstats.bor,optional"`
+
 	// Logging has the logging related settings
 	Logging *LoggingConfig `hcl:"log,block" toml:"log,block"`
 
@@ -122,6 +132,12 @@ type Config struct {
 
 	ExtraDB *ExtraDBConfig `hcl:"leveldb,block" toml:"leveldb,block"`
 
+	// History has the history retention related settings
+	History *HistoryConfig `hcl:"history,block" toml:"history,block"`
+
+	// RemoteDB has the settings of the database served by another node to run over
+	RemoteDB *RemoteDBConfig `hcl:"remotedb,block" toml:"remotedb,block"`
+
 	// Account has the validator account related settings
 	Accounts *AccountsConfig `hcl:"accounts,block" toml:"accounts,block"`
 
@@ -215,12 +231,49 @@ type P2PConfig struct {
 	// Discovery has the p2p discovery related settings
 	Discovery *P2PDiscovery `hcl:"discovery,block" toml:"discovery,block"`
 
+	// Sentry has the sentry/validator topology related settings
+	Sentry *P2PSentry `hcl:"sentry,block" toml:"sentry,block"`
+
 	// TxArrivalWait sets the maximum duration the transaction fetcher will wait for
 	// an announced transaction to arrive before explicitly requesting it
 	TxArrivalWait    time.Duration `hcl:"-,optional" toml:"-"`
 	TxArrivalWaitRaw string        `hcl:"txarrivalwait,optional" toml:"txarrivalwait,optional"`
 }
 
+type P2PSentry struct {
+	// Mode is the role of the node in the topology: "validator" or "sentry" (empty to disable)
+	Mode string `hcl:"mode,optional" toml:"mode,optional"`
+
+	// Sentries is the list of sentry enodes a validator exclusively peers with
+	Sentries []string `hcl:"sentries,optional" toml:"sentries,optional"`
+
+	// Validators is the list of validator enodes a sentry relays the blocks of
+	Validators []string `hcl:"validators,optional" toml:"validators,optional"`
+}
+
+// peers returns the private peers of the topology: the sentries of a validator,
+// or the validators behind a sentry.
+func (s *P2PSentry) peers() ([]*enode.Node, error) {
+	switch s.Mode {
+	case "":
+		return nil, nil
+	case ethconfig.SentryModeValidator:
+		if len(s.Sentries) == 0 {
+			return nil, fmt.Errorf("no sentries configured for the validator")
+		}
+
+		return parseBootnodes(s.Sentries)
+	case ethconfig.SentryModeSentry:
+		if len(s.Validators) == 0 {
+			return nil, fmt.Errorf("no validators configured for the sentry")
+		}
+
+		return parseBootnodes(s.Validators)
+	default:
+		return nil, fmt.Errorf("unknown sentry mode '%s'", s.Mode)
+	}
+}
+
 type P2PDiscovery struct {
 	// DiscoveryV4 specifies whether V4 discovery should be started.
 	DiscoveryV4 bool `hcl:"v4disc,optional" toml:"v4disc,optional"`
@@ -265,6 +318,9 @@ type HeimdallConfig struct {
 
 	// UseHeimdallApp is used to fetch data from heimdall app when running heimdall as a child process
 	UseHeimdallApp bool `hcl:"bor.useheimdallapp,optional" toml:"bor.useheimdallapp,optional"`
+
+	// VerifySpans is used to compare the committed spans with heimdall and the snapshots in the background
+	VerifySpans bool `hcl:"bor.verifyspans,optional" toml:"bor.verifyspans,optional"`
 }
 
 type TxPoolConfig struct {
@@ -302,6 +358,12 @@ type TxPoolConfig struct {
 	// lifetime is the maximum amount of time non-executable transaction are queued
 	LifeTime    time.Duration `hcl:"-,optional" toml:"-"`
 	LifeTimeRaw string        `hcl:"lifetime,optional" toml:"lifetime,optional"`
+
+	// PrivateLifetime is the number of blocks a private transaction is kept before being dropped
+	PrivateLifetime uint64 `hcl:"privatelifetime,optional" toml:"privatelifetime,optional"`
+
+	// PrivateSlots is the maximum number of private transactions for all accounts
+	PrivateSlots uint64 `hcl:"privateslots,optional" toml:"privateslots,optional"`
 }
 
 type SealerConfig struct {
@@ -326,6 +388,12 @@ type SealerConfig struct {
 	RecommitRaw string        `hcl:"recommit,optional" toml:"recommit,optional"`
 
 	CommitInterruptFlag bool `hcl:"commitinterrupt,optional" toml:"commitinterrupt,optional"`
+
+	// SigningProtection is used to refuse signing a block conflicting with one already released by the validator
+	SigningProtection bool `hcl:"signingprotection,optional" toml:"signingprotection,optional"`
+
+	// RecordBuilds is used to record the building of the sealed blocks in the datadir, for them to be replayed offline
+	RecordBuilds bool `hcl:"recordbuilds,optional" toml:"recordbuilds,optional"`
 }
 
 type JsonRPCConfig struct {
@@ -465,6 +533,10 @@ type GpoConfig struct {
 	// IgnorePrice is a lower bound gas price
 	IgnorePrice    *big.Int `hcl:"-,optional" toml:"-"`
 	IgnorePriceRaw string   `hcl:"ignoreprice,optional" toml:"ignoreprice,optional"`
+
+	// Bor enables the estimates accounting for the fullness of the sprints, the
+	// pending transactions and the tip floor, served by bor_gasEstimates
+	Bor bool `hcl:"bor,optional" toml:"bor,optional"`
 }
 
 type TelemetryConfig struct {
@@ -562,6 +634,44 @@ type ExtraDBConfig struct {
 	LevelDbCompactionTotalSizeMultiplier float64 `hcl:"compactiontotalsizemultiplier,optional" toml:"compactiontotalsizemultiplier,optional"`
 }
 
+type HistoryConfig struct {
+	// State is the number of recent blocks to retain state history for (path-based scheme only)
+	State uint64 `hcl:"state,optional" toml:"state,optional"`
+
+	// Blocks is the number of recent blocks to retain headers, bodies and receipts for.
+	// Older blocks are expired from the ancient store (0 = entire chain)
+	Blocks uint64 `hcl:"blocks,optional" toml:"blocks,optional"`
+}
+
+type RemoteDBConfig struct {
+	// Addr is the address of a database served with 'bor db serve' to run RPC-only over,
+	// read-only, instead of the local chain database
+	Addr string `hcl:"addr,optional" toml:"addr,optional"`
+
+	// Cert is the client certificate authenticating to the database server over TLS
+	Cert string `hcl:"cert,optional" toml:"cert,optional"`
+
+	// Key is the key of the client certificate
+	Key string `hcl:"key,optional" toml:"key,optional"`
+
+	// CA is the certificate authority of the database server, the system ones if empty
+	CA string `hcl:"ca,optional" toml:"ca,optional"`
+}
+
+// Dial connects to the database server, over TLS if any certificate is configured.
+func (c *RemoteDBConfig) Dial() (*remotedb.Client, error) {
+	var config *tls.Config
+
+	if c.Cert != "" || c.Key != "" || c.CA != "" {
+		var err error
+		if config, err = remotedb.ClientTLSConfig(c.Cert, c.Key, c.CA); err != nil {
+			return nil, err
+		}
+	}
+
+	return remotedb.Dial(c.Addr, config)
+}
+
 type AccountsConfig struct {
 	// Unlock is the list of addresses to unlock in the node
 	Unlock []string `hcl:"unlock,optional" toml:"unlock,optional"`
@@ -577,6 +687,18 @@ type AccountsConfig struct {
 
 	// DisableBorWallet disables the personal wallet endpoints
 	DisableBorWallet bool `hcl:"disable-bor-wallet,optional" toml:"disable-bor-wallet,optional"`
+
+	// ExternalSigner is the URL of a remote signer (clef or compatible) holding the keys, used to seal the blocks
+	ExternalSigner string `hcl:"signer,optional" toml:"signer,optional"`
+
+	// ExternalSignerCert is the client certificate authenticating to the remote signer over https
+	ExternalSignerCert string `hcl:"signer.cert,optional" toml:"signer.cert,optional"`
+
+	// ExternalSignerKey is the key of the client certificate
+	ExternalSignerKey string `hcl:"signer.key,optional" toml:"signer.key,optional"`
+
+	// ExternalSignerCA is the certificate authority of the remote signer, the system ones if empty
+	ExternalSignerCA string `hcl:"signer.ca,optional" toml:"signer.ca,optional"`
 }
 
 type DeveloperConfig struct {
@@ -588,6 +710,9 @@ type DeveloperConfig struct {
 
 	// Initial block gas limit
 	GasLimit uint64 `hcl:"gaslimit,optional" toml:"gaslimit,optional"`
+
+	// Bor runs the developer mode with the bor consensus and an in-process fake heimdall
+	Bor bool `hcl:"bor,optional" toml:"bor,optional"`
 }
 
 type ParallelEVMConfig struct {
@@ -636,6 +761,11 @@ func DefaultConfig() *Config {
 				TrustedNodes: []string{},
 				DNS:          []string{},
 			},
+			Sentry: &P2PSentry{
+				Mode:       "",
+				Sentries:   []string{},
+				Validators: []string{},
+			},
 		},
 		Heimdall: &HeimdallConfig{
 			URL:         "http://localhost:1317",
@@ -658,6 +788,9 @@ func DefaultConfig() *Config {
 			AccountQueue: 16,
 			GlobalQueue:  32768,
 			LifeTime:     3 * time.Hour,
+
+			PrivateLifetime: 64,
+			PrivateSlots:    4096,
 		},
 		Sealer: &SealerConfig{
 			Enabled:             false,
@@ -667,6 +800,7 @@ func DefaultConfig() *Config {
 			ExtraData:           "",
 			Recommit:            125 * time.Second,
 			CommitInterruptFlag: true,
+			SigningProtection:   true,
 		},
 		Gpo: &GpoConfig{
 			Blocks:           20,
@@ -722,7 +856,8 @@ func DefaultConfig() *Config {
 				VHosts:    node.DefaultAuthVhosts,
 			},
 		},
-		Ethstats: "",
+		Ethstats:    "",
+		EthstatsBor: false,
 		Telemetry: &TelemetryConfig{
 			Enabled:               false,
 			Expensive:             false,
@@ -755,6 +890,16 @@ func DefaultConfig() *Config {
 			TrieTimeout:        60 * time.Minute,
 			FDLimit:            0,
 		},
+		History: &HistoryConfig{
+			State:  params.FullImmutabilityThreshold,
+			Blocks: 0,
+		},
+		RemoteDB: &RemoteDBConfig{
+			Addr: "",
+			Cert: "",
+			Key:  "",
+			CA:   "",
+		},
 		ExtraDB: &ExtraDBConfig{
 			// These are LevelDB defaults, specifying here for clarity in code and in logging.
 			// See: https://github.com/syndtr/goleveldb/blob/126854af5e6d8295ef8e8bee3040dd8380ae72e8/leveldb/opt/options.go
@@ -777,6 +922,7 @@ func DefaultConfig() *Config {
 			Enabled:  false,
 			Period:   0,
 			GasLimit: 11500000,
+			Bor:      false,
 		},
 		DevFakeAuthor: false,
 		Pprof: &PprofConfig{
@@ -928,6 +1074,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 	n.RunHeimdall = c.Heimdall.RunHeimdall
 	n.RunHeimdallArgs = c.Heimdall.RunHeimdallArgs
 	n.UseHeimdallApp = c.Heimdall.UseHeimdallApp
+	n.VerifySpans = c.Heimdall.VerifySpans
 
 	// Developer Fake Author for producing blocks without authorisation on bor consensus
 	n.DevFakeAuthor = c.DevFakeAuthor
@@ -943,6 +1090,7 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.GPO.MaxBlockHistory = uint64(c.Gpo.MaxBlockHistory)
 		n.GPO.MaxPrice = c.Gpo.MaxPrice
 		n.GPO.IgnorePrice = c.Gpo.IgnorePrice
+		n.GPO.Bor = c.Gpo.Bor
 	}
 
 	n.EnablePreimageRecording = c.EnablePreimageRecording
@@ -959,6 +1107,9 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.TxPool.AccountQueue = c.TxPool.AccountQueue
 		n.TxPool.GlobalQueue = c.TxPool.GlobalQueue
 		n.TxPool.Lifetime = c.TxPool.LifeTime
+
+		n.PrivatePool.Lifetime = c.TxPool.PrivateLifetime
+		n.PrivatePool.GlobalSlots = c.TxPool.PrivateSlots
 	}
 
 	// miner options
@@ -968,6 +1119,11 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.GasCeil = c.Sealer.GasCeil
 		n.Miner.ExtraData = []byte(c.Sealer.ExtraData)
 		n.Miner.CommitInterruptFlag = c.Sealer.CommitInterruptFlag
+		n.SigningProtection = c.Sealer.SigningProtection
+
+		if c.Sealer.RecordBuilds {
+			n.Miner.RecordBuilds = stack.ResolvePath("buildrecords")
+		}
 
 		if etherbase := c.Sealer.Etherbase; etherbase != "" {
 			if !common.IsHexAddress(etherbase) {
@@ -1038,7 +1194,14 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.Miner.Etherbase = developer.Address
 
 		// get developer mode chain config
-		c.chain = chains.GetDeveloperChain(c.Developer.Period, c.Developer.GasLimit, developer.Address)
+		if c.Developer.Bor {
+			c.chain = chains.GetDeveloperBorChain(c.Developer.Period, c.Developer.GasLimit, developer.Address)
+
+			// serve the spans and state syncs in-process
+			n.DevHeimdall = true
+		} else {
+			c.chain = chains.GetDeveloperChain(c.Developer.Period, c.Developer.GasLimit, developer.Address)
+		}
 
 		// update the parameters
 		n.NetworkId = c.chain.NetworkId
@@ -1062,6 +1225,25 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		n.SnapDiscoveryURLs = c.P2P.Discovery.DNS
 	}
 
+	// sentry/validator topology
+	{
+		peers, err := c.P2P.Sentry.peers()
+		if err != nil {
+			return nil, err
+		}
+
+		n.SentryMode = c.P2P.Sentry.Mode
+		for _, peer := range peers {
+			n.SentryPeers = append(n.SentryPeers, peer.ID())
+		}
+
+		// A validator doesn't dial the nodes found over DNS either
+		if n.SentryMode == ethconfig.SentryModeValidator {
+			n.EthDiscoveryURLs = nil
+			n.SnapDiscoveryURLs = nil
+		}
+	}
+
 	// RequiredBlocks
 	{
 		n.RequiredBlocks = map[uint64]common.Hash{}
@@ -1168,6 +1350,38 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		return nil, fmt.Errorf("gcmode '%s' not found", c.GcMode)
 	}
 
+	// state scheme. It can either be "hash" or "path", the scheme of the
+	// existing database is used if none is set.
+	switch c.StateScheme {
+	case "":
+	case "hash":
+		n.StateScheme = rawdb.HashScheme
+	case "path":
+		if n.NoPruning {
+			return nil, fmt.Errorf("state.scheme 'path' is not supported in archive mode")
+		}
+
+		n.StateScheme = rawdb.PathScheme
+	default:
+		return nil, fmt.Errorf("state.scheme '%s' not found", c.StateScheme)
+	}
+
+	// history retention
+	n.StateHistory = c.History.State
+	n.BlockHistory = c.History.Blocks
+
+	if n.BlockHistory != 0 {
+		if n.NoPruning {
+			return nil, fmt.Errorf("history.blocks is not supported in archive mode")
+		}
+
+		// Transactions can't be indexed past the retained block history
+		if n.TxLookupLimit == 0 || n.TxLookupLimit > n.BlockHistory {
+			log.Warn("Capping transaction index to the retained block history", "txlookuplimit", n.TxLookupLimit, "history", n.BlockHistory)
+			n.TxLookupLimit = n.BlockHistory
+		}
+	}
+
 	// snapshot disable check
 	if !c.Snapshot {
 		if n.SyncMode == downloader.SnapSync {
@@ -1179,6 +1393,32 @@ func (c *Config) buildEth(stack *node.Node, accountManager *accounts.Manager) (*
 		}
 	}
 
+	// run over the database served by another node, serving RPC only
+	if c.RemoteDB.Addr != "" {
+		if c.Sealer.Enabled || c.Developer.Enabled {
+			return nil, errors.New("mining is not supported over a remote database")
+		}
+
+		if n.StateScheme == rawdb.PathScheme {
+			return nil, errors.New("state.scheme 'path' is not supported over a remote database")
+		}
+
+		if n.BlockHistory != 0 {
+			return nil, errors.New("history.blocks is not supported over a remote database")
+		}
+
+		// The snapshot would be generated in memory, the state is read off the tries
+		n.TrieCleanCache += n.SnapshotCache
+		n.SnapshotCache = 0
+
+		db, err := c.RemoteDB.Dial()
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to the remote database %s: %w", c.RemoteDB.Addr, err)
+		}
+
+		n.RemoteDB = db
+	}
+
 	n.BorLogs = c.BorLogs
 	n.DatabaseHandles = dbHandles
 
@@ -1366,6 +1606,15 @@ func (c *Config) buildNode() (*node.Config, error) {
 		cfg.P2P.PrivateKey = key
 	}
 
+	// the node only serves RPC over a remote database, it doesn't sync
+	if c.RemoteDB.Addr != "" {
+		c.P2P.NoDiscover = true
+		cfg.P2P.MaxPeers = 0
+		cfg.P2P.ListenAddr = ""
+		cfg.P2P.NoDial = true
+		cfg.P2P.DiscoveryV5 = false
+	}
+
 	// dev mode
 	if c.Developer.Enabled {
 		cfg.UseLightweightKDF = true
@@ -1379,6 +1628,9 @@ func (c *Config) buildNode() (*node.Config, error) {
 		// enable JsonRPC HTTP API
 		c.JsonRPC.Http.Enabled = true
 		cfg.HTTPModules = []string{"admin", "debug", "eth", "miner", "net", "personal", "txpool", "web3", "bor"}
+		if c.Developer.Bor {
+			cfg.HTTPModules = append(cfg.HTTPModules, "dev")
+		}
 	}
 
 	// enable jsonrpc endpoints
@@ -1440,6 +1692,30 @@ func (c *Config) buildNode() (*node.Config, error) {
 		cfg.P2P.NoDiscovery = true
 	}
 
+	// sentry/validator topology
+	peers, err := c.P2P.Sentry.peers()
+	if err != nil {
+		return nil, err
+	}
+
+	switch c.P2P.Sentry.Mode {
+	case ethconfig.SentryModeValidator:
+		// Only peer with the sentries, authenticated by their node key, and
+		// stay out of discovery to keep the validator enode private
+		cfg.P2P.NoDiscovery = true
+		cfg.P2P.DiscoveryV4 = false
+		cfg.P2P.DiscoveryV5 = false
+		cfg.P2P.BootstrapNodes = nil
+		cfg.P2P.BootstrapNodesV5 = nil
+		cfg.P2P.StaticNodes = peers
+		cfg.P2P.TrustedNodes = peers
+		cfg.P2P.TrustedOnly = true
+	case ethconfig.SentryModeSentry:
+		// Keep the validators connected, even above the peer limit
+		cfg.P2P.StaticNodes = append(cfg.P2P.StaticNodes, peers...)
+		cfg.P2P.TrustedNodes = append(cfg.P2P.TrustedNodes, peers...)
+	}
+
 	return cfg, nil
 }
 
@@ -22,6 +22,7 @@ func TestConfigLegacy(t *testing.T) {
 		testConfig.RPCBatchLimit = 0
 		testConfig.Snapshot = true
 		testConfig.BorLogs = false
+		testConfig.StateScheme = "path"
 		testConfig.RequiredBlocks = map[string]string{
 			"31000000": "0x2087b9e2b353209c2c21e370c82daa12278efd0fe5f0febe6c29035352cf050e",
 			"32000000": "0x875500011e5eecc0c554f95d07b31cf59df4ca2505f4dbbfffa7d4e4da917c68",
@@ -33,6 +34,9 @@ func TestConfigLegacy(t *testing.T) {
 		testConfig.JsonRPC.Http.API = []string{"eth", "bor"}
 		testConfig.JsonRPC.Ws.API = []string{""}
 		testConfig.Gpo.MaxPrice = big.NewInt(5000000000000)
+		testConfig.Cache.TxLookupLimit = 100000
+		testConfig.History.State = 10000
+		testConfig.History.Blocks = 200000
 
 		assert.Equal(t, expectedConfig, testConfig)
 	}
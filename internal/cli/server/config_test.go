@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
 )
 
 func TestConfigDefault(t *testing.T) {
@@ -123,6 +125,154 @@ func TestConfigBootnodesDefault(t *testing.T) {
 	})
 }
 
+func TestConfigSentry(t *testing.T) {
+	t.Run("Validator", func(t *testing.T) {
+		// a validator only peers with its trusted sentries, out of discovery
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.P2P.Sentry.Mode = "validator"
+		config.P2P.Sentry.Sentries = []string{dummyEnodeAddr}
+
+		cfg, err := config.buildNode()
+		assert.NoError(t, err)
+		assert.True(t, cfg.P2P.NoDiscovery)
+		assert.True(t, cfg.P2P.TrustedOnly)
+		assert.Empty(t, cfg.P2P.BootstrapNodes)
+		assert.Len(t, cfg.P2P.StaticNodes, 1)
+		assert.Len(t, cfg.P2P.TrustedNodes, 1)
+	})
+	t.Run("Sentry", func(t *testing.T) {
+		// a sentry keeps discovering peers, and trusts its validators
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.P2P.Sentry.Mode = "sentry"
+		config.P2P.Sentry.Validators = []string{dummyEnodeAddr}
+
+		cfg, err := config.buildNode()
+		assert.NoError(t, err)
+		assert.False(t, cfg.P2P.TrustedOnly)
+		assert.NotEmpty(t, cfg.P2P.BootstrapNodes)
+		assert.Len(t, cfg.P2P.TrustedNodes, 1)
+	})
+	t.Run("MissingPeers", func(t *testing.T) {
+		config := DefaultConfig()
+		config.P2P.Sentry.Mode = "validator"
+
+		_, err := config.buildNode()
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigStateScheme(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		// the scheme of the existing database is used if none is set
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		cfg, err := config.buildEth(nil, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, cfg.StateScheme)
+		assert.Equal(t, config.History.State, cfg.StateHistory)
+	})
+	t.Run("Path", func(t *testing.T) {
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.StateScheme = "path"
+
+		cfg, err := config.buildEth(nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, rawdb.PathScheme, cfg.StateScheme)
+	})
+	t.Run("PathArchive", func(t *testing.T) {
+		// the path-based scheme keeps a single version of the state
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.StateScheme = "path"
+		config.GcMode = "archive"
+
+		_, err := config.buildEth(nil, nil)
+		assert.Error(t, err)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.StateScheme = "tree"
+
+		_, err := config.buildEth(nil, nil)
+		assert.Error(t, err)
+	})
+	t.Run("BlockHistory", func(t *testing.T) {
+		// transactions are not indexed past the retained block history
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.History.Blocks = 1000
+
+		cfg, err := config.buildEth(nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1000), cfg.BlockHistory)
+		assert.Equal(t, uint64(1000), cfg.TxLookupLimit)
+	})
+}
+
+func TestConfigRemoteDB(t *testing.T) {
+	t.Run("Node", func(t *testing.T) {
+		// the node serves RPC only over the remote database, it doesn't sync
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.RemoteDB.Addr = "127.0.0.1:3133"
+
+		cfg, err := config.buildNode()
+		assert.NoError(t, err)
+		assert.Zero(t, cfg.P2P.MaxPeers)
+		assert.True(t, cfg.P2P.NoDiscovery)
+		assert.True(t, cfg.P2P.NoDial)
+
+		ethCfg, err := config.buildEth(nil, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, ethCfg.RemoteDB)
+		assert.Zero(t, ethCfg.SnapshotCache)
+		assert.NoError(t, ethCfg.RemoteDB.Close())
+	})
+	t.Run("Mining", func(t *testing.T) {
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.RemoteDB.Addr = "127.0.0.1:3133"
+		config.Sealer.Enabled = true
+
+		_, err := config.buildEth(nil, nil)
+		assert.Error(t, err)
+	})
+	t.Run("Path", func(t *testing.T) {
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.RemoteDB.Addr = "127.0.0.1:3133"
+		config.StateScheme = "path"
+
+		_, err := config.buildEth(nil, nil)
+		assert.Error(t, err)
+	})
+	t.Run("KeyPair", func(t *testing.T) {
+		// the client certificate needs its key
+		config := DefaultConfig()
+		assert.NoError(t, config.loadChain())
+
+		config.RemoteDB.Addr = "127.0.0.1:3133"
+		config.RemoteDB.Cert = "client.crt"
+
+		_, err := config.buildEth(nil, nil)
+		assert.Error(t, err)
+	})
+}
+
 func TestMakePasswordListFromFile(t *testing.T) {
 	t.Parallel()
 
@@ -63,6 +63,30 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.DBEngine,
 		Default: c.cliConfig.DBEngine,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "db.remote",
+		Usage:   "Address of a database served with 'bor db serve' to run RPC-only over, read-only, instead of the local chain database",
+		Value:   &c.cliConfig.RemoteDB.Addr,
+		Default: c.cliConfig.RemoteDB.Addr,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "db.remote.cert",
+		Usage:   "Client certificate authenticating to the database server over TLS",
+		Value:   &c.cliConfig.RemoteDB.Cert,
+		Default: c.cliConfig.RemoteDB.Cert,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "db.remote.key",
+		Usage:   "Key of the client certificate of the database server",
+		Value:   &c.cliConfig.RemoteDB.Key,
+		Default: c.cliConfig.RemoteDB.Key,
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "db.remote.ca",
+		Usage:   "Certificate authority of the database server, the system ones if empty",
+		Value:   &c.cliConfig.RemoteDB.CA,
+		Default: c.cliConfig.RemoteDB.CA,
+	})
 	f.StringFlag(&flagset.StringFlag{
 		Name:    "keystore",
 		Usage:   "Path of the directory where keystores are located",
@@ -98,6 +122,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.GcMode,
 		Default: c.cliConfig.GcMode,
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "state.scheme",
+		Usage:   `Scheme to use for storing the state ("hash", "path"), the scheme of the existing database is used if not set`,
+		Value:   &c.cliConfig.StateScheme,
+		Default: c.cliConfig.StateScheme,
+	})
 	f.MapStringFlag(&flagset.MapStringFlag{
 		Name:    "eth.requiredblocks",
 		Usage:   "Comma separated block number-to-hash mappings to require for peering (<number>=<hash>)",
@@ -197,6 +227,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Heimdall.UseHeimdallApp,
 		Default: c.cliConfig.Heimdall.UseHeimdallApp,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "bor.verifyspans",
+		Usage:   "Compare the spans committed to the validator contract with Heimdall and the snapshots in the background",
+		Value:   &c.cliConfig.Heimdall.VerifySpans,
+		Default: c.cliConfig.Heimdall.VerifySpans,
+	})
 
 	// txpool options
 	f.SliceStringFlag(&flagset.SliceStringFlag{
@@ -276,6 +312,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.TxPool.LifeTime,
 		Group:   "Transaction Pool",
 	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "txpool.privatelifetime",
+		Usage:   "Number of blocks a private transaction is kept before being dropped",
+		Value:   &c.cliConfig.TxPool.PrivateLifetime,
+		Default: c.cliConfig.TxPool.PrivateLifetime,
+		Group:   "Transaction Pool",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "txpool.privateslots",
+		Usage:   "Maximum number of private transactions for all accounts",
+		Value:   &c.cliConfig.TxPool.PrivateSlots,
+		Default: c.cliConfig.TxPool.PrivateSlots,
+		Group:   "Transaction Pool",
+	})
 
 	// sealer options
 	f.BoolFlag(&flagset.BoolFlag{
@@ -327,6 +377,20 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Sealer.CommitInterruptFlag,
 		Group:   "Sealer",
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.signingprotection",
+		Usage:   "Refuse to sign a block conflicting with one already signed by the validator, as recorded in the datadir",
+		Value:   &c.cliConfig.Sealer.SigningProtection,
+		Default: c.cliConfig.Sealer.SigningProtection,
+		Group:   "Sealer",
+	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "miner.recordbuilds",
+		Usage:   "Record the inputs and decisions of the building of the sealed blocks in the datadir, for 'bor debug replay-build'",
+		Value:   &c.cliConfig.Sealer.RecordBuilds,
+		Default: c.cliConfig.Sealer.RecordBuilds,
+		Group:   "Sealer",
+	})
 
 	// ethstats
 	f.StringFlag(&flagset.StringFlag{
@@ -335,6 +399,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Ethstats,
 		Default: c.cliConfig.Ethstats,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "ethstats.bor",
+		Usage:   "Report the span, block signers, finality, Heimdall connectivity and block-STM usage to ethstats",
+		Value:   &c.cliConfig.EthstatsBor,
+		Default: c.cliConfig.EthstatsBor,
+	})
 
 	// gas price oracle
 	f.Uint64Flag(&flagset.Uint64Flag{
@@ -373,6 +443,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   c.cliConfig.Gpo.IgnorePrice,
 		Default: c.cliConfig.Gpo.IgnorePrice,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "gpo.bor",
+		Usage:   "Estimate fees from the fullness of the recent sprints, the pending transactions and the tip floor, served by bor_gasEstimates",
+		Value:   &c.cliConfig.Gpo.Bor,
+		Default: c.cliConfig.Gpo.Bor,
+	})
 
 	// cache options
 	f.Uint64Flag(&flagset.Uint64Flag{
@@ -453,6 +529,22 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Group:   "Cache",
 	})
 
+	// History options
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "history.state",
+		Usage:   "Number of recent blocks to retain state history for (path-based state scheme only)",
+		Value:   &c.cliConfig.History.State,
+		Default: c.cliConfig.History.State,
+		Group:   "History",
+	})
+	f.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "history.blocks",
+		Usage:   "Number of recent blocks to retain headers, bodies and receipts for, older ones are expired from the ancient store (0 = entire chain)",
+		Value:   &c.cliConfig.History.Blocks,
+		Default: c.cliConfig.History.Blocks,
+		Group:   "History",
+	})
+
 	// LevelDB options
 	f.Uint64Flag(&flagset.Uint64Flag{
 		Name:    "leveldb.compaction.table.size",
@@ -793,6 +885,27 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.P2P.Discovery.V5Enabled,
 		Group:   "P2P",
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "sentry.mode",
+		Usage:   "Role of the node in a sentry/validator topology (validator|sentry)",
+		Value:   &c.cliConfig.P2P.Sentry.Mode,
+		Default: c.cliConfig.P2P.Sentry.Mode,
+		Group:   "P2P",
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "sentry.sentries",
+		Usage:   "Comma separated enode URLs of the sentries a validator exclusively peers with",
+		Value:   &c.cliConfig.P2P.Sentry.Sentries,
+		Default: c.cliConfig.P2P.Sentry.Sentries,
+		Group:   "P2P",
+	})
+	f.SliceStringFlag(&flagset.SliceStringFlag{
+		Name:    "sentry.validators",
+		Usage:   "Comma separated enode URLs of the validators a sentry relays the blocks of",
+		Value:   &c.cliConfig.P2P.Sentry.Validators,
+		Default: c.cliConfig.P2P.Sentry.Validators,
+		Group:   "P2P",
+	})
 	f.DurationFlag(&flagset.DurationFlag{
 		Name:    "txarrivalwait",
 		Usage:   "Maximum duration to wait for a transaction before explicitly requesting it",
@@ -931,6 +1044,34 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Default: c.cliConfig.Accounts.UseLightweightKDF,
 		Group:   "Account Management",
 	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "signer",
+		Usage:   "URL of a remote signer (clef or compatible) holding the keys, used to seal the blocks",
+		Value:   &c.cliConfig.Accounts.ExternalSigner,
+		Default: c.cliConfig.Accounts.ExternalSigner,
+		Group:   "Account Management",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "signer.cert",
+		Usage:   "Client certificate authenticating to the remote signer over https",
+		Value:   &c.cliConfig.Accounts.ExternalSignerCert,
+		Default: c.cliConfig.Accounts.ExternalSignerCert,
+		Group:   "Account Management",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "signer.key",
+		Usage:   "Key of the client certificate of the remote signer",
+		Value:   &c.cliConfig.Accounts.ExternalSignerKey,
+		Default: c.cliConfig.Accounts.ExternalSignerKey,
+		Group:   "Account Management",
+	})
+	f.StringFlag(&flagset.StringFlag{
+		Name:    "signer.ca",
+		Usage:   "Certificate authority of the remote signer, the system ones if empty",
+		Value:   &c.cliConfig.Accounts.ExternalSignerCA,
+		Default: c.cliConfig.Accounts.ExternalSignerCA,
+		Group:   "Account Management",
+	})
 	f.BoolFlag((&flagset.BoolFlag{
 		Name:    "disable-bor-wallet",
 		Usage:   "Disable the personal wallet endpoints",
@@ -959,6 +1100,12 @@ func (c *Command) Flags(config *Config) *flagset.Flagset {
 		Value:   &c.cliConfig.Developer.Period,
 		Default: c.cliConfig.Developer.Period,
 	})
+	f.BoolFlag(&flagset.BoolFlag{
+		Name:    "dev.bor",
+		Usage:   "Run the developer mode with the bor consensus and an in-process fake heimdall, state syncs are injected with dev_injectStateSync",
+		Value:   &c.cliConfig.Developer.Bor,
+		Default: c.cliConfig.Developer.Bor,
+	})
 
 	// parallelevm
 	f.BoolFlag(&flagset.BoolFlag{
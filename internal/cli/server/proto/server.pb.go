@@ -747,13 +747,15 @@ type Peer struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Enode   string   `protobuf:"bytes,2,opt,name=enode,proto3" json:"enode,omitempty"`
-	Enr     string   `protobuf:"bytes,3,opt,name=enr,proto3" json:"enr,omitempty"`
-	Caps    []string `protobuf:"bytes,4,rep,name=caps,proto3" json:"caps,omitempty"`
-	Name    string   `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
-	Trusted bool     `protobuf:"varint,6,opt,name=trusted,proto3" json:"trusted,omitempty"`
-	Static  bool     `protobuf:"varint,7,opt,name=static,proto3" json:"static,omitempty"`
+	Id          string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Enode       string           `protobuf:"bytes,2,opt,name=enode,proto3" json:"enode,omitempty"`
+	Enr         string           `protobuf:"bytes,3,opt,name=enr,proto3" json:"enr,omitempty"`
+	Caps        []string         `protobuf:"bytes,4,rep,name=caps,proto3" json:"caps,omitempty"`
+	Name        string           `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Trusted     bool             `protobuf:"varint,6,opt,name=trusted,proto3" json:"trusted,omitempty"`
+	Static      bool             `protobuf:"varint,7,opt,name=static,proto3" json:"static,omitempty"`
+	Propagation *PeerPropagation `protobuf:"bytes,8,opt,name=propagation,proto3" json:"propagation,omitempty"`
+	Reputation  *PeerReputation  `protobuf:"bytes,9,opt,name=reputation,proto3" json:"reputation,omitempty"`
 }
 
 func (x *Peer) Reset() {
@@ -848,6 +850,20 @@ func (x *Peer) GetStatic() bool {
 	return false
 }
 
+func (x *Peer) GetPropagation() *PeerPropagation {
+	if x != nil {
+		return x.Propagation
+	}
+	return nil
+}
+
+func (x *Peer) GetReputation() *PeerReputation {
+	if x != nil {
+		return x.Reputation
+	}
+	return nil
+}
+
 type ChainSetHeadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1005,6 +1021,7 @@ type StatusResponse struct {
 	SyncMode      string                  `protobuf:"bytes,4,opt,name=syncMode,proto3" json:"syncMode,omitempty"`
 	Syncing       *StatusResponse_Syncing `protobuf:"bytes,5,opt,name=syncing,proto3" json:"syncing,omitempty"`
 	Forks         []*StatusResponse_Fork  `protobuf:"bytes,6,rep,name=forks,proto3" json:"forks,omitempty"`
+	SentryLinks   []*SentryLink           `protobuf:"bytes,7,rep,name=sentryLinks,proto3" json:"sentryLinks,omitempty"`
 }
 
 func (x *StatusResponse) Reset() {
@@ -1091,6 +1108,13 @@ func (x *StatusResponse) GetForks() []*StatusResponse_Fork {
 	return nil
 }
 
+func (x *StatusResponse) GetSentryLinks() []*SentryLink {
+	if x != nil {
+		return x.SentryLinks
+	}
+	return nil
+}
+
 type Header struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1222,161 +1246,729 @@ func (x *DebugPprofRequest) GetSeconds() int64 {
 	return 0
 }
 
-type DebugBlockRequest struct {
+type DebugBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *DebugBlockRequest) Reset() {
+	*x = DebugBlockRequest{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugBlockRequest) ProtoMessage() {}
+
+func (x *DebugBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[20]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugBlockRequest.ProtoReflect.Descriptor instead.
+func (*DebugBlockRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DebugBlockRequest) GetNumber() int64 {
+	if x != nil {
+		return x.Number
+	}
+
+	return 0
+}
+
+type DebugFileResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//
+	//	*DebugFileResponse_Open_
+	//	*DebugFileResponse_Input_
+	//	*DebugFileResponse_Eof
+	Event isDebugFileResponse_Event `protobuf_oneof:"event"`
+}
+
+func (x *DebugFileResponse) Reset() {
+	*x = DebugFileResponse{}
+
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DebugFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugFileResponse) ProtoMessage() {}
+
+func (x *DebugFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[21]
+
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+
+		return ms
+	}
+
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugFileResponse.ProtoReflect.Descriptor instead.
+func (*DebugFileResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (m *DebugFileResponse) GetEvent() isDebugFileResponse_Event {
+	if m != nil {
+		return m.Event
+	}
+
+	return nil
+}
+
+func (x *DebugFileResponse) GetOpen() *DebugFileResponse_Open {
+	if x, ok := x.GetEvent().(*DebugFileResponse_Open_); ok {
+		return x.Open
+	}
+
+	return nil
+}
+
+func (x *DebugFileResponse) GetInput() *DebugFileResponse_Input {
+	if x, ok := x.GetEvent().(*DebugFileResponse_Input_); ok {
+		return x.Input
+	}
+
+	return nil
+}
+
+func (x *DebugFileResponse) GetEof() *emptypb.Empty {
+	if x, ok := x.GetEvent().(*DebugFileResponse_Eof); ok {
+		return x.Eof
+	}
+
+	return nil
+}
+
+type isDebugFileResponse_Event interface {
+	isDebugFileResponse_Event()
+}
+
+type DebugFileResponse_Open_ struct {
+	Open *DebugFileResponse_Open `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+}
+
+type DebugFileResponse_Input_ struct {
+	Input *DebugFileResponse_Input `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+}
+
+type DebugFileResponse_Eof struct {
+	Eof *emptypb.Empty `protobuf:"bytes,3,opt,name=eof,proto3,oneof"`
+}
+
+func (*DebugFileResponse_Open_) isDebugFileResponse_Event() {}
+
+func (*DebugFileResponse_Input_) isDebugFileResponse_Event() {}
+
+func (*DebugFileResponse_Eof) isDebugFileResponse_Event() {}
+
+type PeerPropagation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Announces      uint64                      `protobuf:"varint,1,opt,name=announces,proto3" json:"announces,omitempty"`
+	FirstAnnounces uint64                      `protobuf:"varint,2,opt,name=firstAnnounces,proto3" json:"firstAnnounces,omitempty"`
+	AnnounceLagMs  int64                       `protobuf:"varint,3,opt,name=announceLagMs,proto3" json:"announceLagMs,omitempty"`
+	Blocks         uint64                      `protobuf:"varint,4,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	FirstBlocks    uint64                      `protobuf:"varint,5,opt,name=firstBlocks,proto3" json:"firstBlocks,omitempty"`
+	Duplicates     uint64                      `protobuf:"varint,6,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Late           uint64                      `protobuf:"varint,7,opt,name=late,proto3" json:"late,omitempty"`
+	BlockLagMs     int64                       `protobuf:"varint,8,opt,name=blockLagMs,proto3" json:"blockLagMs,omitempty"`
+	MeanDelayMs    int64                       `protobuf:"varint,9,opt,name=meanDelayMs,proto3" json:"meanDelayMs,omitempty"`
+	MaxDelayMs     int64                       `protobuf:"varint,10,opt,name=maxDelayMs,proto3" json:"maxDelayMs,omitempty"`
+	Producers      []*PeerPropagation_Producer `protobuf:"bytes,11,rep,name=producers,proto3" json:"producers,omitempty"`
+}
+
+func (x *PeerPropagation) Reset() {
+	*x = PeerPropagation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerPropagation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerPropagation) ProtoMessage() {}
+
+func (x *PeerPropagation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerPropagation.ProtoReflect.Descriptor instead.
+func (*PeerPropagation) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PeerPropagation) GetAnnounces() uint64 {
+	if x != nil {
+		return x.Announces
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetFirstAnnounces() uint64 {
+	if x != nil {
+		return x.FirstAnnounces
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetAnnounceLagMs() int64 {
+	if x != nil {
+		return x.AnnounceLagMs
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetBlocks() uint64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetFirstBlocks() uint64 {
+	if x != nil {
+		return x.FirstBlocks
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetDuplicates() uint64 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetLate() uint64 {
+	if x != nil {
+		return x.Late
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetBlockLagMs() int64 {
+	if x != nil {
+		return x.BlockLagMs
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetMeanDelayMs() int64 {
+	if x != nil {
+		return x.MeanDelayMs
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetMaxDelayMs() int64 {
+	if x != nil {
+		return x.MaxDelayMs
+	}
+	return 0
+}
+
+func (x *PeerPropagation) GetProducers() []*PeerPropagation_Producer {
+	if x != nil {
+		return x.Producers
+	}
+	return nil
+}
+
+type SentryLink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Role          string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Id            string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Enode         string `protobuf:"bytes,3,opt,name=enode,proto3" json:"enode,omitempty"`
+	Connected     bool   `protobuf:"varint,4,opt,name=connected,proto3" json:"connected,omitempty"`
+	RemoteAddress string `protobuf:"bytes,5,opt,name=remoteAddress,proto3" json:"remoteAddress,omitempty"`
+	Blocks        uint64 `protobuf:"varint,6,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	Late          uint64 `protobuf:"varint,7,opt,name=late,proto3" json:"late,omitempty"`
+	MeanDelayMs   int64  `protobuf:"varint,8,opt,name=meanDelayMs,proto3" json:"meanDelayMs,omitempty"`
+}
+
+func (x *SentryLink) Reset() {
+	*x = SentryLink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SentryLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SentryLink) ProtoMessage() {}
+
+func (x *SentryLink) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SentryLink.ProtoReflect.Descriptor instead.
+func (*SentryLink) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SentryLink) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *SentryLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SentryLink) GetEnode() string {
+	if x != nil {
+		return x.Enode
+	}
+	return ""
+}
+
+func (x *SentryLink) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *SentryLink) GetRemoteAddress() string {
+	if x != nil {
+		return x.RemoteAddress
+	}
+	return ""
+}
+
+func (x *SentryLink) GetBlocks() uint64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *SentryLink) GetLate() uint64 {
+	if x != nil {
+		return x.Late
+	}
+	return 0
+}
+
+func (x *SentryLink) GetMeanDelayMs() int64 {
+	if x != nil {
+		return x.MeanDelayMs
+	}
+	return 0
+}
+
+type PeerReputation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Score       int64  `protobuf:"varint,1,opt,name=score,proto3" json:"score,omitempty"`
+	Banned      bool   `protobuf:"varint,2,opt,name=banned,proto3" json:"banned,omitempty"`
+	BannedUntil int64  `protobuf:"varint,3,opt,name=bannedUntil,proto3" json:"bannedUntil,omitempty"`
+	Reason      string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PeerReputation) Reset() {
+	*x = PeerReputation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerReputation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerReputation) ProtoMessage() {}
+
+func (x *PeerReputation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerReputation.ProtoReflect.Descriptor instead.
+func (*PeerReputation) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PeerReputation) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetBanned() bool {
+	if x != nil {
+		return x.Banned
+	}
+	return false
+}
+
+func (x *PeerReputation) GetBannedUntil() int64 {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return 0
+}
+
+func (x *PeerReputation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PeerBan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Until  int64  `protobuf:"varint,2,opt,name=until,proto3" json:"until,omitempty"`
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PeerBan) Reset() {
+	*x = PeerBan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerBan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerBan) ProtoMessage() {}
+
+func (x *PeerBan) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerBan.ProtoReflect.Descriptor instead.
+func (*PeerBan) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PeerBan) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PeerBan) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *PeerBan) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PeersBanRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enode   string `protobuf:"bytes,1,opt,name=enode,proto3" json:"enode,omitempty"`
+	Seconds int64  `protobuf:"varint,2,opt,name=seconds,proto3" json:"seconds,omitempty"`
+	Reason  string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PeersBanRequest) Reset() {
+	*x = PeersBanRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeersBanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersBanRequest) ProtoMessage() {}
+
+func (x *PeersBanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersBanRequest.ProtoReflect.Descriptor instead.
+func (*PeersBanRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PeersBanRequest) GetEnode() string {
+	if x != nil {
+		return x.Enode
+	}
+	return ""
+}
+
+func (x *PeersBanRequest) GetSeconds() int64 {
+	if x != nil {
+		return x.Seconds
+	}
+	return 0
+}
+
+func (x *PeersBanRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type PeersBanResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Number int64 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Bans []*PeerBan `protobuf:"bytes,1,rep,name=bans,proto3" json:"bans,omitempty"`
 }
 
-func (x *DebugBlockRequest) Reset() {
-	*x = DebugBlockRequest{}
-
+func (x *PeersBanResponse) Reset() {
+	*x = PeersBanResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[20]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DebugBlockRequest) String() string {
+func (x *PeersBanResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugBlockRequest) ProtoMessage() {}
-
-func (x *DebugBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[20]
+func (*PeersBanResponse) ProtoMessage() {}
 
+func (x *PeersBanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-
 		return ms
 	}
-
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugBlockRequest.ProtoReflect.Descriptor instead.
-func (*DebugBlockRequest) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use PeersBanResponse.ProtoReflect.Descriptor instead.
+func (*PeersBanResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *DebugBlockRequest) GetNumber() int64 {
+func (x *PeersBanResponse) GetBans() []*PeerBan {
 	if x != nil {
-		return x.Number
+		return x.Bans
 	}
-
-	return 0
+	return nil
 }
 
-type DebugFileResponse struct {
+type PeersUnbanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Types that are assignable to Event:
-	//
-	//	*DebugFileResponse_Open_
-	//	*DebugFileResponse_Input_
-	//	*DebugFileResponse_Eof
-	Event isDebugFileResponse_Event `protobuf_oneof:"event"`
+	Enode string `protobuf:"bytes,1,opt,name=enode,proto3" json:"enode,omitempty"`
 }
 
-func (x *DebugFileResponse) Reset() {
-	*x = DebugFileResponse{}
-
+func (x *PeersUnbanRequest) Reset() {
+	*x = PeersUnbanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[21]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DebugFileResponse) String() string {
+func (x *PeersUnbanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugFileResponse) ProtoMessage() {}
-
-func (x *DebugFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[21]
+func (*PeersUnbanRequest) ProtoMessage() {}
 
+func (x *PeersUnbanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-
 		return ms
 	}
-
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugFileResponse.ProtoReflect.Descriptor instead.
-func (*DebugFileResponse) Descriptor() ([]byte, []int) {
-	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use PeersUnbanRequest.ProtoReflect.Descriptor instead.
+func (*PeersUnbanRequest) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{28}
 }
 
-func (m *DebugFileResponse) GetEvent() isDebugFileResponse_Event {
-	if m != nil {
-		return m.Event
+func (x *PeersUnbanRequest) GetEnode() string {
+	if x != nil {
+		return x.Enode
 	}
-
-	return nil
+	return ""
 }
 
-func (x *DebugFileResponse) GetOpen() *DebugFileResponse_Open {
-	if x, ok := x.GetEvent().(*DebugFileResponse_Open_); ok {
-		return x.Open
-	}
+type PeersUnbanResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-	return nil
+	Banned bool `protobuf:"varint,1,opt,name=banned,proto3" json:"banned,omitempty"`
 }
 
-func (x *DebugFileResponse) GetInput() *DebugFileResponse_Input {
-	if x, ok := x.GetEvent().(*DebugFileResponse_Input_); ok {
-		return x.Input
+func (x *PeersUnbanResponse) Reset() {
+	*x = PeersUnbanResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-
-	return nil
 }
 
-func (x *DebugFileResponse) GetEof() *emptypb.Empty {
-	if x, ok := x.GetEvent().(*DebugFileResponse_Eof); ok {
-		return x.Eof
-	}
-
-	return nil
+func (x *PeersUnbanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type isDebugFileResponse_Event interface {
-	isDebugFileResponse_Event()
-}
+func (*PeersUnbanResponse) ProtoMessage() {}
 
-type DebugFileResponse_Open_ struct {
-	Open *DebugFileResponse_Open `protobuf:"bytes,1,opt,name=open,proto3,oneof"`
+func (x *PeersUnbanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type DebugFileResponse_Input_ struct {
-	Input *DebugFileResponse_Input `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+// Deprecated: Use PeersUnbanResponse.ProtoReflect.Descriptor instead.
+func (*PeersUnbanResponse) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{29}
 }
 
-type DebugFileResponse_Eof struct {
-	Eof *emptypb.Empty `protobuf:"bytes,3,opt,name=eof,proto3,oneof"`
+func (x *PeersUnbanResponse) GetBanned() bool {
+	if x != nil {
+		return x.Banned
+	}
+	return false
 }
 
-func (*DebugFileResponse_Open_) isDebugFileResponse_Event() {}
-
-func (*DebugFileResponse_Input_) isDebugFileResponse_Event() {}
-
-func (*DebugFileResponse_Eof) isDebugFileResponse_Event() {}
-
 type StatusResponse_Fork struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1391,7 +1983,7 @@ func (x *StatusResponse_Fork) Reset() {
 	*x = StatusResponse_Fork{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1404,7 +1996,7 @@ func (x *StatusResponse_Fork) String() string {
 func (*StatusResponse_Fork) ProtoMessage() {}
 
 func (x *StatusResponse_Fork) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[22]
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[30]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1461,7 +2053,7 @@ func (x *StatusResponse_Syncing) Reset() {
 	*x = StatusResponse_Syncing{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1474,7 +2066,7 @@ func (x *StatusResponse_Syncing) String() string {
 func (*StatusResponse_Syncing) ProtoMessage() {}
 
 func (x *StatusResponse_Syncing) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[23]
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[31]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1529,7 +2121,7 @@ func (x *DebugFileResponse_Open) Reset() {
 	*x = DebugFileResponse_Open{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1542,7 +2134,7 @@ func (x *DebugFileResponse_Open) String() string {
 func (*DebugFileResponse_Open) ProtoMessage() {}
 
 func (x *DebugFileResponse_Open) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[24]
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[32]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1581,7 +2173,7 @@ func (x *DebugFileResponse_Input) Reset() {
 	*x = DebugFileResponse_Input{}
 
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1594,7 +2186,7 @@ func (x *DebugFileResponse_Input) String() string {
 func (*DebugFileResponse_Input) ProtoMessage() {}
 
 func (x *DebugFileResponse_Input) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_cli_server_proto_server_proto_msgTypes[25]
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[33]
 
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1621,6 +2213,77 @@ func (x *DebugFileResponse_Input) GetData() []byte {
 	return nil
 }
 
+type PeerPropagation_Producer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Blocks      uint64 `protobuf:"varint,2,opt,name=blocks,proto3" json:"blocks,omitempty"`
+	FirstBlocks uint64 `protobuf:"varint,3,opt,name=firstBlocks,proto3" json:"firstBlocks,omitempty"`
+	MeanDelayMs int64  `protobuf:"varint,4,opt,name=meanDelayMs,proto3" json:"meanDelayMs,omitempty"`
+}
+
+func (x *PeerPropagation_Producer) Reset() {
+	*x = PeerPropagation_Producer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_cli_server_proto_server_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PeerPropagation_Producer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerPropagation_Producer) ProtoMessage() {}
+
+func (x *PeerPropagation_Producer) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_cli_server_proto_server_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerPropagation_Producer.ProtoReflect.Descriptor instead.
+func (*PeerPropagation_Producer) Descriptor() ([]byte, []int) {
+	return file_internal_cli_server_proto_server_proto_rawDescGZIP(), []int{22, 0}
+}
+
+func (x *PeerPropagation_Producer) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerPropagation_Producer) GetBlocks() uint64 {
+	if x != nil {
+		return x.Blocks
+	}
+	return 0
+}
+
+func (x *PeerPropagation_Producer) GetFirstBlocks() uint64 {
+	if x != nil {
+		return x.FirstBlocks
+	}
+	return 0
+}
+
+func (x *PeerPropagation_Producer) GetMeanDelayMs() int64 {
+	if x != nil {
+		return x.MeanDelayMs
+	}
+	return 0
+}
+
 var File_internal_cli_server_proto_server_proto protoreflect.FileDescriptor
 
 var file_internal_cli_server_proto_server_proto_rawDesc = []byte{
@@ -1667,8 +2330,8 @@ var file_internal_cli_server_proto_server_proto_rawDesc = []byte{
 	0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x36, 0x0a, 0x13, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53,
 	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
 	0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0x98,
-	0x01, 0x0a, 0x04, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0x89,
+	0x02, 0x0a, 0x04, 0x50, 0x65, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a,
 	0x03, 0x65, 0x6e, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x65, 0x6e, 0x72, 0x12,
@@ -1677,123 +2340,215 @@ var file_internal_cli_server_proto_server_proto_rawDesc = []byte{
 	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74,
 	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65,
 	0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x68, 0x61,
-	0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x43, 0x68, 0x61, 0x69,
-	0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x23, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x61, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x04, 0x57, 0x61, 0x69, 0x74, 0x22, 0xe2, 0x03, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0c, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x0d, 0x63,
-	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08,
-	0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x73, 0x79, 0x6e, 0x63,
-	0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x79, 0x6e, 0x63, 0x69, 0x6e,
-	0x67, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x05, 0x66, 0x6f,
-	0x72, 0x6b, 0x73, 0x1a, 0x4c, 0x0a, 0x04, 0x46, 0x6f, 0x72, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x1a, 0x77, 0x0a, 0x07, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0d,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x68, 0x69, 0x67, 0x68, 0x65, 0x73,
-	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x75,
-	0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x22, 0x34, 0x0a, 0x06, 0x48, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
-	0x22, 0xa2, 0x01, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62,
-	0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66,
-	0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x26, 0x0a,
-	0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4c, 0x4f, 0x4f, 0x4b, 0x55, 0x50, 0x10,
-	0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x54, 0x52,
-	0x41, 0x43, 0x45, 0x10, 0x02, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x22, 0xdd, 0x02, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
-	0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x36, 0x0a,
-	0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70,
+	0x08, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x12, 0x38, 0x0a, 0x0b, 0x70, 0x72, 0x6f,
+	0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x70, 0x61,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a,
+	0x72, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2d, 0x0a, 0x13, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x16, 0x0a, 0x14, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x23, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x57, 0x61, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x04, 0x57, 0x61, 0x69, 0x74, 0x22, 0x97, 0x04, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x0c, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0c,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x33, 0x0a, 0x0d,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x52, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x79, 0x6e, 0x63, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x73, 0x79, 0x6e,
+	0x63, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x73, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x67, 0x12, 0x30, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x46, 0x6f, 0x72, 0x6b, 0x52, 0x05, 0x66,
+	0x6f, 0x72, 0x6b, 0x73, 0x12, 0x33, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x4c, 0x69,
+	0x6e, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x53, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x0b, 0x73, 0x65,
+	0x6e, 0x74, 0x72, 0x79, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x1a, 0x4c, 0x0a, 0x04, 0x46, 0x6f, 0x72,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x1a, 0x77, 0x0a, 0x07, 0x53, 0x79, 0x6e, 0x63, 0x69,
+	0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x69, 0x67, 0x68,
+	0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
+	0x68, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x22, 0x34, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16,
+	0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xa2, 0x01, 0x0a, 0x11, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x22, 0x26, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x4c,
+	0x4f, 0x4f, 0x4b, 0x55, 0x50, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x54, 0x52, 0x41, 0x43, 0x45, 0x10, 0x02, 0x22, 0x2b, 0x0a, 0x11, 0x44,
+	0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0xdd, 0x02, 0x0a, 0x11, 0x44, 0x65, 0x62,
+	0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33,
+	0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x05,
-	0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x03, 0x65, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x48, 0x00, 0x52, 0x03, 0x65, 0x6f,
-	0x66, 0x1a, 0x88, 0x01, 0x0a, 0x04, 0x4f, 0x70, 0x65, 0x6e, 0x12, 0x44, 0x0a, 0x07, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73,
-	0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
-	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
-	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x1b, 0x0a, 0x05,
-	0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x32, 0xdb, 0x04, 0x0a, 0x03, 0x42, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x08, 0x50, 0x65,
-	0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73,
-	0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50,
-	0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52,
-	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a,
-	0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72,
-	0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
-	0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48,
-	0x65, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69,
-	0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x74,
-	0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x06,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63,
-	0x68, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57,
-	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75,
-	0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
-	0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46, 0x69,
-	0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a,
-	0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62,
-	0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01,
-	0x42, 0x1c, 0x5a, 0x1a, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x63, 0x6c,
-	0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x48, 0x00, 0x52, 0x04, 0x6f,
+	0x70, 0x65, 0x6e, 0x12, 0x36, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x48, 0x00, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x2a, 0x0a, 0x03, 0x65,
+	0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x48, 0x00, 0x52, 0x03, 0x65, 0x6f, 0x66, 0x1a, 0x88, 0x01, 0x0a, 0x04, 0x4f, 0x70, 0x65, 0x6e,
+	0x12, 0x44, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x2a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
+	0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x1b, 0x0a, 0x05, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42,
+	0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x8f, 0x04, 0x0a, 0x0f, 0x50, 0x65, 0x65,
+	0x72, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09,
+	0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0e, 0x66, 0x69, 0x72, 0x73, 0x74, 0x41, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63,
+	0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x6e, 0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x4c, 0x61,
+	0x67, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x61, 0x6e, 0x6e, 0x6f, 0x75,
+	0x6e, 0x63, 0x65, 0x4c, 0x61, 0x67, 0x4d, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74,
+	0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4c,
+	0x61, 0x67, 0x4d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4c, 0x61, 0x67, 0x4d, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x61, 0x6e, 0x44, 0x65,
+	0x6c, 0x61, 0x79, 0x4d, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x61,
+	0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x44,
+	0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61,
+	0x78, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x12, 0x3d, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x64, 0x75, 0x63, 0x65, 0x72, 0x73, 0x1a, 0x80, 0x01, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x61, 0x6e,
+	0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d,
+	0x65, 0x61, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x4d, 0x73, 0x22, 0xd8, 0x01, 0x0a, 0x0a, 0x53,
+	0x65, 0x6e, 0x74, 0x72, 0x79, 0x4c, 0x69, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e,
+	0x6f, 0x64, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x6c,
+	0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x61, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79,
+	0x4d, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x61, 0x6e, 0x44, 0x65,
+	0x6c, 0x61, 0x79, 0x4d, 0x73, 0x22, 0x78, 0x0a, 0x0e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x70,
+	0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62,
+	0x61, 0x6e, 0x6e, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x55,
+	0x6e, 0x74, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x61, 0x6e, 0x6e,
+	0x65, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22,
+	0x47, 0x0a, 0x07, 0x50, 0x65, 0x65, 0x72, 0x42, 0x61, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e,
+	0x74, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x59, 0x0a, 0x0f, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x42, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x22, 0x36, 0x0a, 0x10, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42, 0x61, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x04, 0x62, 0x61, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x42, 0x61, 0x6e, 0x52, 0x04, 0x62, 0x61, 0x6e, 0x73, 0x22, 0x29, 0x0a, 0x11, 0x50,
+	0x65, 0x65, 0x72, 0x73, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x2c, 0x0a, 0x12, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55,
+	0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x61,
+	0x6e, 0x6e, 0x65, 0x64, 0x32, 0xdb, 0x05, 0x0a, 0x03, 0x42, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x08,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x41, 0x64,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x50, 0x65, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3e, 0x0a, 0x09, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65,
+	0x65, 0x72, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x44, 0x0a, 0x0b, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42, 0x61,
+	0x6e, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42,
+	0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x42, 0x61, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55, 0x6e, 0x62, 0x61, 0x6e,
+	0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55, 0x6e,
+	0x62, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x65, 0x65, 0x72, 0x73, 0x55, 0x6e, 0x62, 0x61, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0c, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53, 0x65,
+	0x74, 0x48, 0x65, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x53,
+	0x65, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35,
+	0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x0a, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x0a, 0x44, 0x65,
+	0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x50, 0x70, 0x72, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x42,
+	0x0a, 0x0a, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x62, 0x75, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x65, 0x62, 0x75, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x30, 0x01, 0x42, 0x1c, 0x5a, 0x1a, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x63, 0x6c, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1810,75 +2565,93 @@ func file_internal_cli_server_proto_server_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_cli_server_proto_server_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_cli_server_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
+var file_internal_cli_server_proto_server_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_internal_cli_server_proto_server_proto_goTypes = []interface{}{
-	(DebugPprofRequest_Type)(0),     // 0: proto.DebugPprofRequest.Type
-	(*TraceRequest)(nil),            // 1: proto.TraceRequest
-	(*TraceResponse)(nil),           // 2: proto.TraceResponse
-	(*ChainWatchRequest)(nil),       // 3: proto.ChainWatchRequest
-	(*ChainWatchResponse)(nil),      // 4: proto.ChainWatchResponse
-	(*BlockStub)(nil),               // 5: proto.BlockStub
-	(*PeersAddRequest)(nil),         // 6: proto.PeersAddRequest
-	(*PeersAddResponse)(nil),        // 7: proto.PeersAddResponse
-	(*PeersRemoveRequest)(nil),      // 8: proto.PeersRemoveRequest
-	(*PeersRemoveResponse)(nil),     // 9: proto.PeersRemoveResponse
-	(*PeersListRequest)(nil),        // 10: proto.PeersListRequest
-	(*PeersListResponse)(nil),       // 11: proto.PeersListResponse
-	(*PeersStatusRequest)(nil),      // 12: proto.PeersStatusRequest
-	(*PeersStatusResponse)(nil),     // 13: proto.PeersStatusResponse
-	(*Peer)(nil),                    // 14: proto.Peer
-	(*ChainSetHeadRequest)(nil),     // 15: proto.ChainSetHeadRequest
-	(*ChainSetHeadResponse)(nil),    // 16: proto.ChainSetHeadResponse
-	(*StatusRequest)(nil),           // 17: proto.StatusRequest
-	(*StatusResponse)(nil),          // 18: proto.StatusResponse
-	(*Header)(nil),                  // 19: proto.Header
-	(*DebugPprofRequest)(nil),       // 20: proto.DebugPprofRequest
-	(*DebugBlockRequest)(nil),       // 21: proto.DebugBlockRequest
-	(*DebugFileResponse)(nil),       // 22: proto.DebugFileResponse
-	(*StatusResponse_Fork)(nil),     // 23: proto.StatusResponse.Fork
-	(*StatusResponse_Syncing)(nil),  // 24: proto.StatusResponse.Syncing
-	(*DebugFileResponse_Open)(nil),  // 25: proto.DebugFileResponse.Open
-	(*DebugFileResponse_Input)(nil), // 26: proto.DebugFileResponse.Input
-	nil,                             // 27: proto.DebugFileResponse.Open.HeadersEntry
-	(*emptypb.Empty)(nil),           // 28: google.protobuf.Empty
+	(DebugPprofRequest_Type)(0),      // 0: proto.DebugPprofRequest.Type
+	(*TraceRequest)(nil),             // 1: proto.TraceRequest
+	(*TraceResponse)(nil),            // 2: proto.TraceResponse
+	(*ChainWatchRequest)(nil),        // 3: proto.ChainWatchRequest
+	(*ChainWatchResponse)(nil),       // 4: proto.ChainWatchResponse
+	(*BlockStub)(nil),                // 5: proto.BlockStub
+	(*PeersAddRequest)(nil),          // 6: proto.PeersAddRequest
+	(*PeersAddResponse)(nil),         // 7: proto.PeersAddResponse
+	(*PeersRemoveRequest)(nil),       // 8: proto.PeersRemoveRequest
+	(*PeersRemoveResponse)(nil),      // 9: proto.PeersRemoveResponse
+	(*PeersListRequest)(nil),         // 10: proto.PeersListRequest
+	(*PeersListResponse)(nil),        // 11: proto.PeersListResponse
+	(*PeersStatusRequest)(nil),       // 12: proto.PeersStatusRequest
+	(*PeersStatusResponse)(nil),      // 13: proto.PeersStatusResponse
+	(*Peer)(nil),                     // 14: proto.Peer
+	(*ChainSetHeadRequest)(nil),      // 15: proto.ChainSetHeadRequest
+	(*ChainSetHeadResponse)(nil),     // 16: proto.ChainSetHeadResponse
+	(*StatusRequest)(nil),            // 17: proto.StatusRequest
+	(*StatusResponse)(nil),           // 18: proto.StatusResponse
+	(*Header)(nil),                   // 19: proto.Header
+	(*DebugPprofRequest)(nil),        // 20: proto.DebugPprofRequest
+	(*DebugBlockRequest)(nil),        // 21: proto.DebugBlockRequest
+	(*DebugFileResponse)(nil),        // 22: proto.DebugFileResponse
+	(*PeerPropagation)(nil),          // 23: proto.PeerPropagation
+	(*SentryLink)(nil),               // 24: proto.SentryLink
+	(*PeerReputation)(nil),           // 25: proto.PeerReputation
+	(*PeerBan)(nil),                  // 26: proto.PeerBan
+	(*PeersBanRequest)(nil),          // 27: proto.PeersBanRequest
+	(*PeersBanResponse)(nil),         // 28: proto.PeersBanResponse
+	(*PeersUnbanRequest)(nil),        // 29: proto.PeersUnbanRequest
+	(*PeersUnbanResponse)(nil),       // 30: proto.PeersUnbanResponse
+	(*StatusResponse_Fork)(nil),      // 31: proto.StatusResponse.Fork
+	(*StatusResponse_Syncing)(nil),   // 32: proto.StatusResponse.Syncing
+	(*DebugFileResponse_Open)(nil),   // 33: proto.DebugFileResponse.Open
+	(*DebugFileResponse_Input)(nil),  // 34: proto.DebugFileResponse.Input
+	nil,                              // 35: proto.DebugFileResponse.Open.HeadersEntry
+	(*PeerPropagation_Producer)(nil), // 36: proto.PeerPropagation.Producer
+	(*emptypb.Empty)(nil),            // 37: google.protobuf.Empty
 }
 var file_internal_cli_server_proto_server_proto_depIdxs = []int32{
 	5,  // 0: proto.ChainWatchResponse.oldchain:type_name -> proto.BlockStub
 	5,  // 1: proto.ChainWatchResponse.newchain:type_name -> proto.BlockStub
 	14, // 2: proto.PeersListResponse.peers:type_name -> proto.Peer
 	14, // 3: proto.PeersStatusResponse.peer:type_name -> proto.Peer
-	19, // 4: proto.StatusResponse.currentBlock:type_name -> proto.Header
-	19, // 5: proto.StatusResponse.currentHeader:type_name -> proto.Header
-	24, // 6: proto.StatusResponse.syncing:type_name -> proto.StatusResponse.Syncing
-	23, // 7: proto.StatusResponse.forks:type_name -> proto.StatusResponse.Fork
-	0,  // 8: proto.DebugPprofRequest.type:type_name -> proto.DebugPprofRequest.Type
-	25, // 9: proto.DebugFileResponse.open:type_name -> proto.DebugFileResponse.Open
-	26, // 10: proto.DebugFileResponse.input:type_name -> proto.DebugFileResponse.Input
-	28, // 11: proto.DebugFileResponse.eof:type_name -> google.protobuf.Empty
-	27, // 12: proto.DebugFileResponse.Open.headers:type_name -> proto.DebugFileResponse.Open.HeadersEntry
-	6,  // 13: proto.Bor.PeersAdd:input_type -> proto.PeersAddRequest
-	8,  // 14: proto.Bor.PeersRemove:input_type -> proto.PeersRemoveRequest
-	10, // 15: proto.Bor.PeersList:input_type -> proto.PeersListRequest
-	12, // 16: proto.Bor.PeersStatus:input_type -> proto.PeersStatusRequest
-	15, // 17: proto.Bor.ChainSetHead:input_type -> proto.ChainSetHeadRequest
-	17, // 18: proto.Bor.Status:input_type -> proto.StatusRequest
-	3,  // 19: proto.Bor.ChainWatch:input_type -> proto.ChainWatchRequest
-	20, // 20: proto.Bor.DebugPprof:input_type -> proto.DebugPprofRequest
-	21, // 21: proto.Bor.DebugBlock:input_type -> proto.DebugBlockRequest
-	7,  // 22: proto.Bor.PeersAdd:output_type -> proto.PeersAddResponse
-	9,  // 23: proto.Bor.PeersRemove:output_type -> proto.PeersRemoveResponse
-	11, // 24: proto.Bor.PeersList:output_type -> proto.PeersListResponse
-	13, // 25: proto.Bor.PeersStatus:output_type -> proto.PeersStatusResponse
-	16, // 26: proto.Bor.ChainSetHead:output_type -> proto.ChainSetHeadResponse
-	18, // 27: proto.Bor.Status:output_type -> proto.StatusResponse
-	4,  // 28: proto.Bor.ChainWatch:output_type -> proto.ChainWatchResponse
-	22, // 29: proto.Bor.DebugPprof:output_type -> proto.DebugFileResponse
-	22, // 30: proto.Bor.DebugBlock:output_type -> proto.DebugFileResponse
-	22, // [22:31] is the sub-list for method output_type
-	13, // [13:22] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	23, // 4: proto.Peer.propagation:type_name -> proto.PeerPropagation
+	25, // 5: proto.Peer.reputation:type_name -> proto.PeerReputation
+	19, // 6: proto.StatusResponse.currentBlock:type_name -> proto.Header
+	19, // 7: proto.StatusResponse.currentHeader:type_name -> proto.Header
+	32, // 8: proto.StatusResponse.syncing:type_name -> proto.StatusResponse.Syncing
+	31, // 9: proto.StatusResponse.forks:type_name -> proto.StatusResponse.Fork
+	24, // 10: proto.StatusResponse.sentryLinks:type_name -> proto.SentryLink
+	0,  // 11: proto.DebugPprofRequest.type:type_name -> proto.DebugPprofRequest.Type
+	33, // 12: proto.DebugFileResponse.open:type_name -> proto.DebugFileResponse.Open
+	34, // 13: proto.DebugFileResponse.input:type_name -> proto.DebugFileResponse.Input
+	37, // 14: proto.DebugFileResponse.eof:type_name -> google.protobuf.Empty
+	36, // 15: proto.PeerPropagation.producers:type_name -> proto.PeerPropagation.Producer
+	26, // 16: proto.PeersBanResponse.bans:type_name -> proto.PeerBan
+	35, // 17: proto.DebugFileResponse.Open.headers:type_name -> proto.DebugFileResponse.Open.HeadersEntry
+	6,  // 18: proto.Bor.PeersAdd:input_type -> proto.PeersAddRequest
+	8,  // 19: proto.Bor.PeersRemove:input_type -> proto.PeersRemoveRequest
+	10, // 20: proto.Bor.PeersList:input_type -> proto.PeersListRequest
+	12, // 21: proto.Bor.PeersStatus:input_type -> proto.PeersStatusRequest
+	27, // 22: proto.Bor.PeersBan:input_type -> proto.PeersBanRequest
+	29, // 23: proto.Bor.PeersUnban:input_type -> proto.PeersUnbanRequest
+	15, // 24: proto.Bor.ChainSetHead:input_type -> proto.ChainSetHeadRequest
+	17, // 25: proto.Bor.Status:input_type -> proto.StatusRequest
+	3,  // 26: proto.Bor.ChainWatch:input_type -> proto.ChainWatchRequest
+	20, // 27: proto.Bor.DebugPprof:input_type -> proto.DebugPprofRequest
+	21, // 28: proto.Bor.DebugBlock:input_type -> proto.DebugBlockRequest
+	7,  // 29: proto.Bor.PeersAdd:output_type -> proto.PeersAddResponse
+	9,  // 30: proto.Bor.PeersRemove:output_type -> proto.PeersRemoveResponse
+	11, // 31: proto.Bor.PeersList:output_type -> proto.PeersListResponse
+	13, // 32: proto.Bor.PeersStatus:output_type -> proto.PeersStatusResponse
+	28, // 33: proto.Bor.PeersBan:output_type -> proto.PeersBanResponse
+	30, // 34: proto.Bor.PeersUnban:output_type -> proto.PeersUnbanResponse
+	16, // 35: proto.Bor.ChainSetHead:output_type -> proto.ChainSetHeadResponse
+	18, // 36: proto.Bor.Status:output_type -> proto.StatusResponse
+	4,  // 37: proto.Bor.ChainWatch:output_type -> proto.ChainWatchResponse
+	22, // 38: proto.Bor.DebugPprof:output_type -> proto.DebugFileResponse
+	22, // 39: proto.Bor.DebugBlock:output_type -> proto.DebugFileResponse
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_internal_cli_server_proto_server_proto_init() }
@@ -2153,7 +2926,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_Fork); i {
+			switch v := v.(*PeerPropagation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2165,7 +2938,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_Syncing); i {
+			switch v := v.(*SentryLink); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2177,7 +2950,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DebugFileResponse_Open); i {
+			switch v := v.(*PeerReputation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2189,6 +2962,102 @@ func file_internal_cli_server_proto_server_proto_init() {
 			}
 		}
 		file_internal_cli_server_proto_server_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerBan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeersBanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeersBanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeersUnbanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeersUnbanResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Fork); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_Syncing); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DebugFileResponse_Open); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_cli_server_proto_server_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DebugFileResponse_Input); i {
 			case 0:
 				return &v.state
@@ -2200,6 +3069,18 @@ func file_internal_cli_server_proto_server_proto_init() {
 				return nil
 			}
 		}
+		file_internal_cli_server_proto_server_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PeerPropagation_Producer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 
 	file_internal_cli_server_proto_server_proto_msgTypes[21].OneofWrappers = []interface{}{
@@ -2215,7 +3096,7 @@ func file_internal_cli_server_proto_server_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_cli_server_proto_server_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   27,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -26,6 +26,8 @@ type BorClient interface {
 	PeersRemove(ctx context.Context, in *PeersRemoveRequest, opts ...grpc.CallOption) (*PeersRemoveResponse, error)
 	PeersList(ctx context.Context, in *PeersListRequest, opts ...grpc.CallOption) (*PeersListResponse, error)
 	PeersStatus(ctx context.Context, in *PeersStatusRequest, opts ...grpc.CallOption) (*PeersStatusResponse, error)
+	PeersBan(ctx context.Context, in *PeersBanRequest, opts ...grpc.CallOption) (*PeersBanResponse, error)
+	PeersUnban(ctx context.Context, in *PeersUnbanRequest, opts ...grpc.CallOption) (*PeersUnbanResponse, error)
 	ChainSetHead(ctx context.Context, in *ChainSetHeadRequest, opts ...grpc.CallOption) (*ChainSetHeadResponse, error)
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	ChainWatch(ctx context.Context, in *ChainWatchRequest, opts ...grpc.CallOption) (Bor_ChainWatchClient, error)
@@ -85,6 +87,28 @@ func (c *borClient) PeersStatus(ctx context.Context, in *PeersStatusRequest, opt
 	return out, nil
 }
 
+func (c *borClient) PeersBan(ctx context.Context, in *PeersBanRequest, opts ...grpc.CallOption) (*PeersBanResponse, error) {
+	out := new(PeersBanResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/PeersBan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+func (c *borClient) PeersUnban(ctx context.Context, in *PeersUnbanRequest, opts ...grpc.CallOption) (*PeersUnbanResponse, error) {
+	out := new(PeersUnbanResponse)
+
+	err := c.cc.Invoke(ctx, "/proto.Bor/PeersUnban", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (c *borClient) ChainSetHead(ctx context.Context, in *ChainSetHeadRequest, opts ...grpc.CallOption) (*ChainSetHeadResponse, error) {
 	out := new(ChainSetHeadResponse)
 
@@ -223,6 +247,8 @@ type BorServer interface {
 	PeersRemove(context.Context, *PeersRemoveRequest) (*PeersRemoveResponse, error)
 	PeersList(context.Context, *PeersListRequest) (*PeersListResponse, error)
 	PeersStatus(context.Context, *PeersStatusRequest) (*PeersStatusResponse, error)
+	PeersBan(context.Context, *PeersBanRequest) (*PeersBanResponse, error)
+	PeersUnban(context.Context, *PeersUnbanRequest) (*PeersUnbanResponse, error)
 	ChainSetHead(context.Context, *ChainSetHeadRequest) (*ChainSetHeadResponse, error)
 	Status(context.Context, *StatusRequest) (*StatusResponse, error)
 	ChainWatch(*ChainWatchRequest, Bor_ChainWatchServer) error
@@ -247,6 +273,12 @@ func (UnimplementedBorServer) PeersList(context.Context, *PeersListRequest) (*Pe
 func (UnimplementedBorServer) PeersStatus(context.Context, *PeersStatusRequest) (*PeersStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PeersStatus not implemented")
 }
+func (UnimplementedBorServer) PeersBan(context.Context, *PeersBanRequest) (*PeersBanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersBan not implemented")
+}
+func (UnimplementedBorServer) PeersUnban(context.Context, *PeersUnbanRequest) (*PeersUnbanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeersUnban not implemented")
+}
 func (UnimplementedBorServer) ChainSetHead(context.Context, *ChainSetHeadRequest) (*ChainSetHeadResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChainSetHead not implemented")
 }
@@ -359,6 +391,48 @@ func _Bor_PeersStatus_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bor_PeersBan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersBanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).PeersBan(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/PeersBan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).PeersBan(ctx, req.(*PeersBanRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Bor_PeersUnban_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeersUnbanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+
+	if interceptor == nil {
+		return srv.(BorServer).PeersUnban(ctx, in)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Bor/PeersUnban",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BorServer).PeersUnban(ctx, req.(*PeersUnbanRequest))
+	}
+
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Bor_ChainSetHead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChainSetHeadRequest)
 	if err := dec(in); err != nil {
@@ -490,6 +564,14 @@ var Bor_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PeersStatus",
 			Handler:    _Bor_PeersStatus_Handler,
 		},
+		{
+			MethodName: "PeersBan",
+			Handler:    _Bor_PeersBan_Handler,
+		},
+		{
+			MethodName: "PeersUnban",
+			Handler:    _Bor_PeersUnban_Handler,
+		},
 		{
 			MethodName: "ChainSetHead",
 			Handler:    _Bor_ChainSetHead_Handler,
@@ -25,10 +25,12 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/cmd/utils"
 	"github.com/ethereum/go-ethereum/consensus/beacon" //nolint:typecheck
 	"github.com/ethereum/go-ethereum/consensus/bor"    //nolint:typecheck
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdalldev"
 	"github.com/ethereum/go-ethereum/consensus/clique"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
@@ -178,6 +180,18 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 	// proceed to authorize the local account manager in any case
 	accountManager.AddBackend(keystore.NewKeyStore(keydir, n, p))
 
+	// seal with the keys of the remote signer if any, never held by the node
+	var externalSigner *external.ExternalBackend
+
+	if config.Accounts.ExternalSigner != "" {
+		externalSigner, err = newExternalSigner(config.Accounts)
+		if err != nil {
+			return nil, err
+		}
+
+		accountManager.AddBackend(externalSigner)
+	}
+
 	// flag to set if we're authorizing consensus here
 	authorized := false
 
@@ -189,6 +203,10 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 		// add keystore globally to the node's account manager if personal wallet is enabled
 		stack.AccountManager().AddBackend(keystore.NewKeyStore(keydir, n, p))
 
+		if externalSigner != nil {
+			stack.AccountManager().AddBackend(externalSigner)
+		}
+
 		// register the ethereum backend
 		ethCfg, err = config.buildEth(stack, stack.AccountManager())
 		if err != nil {
@@ -279,6 +297,13 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 
 	// debug tracing is enabled by default
 	stack.RegisterAPIs(tracers.APIs(srv.backend.APIBackend))
+
+	// the fake heimdall of the bor developer mode takes the injected state syncs
+	if engine, ok := srv.backend.Engine().(*bor.Bor); ok {
+		if heimdallClient, ok := engine.HeimdallClient.(*heimdalldev.HeimdallDevClient); ok {
+			stack.RegisterAPIs(heimdallClient.APIs())
+		}
+	}
 	srv.tracerAPI = tracers.NewAPI(srv.backend.APIBackend)
 
 	// graphql is started from another place
@@ -290,7 +315,12 @@ func NewServer(config *Config, opts ...serverOption) (*Server, error) {
 
 	// register ethash service
 	if config.Ethstats != "" {
-		if err := ethstats.New(stack, srv.backend.APIBackend, srv.backend.Engine(), config.Ethstats); err != nil {
+		newEthstats := ethstats.New
+		if config.EthstatsBor {
+			newEthstats = ethstats.NewWithBorStats
+		}
+
+		if err := newEthstats(stack, srv.backend.APIBackend, srv.backend.Engine(), config.Ethstats); err != nil {
 			return nil, err
 		}
 	}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/internal/cli/server/pprof"
@@ -155,11 +158,131 @@ func (s *Server) PeersStatus(ctx context.Context, req *proto.PeersStatusRequest)
 	resp := &proto.PeersStatusResponse{}
 	if peerInfo != nil {
 		resp.Peer = peerInfoToPeer(peerInfo)
+
+		if s.backend != nil {
+			resp.Peer.Propagation = propagationToProto(s.backend.PeerPropagation(peerInfo.ID))
+			resp.Peer.Reputation = reputationToProto(s.backend.PeerReputation(peerInfo.ID))
+		}
 	}
 
 	return resp, nil
 }
 
+func propagationToProto(summary *eth.PeerPropagation) *proto.PeerPropagation {
+	if summary == nil {
+		return nil
+	}
+
+	resp := &proto.PeerPropagation{
+		Announces:      summary.Announces,
+		FirstAnnounces: summary.FirstAnnounces,
+		AnnounceLagMs:  summary.AnnounceLag.Milliseconds(),
+		Blocks:         summary.Blocks,
+		FirstBlocks:    summary.FirstBlocks,
+		Duplicates:     summary.Duplicates,
+		Late:           summary.Late,
+		BlockLagMs:     summary.BlockLag.Milliseconds(),
+		MeanDelayMs:    summary.MeanDelay.Milliseconds(),
+		MaxDelayMs:     summary.MaxDelay.Milliseconds(),
+	}
+
+	for producer, stats := range summary.Producers {
+		resp.Producers = append(resp.Producers, &proto.PeerPropagation_Producer{
+			Address:     producer.Hex(),
+			Blocks:      stats.Blocks,
+			FirstBlocks: stats.FirstBlocks,
+			MeanDelayMs: stats.MeanDelay.Milliseconds(),
+		})
+	}
+
+	sort.Slice(resp.Producers, func(i, j int) bool {
+		return resp.Producers[i].Address < resp.Producers[j].Address
+	})
+
+	return resp
+}
+
+func reputationToProto(rep *eth.PeerReputation) *proto.PeerReputation {
+	resp := &proto.PeerReputation{
+		Score:  int64(rep.Score),
+		Banned: rep.Banned,
+		Reason: rep.Reason,
+	}
+
+	if rep.Banned {
+		resp.BannedUntil = rep.BannedUntil.Unix()
+	}
+
+	return resp
+}
+
+// parseNodeID returns the node ID of an enode URL, or of a node ID in hex.
+func parseNodeID(id string) (enode.ID, error) {
+	if strings.HasPrefix(id, "enode://") {
+		node, err := enode.Parse(enode.ValidSchemes, id)
+		if err != nil {
+			return enode.ID{}, fmt.Errorf("invalid enode: %v", err)
+		}
+
+		return node.ID(), nil
+	}
+
+	nodeID, err := enode.ParseID(id)
+	if err != nil {
+		return enode.ID{}, fmt.Errorf("invalid node id: %v", err)
+	}
+
+	return nodeID, nil
+}
+
+func (s *Server) PeersBan(ctx context.Context, req *proto.PeersBanRequest) (*proto.PeersBanResponse, error) {
+	if s.backend == nil {
+		return nil, ErrUnavailable
+	}
+
+	if req.Enode != "" {
+		id, err := parseNodeID(req.Enode)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Seconds <= 0 {
+			return nil, fmt.Errorf("invalid ban duration: %ds", req.Seconds)
+		}
+
+		reason := req.Reason
+		if reason == "" {
+			reason = "banned by the operator"
+		}
+
+		s.backend.BanPeer(id.String(), time.Duration(req.Seconds)*time.Second, reason)
+	}
+
+	resp := &proto.PeersBanResponse{}
+	for _, ban := range s.backend.PeerBans() {
+		resp.Bans = append(resp.Bans, &proto.PeerBan{
+			Id:     ban.ID,
+			Until:  ban.Until.Unix(),
+			Reason: ban.Reason,
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *Server) PeersUnban(ctx context.Context, req *proto.PeersUnbanRequest) (*proto.PeersUnbanResponse, error) {
+	if s.backend == nil {
+		return nil, ErrUnavailable
+	}
+
+	id, err := parseNodeID(req.Enode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.PeersUnbanResponse{Banned: s.backend.UnbanPeer(id.String())}, nil
+}
+
 func peerInfoToPeer(info *p2p.PeerInfo) *proto.Peer {
 	return &proto.Peer{
 		Id:      info.ID,
@@ -218,9 +341,61 @@ func (s *Server) Status(ctx context.Context, in *proto.StatusRequest) (*proto.St
 		Forks: gatherForks(s.config.chain.Genesis.Config, s.config.chain.Genesis.Config.Bor),
 	}
 
+	links, err := s.gatherSentryLinks()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.SentryLinks = links
+
 	return resp, nil
 }
 
+// gatherSentryLinks returns the health of the links to the private peers of the
+// sentry/validator topology, if the node is part of one.
+func (s *Server) gatherSentryLinks() ([]*proto.SentryLink, error) {
+	peers, err := s.config.P2P.Sentry.peers()
+	if err != nil {
+		return nil, err
+	}
+
+	// The links are labelled with the role of the remote end
+	role := ethconfig.SentryModeSentry
+	if s.config.P2P.Sentry.Mode == ethconfig.SentryModeSentry {
+		role = ethconfig.SentryModeValidator
+	}
+
+	connected := make(map[string]*p2p.PeerInfo)
+	for _, info := range s.node.Server().PeersInfo() {
+		connected[info.ID] = info
+	}
+
+	links := make([]*proto.SentryLink, 0, len(peers))
+
+	for _, peer := range peers {
+		link := &proto.SentryLink{
+			Role:  role,
+			Id:    peer.ID().String(),
+			Enode: peer.URLv4(),
+		}
+
+		if info, ok := connected[link.Id]; ok {
+			link.Connected = true
+			link.RemoteAddress = info.Network.RemoteAddress
+
+			if summary := s.backend.PeerPropagation(link.Id); summary != nil {
+				link.Blocks = summary.Blocks
+				link.Late = summary.Late
+				link.MeanDelayMs = summary.MeanDelay.Milliseconds()
+			}
+		}
+
+		links = append(links, link)
+	}
+
+	return links, nil
+}
+
 func headerToProtoHeader(h *types.Header) *proto.Header {
 	return &proto.Header{
 		Hash:   h.Hash().String(),
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// errSignerTLSScheme is returned when configuring certificates for a signer not reached over https.
+	errSignerTLSScheme = errors.New("signer certificates need an https signer url")
+
+	// errSignerKeyPair is returned when only one of the client certificate and key is configured.
+	errSignerKeyPair = errors.New("signer client certificate and key must be set together")
+)
+
+// newExternalSigner connects to the remote signer of the configuration,
+// authenticating with the client certificate if any.
+func newExternalSigner(config *AccountsConfig) (*external.ExternalBackend, error) {
+	var options []rpc.ClientOption
+
+	if config.ExternalSignerCert != "" || config.ExternalSignerKey != "" || config.ExternalSignerCA != "" {
+		client, err := newSignerHTTPClient(config)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, rpc.WithHTTPClient(client))
+	}
+
+	backend, err := external.NewExternalBackend(config.ExternalSigner, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the signer %s: %w", config.ExternalSigner, err)
+	}
+
+	log.Info("Using external signer", "url", config.ExternalSigner)
+
+	return backend, nil
+}
+
+// newSignerHTTPClient returns the http client of the signer, authenticating
+// with the client certificate and verifying the signer with the authority of
+// the configuration.
+func newSignerHTTPClient(config *AccountsConfig) (*http.Client, error) {
+	u, err := url.Parse(config.ExternalSigner)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != "https" {
+		return nil, errSignerTLSScheme
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if (config.ExternalSignerCert == "") != (config.ExternalSignerKey == "") {
+		return nil, errSignerKeyPair
+	}
+
+	if config.ExternalSignerCert != "" {
+		cert, err := tls.LoadX509KeyPair(config.ExternalSignerCert, config.ExternalSignerKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the signer client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if config.ExternalSignerCA != "" {
+		ca, err := os.ReadFile(config.ExternalSignerCA)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in %s", config.ExternalSignerCA)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}, nil
+}
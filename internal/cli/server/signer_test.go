@@ -0,0 +1,172 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/ethereum/go-ethereum/signer/rules"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+// headlessUI is the ui of a signer without operator, the sealing rules approve
+// the requests on their own.
+type headlessUI struct {
+	core.UIClientAPI
+}
+
+func (headlessUI) ShowError(message string)               {}
+func (headlessUI) RegisterUIServer(api *core.UIServerAPI) {}
+
+// writeClientCert writes a self-signed client certificate and its key to the given directory.
+func writeClientCert(t *testing.T, dir string) (*x509.Certificate, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "bor"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, "client.crt"), filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	return cert, certFile, keyFile
+}
+
+func TestExternalSigner(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// A signer holding the validator key, sealing the Bor headers only
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	keydir := filepath.Join(dir, "keystore")
+	account, err := keystore.NewKeyStore(keydir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "password")
+	require.NoError(t, err)
+
+	pwStorage := storage.NewEphemeralStorage()
+	pwStorage.Put(account.Address.Hex(), "password")
+
+	store, err := protection.Open("")
+	require.NoError(t, err)
+
+	ui := rules.NewBorSealingUI(headlessUI{}, store)
+	api := core.NewSignerAPI(core.StartClefAccountManager(keydir, true, true, ""), 1337, true, ui, nil, false, pwStorage)
+
+	rpcServer := rpc.NewServer("", 0, 0)
+	require.NoError(t, rpcServer.RegisterName("account", api))
+
+	// Served over mTLS
+	clientCert, certFile, keyFile := writeClientCert(t, dir)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	server := httptest.NewUnstartedServer(rpcServer)
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+
+	// Refused without a client certificate
+	_, err = newExternalSigner(&AccountsConfig{ExternalSigner: server.URL, ExternalSignerCA: caFile})
+	require.Error(t, err)
+
+	_, err = newExternalSigner(&AccountsConfig{ExternalSigner: server.URL, ExternalSignerCert: certFile, ExternalSignerCA: caFile})
+	require.ErrorIs(t, err, errSignerKeyPair)
+
+	_, err = newExternalSigner(&AccountsConfig{ExternalSigner: "http://127.0.0.1:1", ExternalSignerCA: caFile})
+	require.ErrorIs(t, err, errSignerTLSScheme)
+
+	backend, err := newExternalSigner(&AccountsConfig{
+		ExternalSigner:     server.URL,
+		ExternalSignerCert: certFile,
+		ExternalSignerKey:  keyFile,
+		ExternalSignerCA:   caFile,
+	})
+	require.NoError(t, err)
+
+	// The producer finds its key in the signer and seals with it
+	manager := accounts.NewManager(&accounts.Config{}, backend)
+	defer manager.Close()
+
+	wallet, err := manager.Find(accounts.Account{Address: account.Address})
+	require.NoError(t, err)
+
+	config := params.TestChainConfig.Bor
+	header := &types.Header{
+		Number:     big.NewInt(1),
+		Difficulty: big.NewInt(1),
+		Time:       uint64(time.Now().Unix()),
+		Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+	}
+
+	require.NoError(t, bor.Sign(wallet.SignData, account.Address, header, config))
+
+	pubkey, err := crypto.Ecrecover(bor.SealHash(header, config).Bytes(), header.Extra[types.ExtraVanityLength:])
+	require.NoError(t, err)
+	require.Equal(t, account.Address, common.BytesToAddress(crypto.Keccak256(pubkey[1:])[12:]))
+
+	// A conflicting block at the same height is refused once a block is sealed
+	// on top of the first one
+	child := &types.Header{
+		ParentHash: header.Hash(),
+		Number:     big.NewInt(2),
+		Difficulty: big.NewInt(1),
+		Time:       header.Time + 2,
+		Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+	}
+	require.NoError(t, bor.Sign(wallet.SignData, account.Address, child, config))
+
+	header.Time++
+	require.ErrorContains(t, bor.Sign(wallet.SignData, account.Address, header, config), protection.ErrConflict.Error())
+
+	// Anything else than sealing is refused
+	_, err = wallet.SignText(accounts.Account{Address: account.Address}, []byte("hello"))
+	require.Error(t, err)
+}
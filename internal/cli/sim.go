@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+// SimCommand is the command to group the simulation commands
+type SimCommand struct {
+	UI cli.Ui
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *SimCommand) MarkDown() string {
+	items := []string{
+		"# Sim",
+		"The ```sim``` command groups offline simulations of the Bor consensus:",
+		"- [```sim producers```](./sim_producers.md): Simulate the block production of a span.",
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *SimCommand) Help() string {
+	return `Usage: bor sim <subcommand>
+
+  This command groups offline simulations of the Bor consensus.
+
+  Simulate the block production of a span:
+
+    $ bor sim producers --validators 4 --availability 0.95`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *SimCommand) Synopsis() string {
+	return "Simulate the Bor consensus"
+}
+
+// Run implements the cli.Command interface
+func (c *SimCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
@@ -0,0 +1,315 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor/sim"
+	"github.com/ethereum/go-ethereum/internal/cli/flagset"
+	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/mitchellh/cli"
+)
+
+// simValidator is a validator of the validators file of the simulation
+type simValidator struct {
+	Address      common.Address `json:"address"`
+	Power        int64          `json:"power"`
+	Availability float64        `json:"availability"`
+	Latency      string         `json:"latency"`
+	Jitter       string         `json:"jitter"`
+}
+
+// SimProducersCommand is the command to simulate the block production of a span
+type SimProducersCommand struct {
+	UI cli.Ui
+
+	chain            string
+	start            uint64
+	spanLength       uint64
+	validatorsFile   string
+	validators       int
+	availability     float64
+	latency          time.Duration
+	jitter           time.Duration
+	period           uint64
+	producerDelay    uint64
+	sprint           uint64
+	backupMultiplier uint64
+	runs             int
+	seed             uint64
+}
+
+// MarkDown implements cli.MarkDown interface
+func (c *SimProducersCommand) MarkDown() string {
+	items := []string{
+		"# Sim producers",
+		"The ```sim producers``` command replays offline the proposer rotation of a span and the wiggle time of the backup producers, given the availability and the latency of each validator. It reports the expected block times, out-of-turn rates and reorg rates.",
+		"The validators can be listed in a JSON file, as objects with the ```address```, ```power```, ```availability```, ```latency``` and ```jitter``` fields, the durations being strings such as ```500ms```. Otherwise they share the same model, set by the flags.",
+		c.Flags().MarkDown(),
+	}
+
+	return strings.Join(items, "\n\n")
+}
+
+// Help implements the cli.Command interface
+func (c *SimProducersCommand) Help() string {
+	return `Usage: bor sim producers [--validators-file <file>]
+
+  This command simulates the block production of a span`
+}
+
+func (c *SimProducersCommand) Flags() *flagset.Flagset {
+	flags := flagset.NewFlagSet("sim producers")
+
+	flags.StringFlag(&flagset.StringFlag{
+		Name:    "chain",
+		Usage:   "Name of the chain or path to its genesis file, providing the consensus parameters",
+		Default: "mainnet",
+		Value:   &c.chain,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "start",
+		Usage: "First block of the span, defaults to the last scheduled change of the consensus parameters",
+		Value: &c.start,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:    "span-length",
+		Usage:   "Number of blocks of the span",
+		Default: 6400,
+		Value:   &c.spanLength,
+	})
+	flags.StringFlag(&flagset.StringFlag{
+		Name:  "validators-file",
+		Usage: "JSON file listing the validators and their models",
+		Value: &c.validatorsFile,
+	})
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "validators",
+		Usage:   "Number of validators with the same power, if no validators file is given",
+		Default: 4,
+		Value:   &c.validators,
+	})
+	flags.Float64Flag(&flagset.Float64Flag{
+		Name:    "availability",
+		Usage:   "Probability for the validators to be online at their turn, if no validators file is given",
+		Default: 1,
+		Value:   &c.availability,
+	})
+	flags.DurationFlag(&flagset.DurationFlag{
+		Name:    "latency",
+		Usage:   "Time for the blocks to reach the network, if no validators file is given",
+		Default: 500 * time.Millisecond,
+		Value:   &c.latency,
+	})
+	flags.DurationFlag(&flagset.DurationFlag{
+		Name:    "jitter",
+		Usage:   "Maximum random delay added to the latency, if no validators file is given",
+		Default: 500 * time.Millisecond,
+		Value:   &c.jitter,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "period",
+		Usage: "Block period to simulate instead of the one of the chain",
+		Value: &c.period,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "producer-delay",
+		Usage: "Producer delay to simulate instead of the one of the chain",
+		Value: &c.producerDelay,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "sprint",
+		Usage: "Sprint length to simulate instead of the one of the chain",
+		Value: &c.sprint,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "backup-multiplier",
+		Usage: "Backup multiplier to simulate instead of the one of the chain",
+		Value: &c.backupMultiplier,
+	})
+	flags.IntFlag(&flagset.IntFlag{
+		Name:    "runs",
+		Usage:   "Number of times the span is replayed",
+		Default: 100,
+		Value:   &c.runs,
+	})
+	flags.Uint64Flag(&flagset.Uint64Flag{
+		Name:  "seed",
+		Usage: "Seed of the random outcomes",
+		Value: &c.seed,
+	})
+
+	return flags
+}
+
+// Synopsis implements the cli.Command interface
+func (c *SimProducersCommand) Synopsis() string {
+	return "Simulate the block production of a span"
+}
+
+// Run implements the cli.Command interface
+func (c *SimProducersCommand) Run(args []string) int {
+	flags := c.Flags()
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	chain, err := chains.GetChain(c.chain)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if chain.Genesis.Config.Bor == nil {
+		c.UI.Error(fmt.Sprintf("Chain %s has no Bor config", c.chain))
+		return 1
+	}
+
+	if err := chain.Genesis.Config.CheckBorConfig(); err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid Bor config:\n%v", err))
+		return 1
+	}
+
+	if c.spanLength == 0 {
+		c.UI.Error("The span must have at least one block")
+		return 1
+	}
+
+	start := c.start
+	if start == 0 {
+		schedule := chain.Genesis.Config.Bor.Schedule()
+		start = schedule[len(schedule)-1]
+	}
+
+	validators, err := c.simValidators()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	config := c.borConfig(chain.Genesis.Config.Bor, start)
+	if err := config.Validate(nil); err != nil {
+		c.UI.Error(fmt.Sprintf("Invalid consensus parameters:\n%v", err))
+		return 1
+	}
+
+	result, err := sim.Run(&sim.Config{
+		Bor:        config,
+		Validators: validators,
+		StartBlock: start,
+		EndBlock:   start + c.spanLength - 1,
+		Runs:       c.runs,
+		Seed:       int64(c.seed),
+	})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Failed to simulate: %v", err))
+		return 1
+	}
+
+	c.UI.Output(formatKV([]string{
+		fmt.Sprintf("Span|%d - %d", start, start+c.spanLength-1),
+		fmt.Sprintf("Blocks|%d", result.Blocks),
+		fmt.Sprintf("Mean block time|%s", result.MeanBlockTime),
+		fmt.Sprintf("Max block time|%s", result.MaxBlockTime),
+		fmt.Sprintf("Out-of-turn rate|%.4f", result.OutOfTurnRate),
+		fmt.Sprintf("Reorg rate|%.4f", result.ReorgRate),
+	}))
+	c.UI.Output("")
+
+	rows := []string{"Validator|In turn|Out of turn|Missed|Reorged"}
+	for _, p := range result.Producers {
+		rows = append(rows, fmt.Sprintf("%s|%d|%d|%d|%d", p.Address, p.InTurn, p.OutOfTurn, p.Missed, p.Reorged))
+	}
+
+	c.UI.Output(formatList(rows))
+
+	return 0
+}
+
+// borConfig returns the consensus parameters of the chain at the given block,
+// with the ones set by the flags replaced.
+func (c *SimProducersCommand) borConfig(config *params.BorConfig, number uint64) *params.BorConfig {
+	current := config.ParamsAt(number)
+
+	schedule := func(override, value uint64) map[string]uint64 {
+		if override != 0 {
+			value = override
+		}
+
+		return map[string]uint64{"0": value}
+	}
+
+	return &params.BorConfig{
+		Period:           schedule(c.period, current.Period),
+		ProducerDelay:    schedule(c.producerDelay, current.ProducerDelay),
+		Sprint:           schedule(c.sprint, current.Sprint),
+		BackupMultiplier: schedule(c.backupMultiplier, current.BackupMultiplier),
+	}
+}
+
+// simValidators returns the validators of the file, or the ones modeled by the flags.
+func (c *SimProducersCommand) simValidators() ([]sim.Validator, error) {
+	if c.validatorsFile == "" {
+		validators := make([]sim.Validator, 0, c.validators)
+
+		for i := 1; i <= c.validators; i++ {
+			validators = append(validators, sim.Validator{
+				Address:      common.BigToAddress(big.NewInt(int64(i))),
+				Power:        1,
+				Availability: c.availability,
+				Latency:      c.latency,
+				Jitter:       c.jitter,
+			})
+		}
+
+		return validators, nil
+	}
+
+	data, err := os.ReadFile(c.validatorsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read validators file: %v", err)
+	}
+
+	var entries []simValidator
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse validators file: %v", err)
+	}
+
+	validators := make([]sim.Validator, 0, len(entries))
+
+	for _, entry := range entries {
+		v := sim.Validator{
+			Address:      entry.Address,
+			Power:        entry.Power,
+			Availability: entry.Availability,
+		}
+
+		for _, d := range []struct {
+			value string
+			dst   *time.Duration
+		}{
+			{entry.Latency, &v.Latency},
+			{entry.Jitter, &v.Jitter},
+		} {
+			if d.value == "" {
+				continue
+			}
+
+			if *d.dst, err = time.ParseDuration(d.value); err != nil {
+				return nil, fmt.Errorf("invalid duration of validator %s: %v", entry.Address, err)
+			}
+		}
+
+		validators = append(validators, v)
+	}
+
+	return validators, nil
+}
@@ -111,5 +111,16 @@ func printStatus(status *proto.StatusResponse) string {
 		formatList(forks),
 	}
 
+	if len(status.SentryLinks) > 0 {
+		links := make([]string, len(status.SentryLinks)+1)
+		links[0] = "Role|ID|Connected|Remote address|Blocks|Late|Mean delay"
+
+		for i, l := range status.SentryLinks {
+			links[i+1] = fmt.Sprintf("%s|%s|%v|%s|%d|%d|%dms", l.Role, l.Id, l.Connected, l.RemoteAddress, l.Blocks, l.Late, l.MeanDelayMs)
+		}
+
+		full = append(full, "\nSentry links", formatList(links))
+	}
+
 	return strings.Join(full, "\n")
 }
@@ -0,0 +1,529 @@
+// Package devnet runs a local network of Bor validators in-process, connected
+// over simulated p2p and sharing a fake Heimdall. It lets integration tests
+// script producer outages, network partitions, span rotations and milestones,
+// and check the resulting reorgs and finality.
+package devnet
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/milestone"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdalldev"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/downloader"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/internal/cli/server/chains"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/p2p/simulations"
+	"github.com/ethereum/go-ethereum/p2p/simulations/adapters"
+)
+
+const (
+	// serviceName is the name of the Bor service run by the simulation nodes.
+	serviceName = "bor"
+
+	// connTimeout is the time allowed to the nodes to connect or disconnect. It
+	// covers the time the p2p server waits before dialing again a dropped peer.
+	connTimeout = time.Minute
+
+	// pollInterval is the interval between two checks of a waited condition.
+	pollInterval = 100 * time.Millisecond
+)
+
+var (
+	// errNoValidators is returned when creating a network without validators.
+	errNoValidators = errors.New("devnet needs at least one validator")
+
+	// errUnknownValidator is returned when referring to a validator out of range.
+	errUnknownValidator = errors.New("unknown validator")
+
+	// errInvalidPartition is returned when the groups of a partition don't
+	// cover each validator exactly once.
+	errInvalidPartition = errors.New("partition groups must cover each validator exactly once")
+
+	// errMissingBlock is returned when a milestone ends past the local chain.
+	errMissingBlock = errors.New("milestone end block not available")
+)
+
+// Config is the configuration of a local network.
+type Config struct {
+	Validators int    // Number of validators
+	Period     uint64 // Block period, in seconds
+	GasLimit   uint64 // Gas limit of the genesis block
+
+	SigningProtection bool // Refuse to sign blocks conflicting with the released ones
+}
+
+// DefaultConfig is the default configuration of a local network.
+var DefaultConfig = Config{
+	Validators: 3,
+	Period:     1,
+	GasLimit:   30_000_000,
+}
+
+// Validator is a Bor validator of the network, running in its own node.
+type Validator struct {
+	Index   int
+	ID      enode.ID
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+	Backend *eth.Ethereum
+
+	lock          sync.Mutex
+	maxReorgDepth uint64
+}
+
+// MaxReorgDepth returns the depth of the deepest reorg the validator went through.
+func (v *Validator) MaxReorgDepth() uint64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.maxReorgDepth
+}
+
+// Head returns the number of the head block of the validator.
+func (v *Validator) Head() uint64 {
+	return v.Backend.BlockChain().CurrentBlock().Number.Uint64()
+}
+
+// Finalized returns the end block of the milestone whitelisted by the validator,
+// if any.
+func (v *Validator) Finalized() (uint64, common.Hash, bool) {
+	exists, number, hash := v.Backend.APIBackend.GetWhitelistedMilestone()
+	return number, hash, exists
+}
+
+// trackReorgs follows the head of the validator, recording the depth of the
+// reorgs until the subscription ends.
+func (v *Validator) trackReorgs() {
+	chain := v.Backend.BlockChain()
+
+	headCh := make(chan core.ChainHeadEvent, 64)
+	sub := chain.SubscribeChainHeadEvent(headCh)
+
+	defer sub.Unsubscribe()
+
+	prev := chain.CurrentBlock()
+
+	for {
+		select {
+		case ev := <-headCh:
+			head := ev.Block.Header()
+
+			// Rewind the previous head until it's back on the canonical chain
+			ancestor := prev
+			for ancestor != nil && chain.GetCanonicalHash(ancestor.Number.Uint64()) != ancestor.Hash() {
+				ancestor = chain.GetHeader(ancestor.ParentHash, ancestor.Number.Uint64()-1)
+			}
+
+			if ancestor != nil {
+				if depth := prev.Number.Uint64() - ancestor.Number.Uint64(); depth > 0 {
+					log.Info("Validator reorged", "validator", v.Index, "depth", depth, "head", head.Number)
+
+					v.lock.Lock()
+					if depth > v.maxReorgDepth {
+						v.maxReorgDepth = depth
+					}
+					v.lock.Unlock()
+				}
+			}
+
+			prev = head
+		case <-sub.Err():
+			return
+		}
+	}
+}
+
+// Devnet is a local network of Bor validators.
+type Devnet struct {
+	Genesis    *core.Genesis
+	Heimdall   *heimdalldev.HeimdallDevClient
+	Validators []*Validator
+
+	config  Config
+	network *simulations.Network
+}
+
+// New creates a local network with the given configuration, generating a key
+// for each validator. The network must be started before use.
+func New(config Config) (*Devnet, error) {
+	if config.Validators < 1 {
+		return nil, errNoValidators
+	}
+
+	d := &Devnet{config: config}
+
+	addresses := make([]common.Address, 0, config.Validators)
+
+	for i := 0; i < config.Validators; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		v := &Validator{
+			Index:   i,
+			ID:      enode.PubkeyToIDV4(&key.PublicKey),
+			Key:     key,
+			Address: crypto.PubkeyToAddress(key.PublicKey),
+		}
+		d.Validators = append(d.Validators, v)
+		addresses = append(addresses, v.Address)
+	}
+
+	// The first validator produces the span 0 of the validator contract, all
+	// of them take over from the first span committed
+	d.Genesis = chains.GetDeveloperBorChain(config.Period, config.GasLimit, addresses[0]).Genesis
+
+	for _, address := range addresses {
+		d.Genesis.Alloc[address] = core.GenesisAccount{Balance: new(big.Int).Lsh(big.NewInt(1), 128)}
+	}
+
+	d.Heimdall = heimdalldev.NewHeimdallDevClient(d.Genesis.Config, addresses...)
+
+	adapter := adapters.NewSimAdapter(adapters.LifecycleConstructors{serviceName: d.newBackend})
+	d.network = simulations.NewNetwork(adapter, &simulations.NetworkConfig{ID: "bor-devnet", DefaultService: serviceName})
+
+	for _, v := range d.Validators {
+		nodeConfig := adapters.RandomNodeConfig()
+		nodeConfig.ID = v.ID
+		nodeConfig.PrivateKey = v.Key
+		nodeConfig.Name = fmt.Sprintf("validator-%d", v.Index)
+		nodeConfig.Lifecycles = []string{serviceName}
+
+		if _, err := d.network.NewNodeWithConfig(nodeConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// newBackend creates the Bor backend of a validator node, sealing with the key
+// of the validator.
+func (d *Devnet) newBackend(ctx *adapters.ServiceContext, stack *node.Node) (node.Lifecycle, error) {
+	var v *Validator
+
+	for _, validator := range d.Validators {
+		if validator.ID == ctx.Config.ID {
+			v = validator
+		}
+	}
+
+	if v == nil {
+		return nil, fmt.Errorf("%w: %s", errUnknownValidator, ctx.Config.ID)
+	}
+
+	config := ethconfig.Defaults
+	config.Genesis = d.Genesis
+	config.NetworkId = d.Genesis.Config.ChainID.Uint64()
+	config.SyncMode = downloader.FullSync
+	config.DevHeimdall = true
+	config.Miner.Etherbase = v.Address
+	config.Miner.GasCeil = d.config.GasLimit
+	config.SigningProtection = d.config.SigningProtection
+
+	backend, err := eth.New(stack, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	// All the validators share the same Heimdall
+	engine := backend.Engine().(*bor.Bor)
+	engine.SetHeimdallClient(d.Heimdall)
+
+	engine.Authorize(v.Address, func(_ accounts.Account, _ string, data []byte) ([]byte, error) {
+		return crypto.Sign(crypto.Keccak256(data), v.Key)
+	})
+	backend.SetAuthorized(true)
+
+	v.Backend = backend
+
+	return backend, nil
+}
+
+// Start starts the validators, connects all of them to each other and starts
+// sealing blocks.
+func (d *Devnet) Start() error {
+	if err := d.network.StartAll(); err != nil {
+		return err
+	}
+
+	for _, v := range d.Validators {
+		go v.trackReorgs()
+	}
+
+	if err := d.Heal(); err != nil {
+		return err
+	}
+
+	for _, v := range d.Validators {
+		if err := v.Backend.StartMining(); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Started Bor devnet", "validators", len(d.Validators))
+
+	return nil
+}
+
+// Stop stops all the validators.
+func (d *Devnet) Stop() {
+	d.network.Shutdown()
+}
+
+// validator returns the validator with the given index.
+func (d *Devnet) validator(i int) (*Validator, error) {
+	if i < 0 || i >= len(d.Validators) {
+		return nil, fmt.Errorf("%w: %d", errUnknownValidator, i)
+	}
+
+	return d.Validators[i], nil
+}
+
+// Outage stops the given validator from sealing blocks, while it keeps following
+// the chain.
+func (d *Devnet) Outage(i int) error {
+	v, err := d.validator(i)
+	if err != nil {
+		return err
+	}
+
+	v.Backend.StopMining()
+	log.Info("Validator outage", "validator", i)
+
+	return nil
+}
+
+// Resume restarts sealing blocks on the given validator after an outage.
+func (d *Devnet) Resume(i int) error {
+	v, err := d.validator(i)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Validator resumed", "validator", i)
+
+	return v.Backend.StartMining()
+}
+
+// Partition splits the network into the given groups of validators, so that
+// they only connect to the validators of their own group.
+func (d *Devnet) Partition(groups ...[]int) error {
+	group := make(map[int]int)
+
+	for g, indexes := range groups {
+		for _, i := range indexes {
+			if _, err := d.validator(i); err != nil {
+				return err
+			}
+
+			if _, ok := group[i]; ok {
+				return errInvalidPartition
+			}
+
+			group[i] = g
+		}
+	}
+
+	if len(group) != len(d.Validators) {
+		return errInvalidPartition
+	}
+
+	log.Info("Partitioning devnet", "groups", groups)
+
+	return d.link(func(i, j int) bool { return group[i] == group[j] })
+}
+
+// Heal connects all the validators to each other. The validators separated
+// by a partition reconnect once their recent dial history expires, which can
+// take up to about 35 seconds.
+func (d *Devnet) Heal() error {
+	return d.link(func(i, j int) bool { return true })
+}
+
+// link connects the pairs of validators for which linked is true and
+// disconnects the others, waiting for the connections to settle.
+func (d *Devnet) link(linked func(i, j int) bool) error {
+	for i, one := range d.Validators {
+		for j := i + 1; j < len(d.Validators); j++ {
+			other := d.Validators[j]
+			up := d.connected(one, other)
+
+			var err error
+
+			if want := linked(i, j); want && !up {
+				err = d.network.Connect(one.ID, other.ID)
+			} else if !want && up {
+				err = d.network.Disconnect(one.ID, other.ID)
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	deadline := time.Now().Add(connTimeout)
+
+	for i, one := range d.Validators {
+		for j := i + 1; j < len(d.Validators); j++ {
+			for d.connected(one, d.Validators[j]) != linked(i, j) {
+				if time.Now().After(deadline) {
+					return fmt.Errorf("timeout linking validators %d and %d", i, j)
+				}
+
+				time.Sleep(pollInterval)
+			}
+		}
+	}
+
+	return nil
+}
+
+// connected tells if the two validators are connected to each other.
+func (d *Devnet) connected(one, other *Validator) bool {
+	conn := d.network.GetConn(one.ID, other.ID)
+	return conn != nil && conn.Up
+}
+
+// RotateValidators makes the given validators produce the spans from the next
+// one fetched.
+func (d *Devnet) RotateValidators(indexes ...int) error {
+	addresses := make([]common.Address, 0, len(indexes))
+
+	for _, i := range indexes {
+		v, err := d.validator(i)
+		if err != nil {
+			return err
+		}
+
+		addresses = append(addresses, v.Address)
+	}
+
+	log.Info("Rotating devnet validators", "validators", indexes)
+
+	return d.Heimdall.SetValidators(addresses)
+}
+
+// Milestone adds a milestone to Heimdall, ending at the given block of the chain
+// of the given validator and starting after the previous milestone.
+func (d *Devnet) Milestone(i int, end uint64) (*milestone.Milestone, error) {
+	v, err := d.validator(i)
+	if err != nil {
+		return nil, err
+	}
+
+	header := v.Backend.BlockChain().GetHeaderByNumber(end)
+	if header == nil {
+		return nil, fmt.Errorf("%w: %d", errMissingBlock, end)
+	}
+
+	start := uint64(0)
+	if latest, err := d.Heimdall.FetchMilestone(context.Background()); err == nil {
+		start = latest.EndBlock.Uint64() + 1
+	}
+
+	m := &milestone.Milestone{
+		Proposer:   v.Address,
+		StartBlock: new(big.Int).SetUint64(start),
+		EndBlock:   new(big.Int).SetUint64(end),
+		Hash:       header.Hash(),
+		Timestamp:  uint64(time.Now().Unix()),
+	}
+	d.Heimdall.AddMilestone(m)
+
+	log.Info("Added devnet milestone", "start", start, "end", end, "hash", m.Hash)
+
+	return m, nil
+}
+
+// WaitBlock waits until all the validators reach the given block.
+func (d *Devnet) WaitBlock(ctx context.Context, number uint64) error {
+	return d.wait(ctx, func() bool {
+		for _, v := range d.Validators {
+			if v.Head() < number {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// WaitFinalized waits until the given validator whitelists a milestone ending
+// at or past the given block.
+func (d *Devnet) WaitFinalized(ctx context.Context, i int, number uint64) error {
+	v, err := d.validator(i)
+	if err != nil {
+		return err
+	}
+
+	return d.wait(ctx, func() bool {
+		finalized, _, ok := v.Finalized()
+		return ok && finalized >= number
+	})
+}
+
+// CheckReorgDepth returns an error if any validator went through a reorg
+// deeper than the given depth.
+func (d *Devnet) CheckReorgDepth(max uint64) error {
+	for _, v := range d.Validators {
+		if depth := v.MaxReorgDepth(); depth > max {
+			return fmt.Errorf("validator %d reorged %d blocks, more than %d", v.Index, depth, max)
+		}
+	}
+
+	return nil
+}
+
+// CheckConsensus returns an error if the validators don't agree on the given block.
+func (d *Devnet) CheckConsensus(number uint64) error {
+	var hash common.Hash
+
+	for _, v := range d.Validators {
+		header := v.Backend.BlockChain().GetHeaderByNumber(number)
+		if header == nil {
+			return fmt.Errorf("validator %d: %w: %d", v.Index, errMissingBlock, number)
+		}
+
+		if v.Index == 0 {
+			hash = header.Hash()
+		} else if header.Hash() != hash {
+			return fmt.Errorf("validator %d disagrees on block %d: have %x, want %x", v.Index, number, header.Hash(), hash)
+		}
+	}
+
+	return nil
+}
+
+// wait polls the given condition until it holds or the context is done.
+func (d *Devnet) wait(ctx context.Context, cond func() bool) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for !cond() {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/span"
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func newTestDevnet(t *testing.T, validators int) *Devnet {
+	t.Helper()
+
+	config := DefaultConfig
+	config.Validators = validators
+
+	d, err := New(config)
+	require.NoError(t, err)
+	require.NoError(t, d.Start())
+
+	t.Cleanup(d.Stop)
+
+	return d
+}
+
+// TestPartition checks that the validators converge on the same chain after
+// a partition is healed, and finalize it with a milestone.
+func TestPartition(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDevnet(t, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	// Wait for the first span with all the validators
+	require.NoError(t, d.WaitBlock(ctx, 12))
+	require.NoError(t, d.CheckConsensus(12))
+
+	require.NoError(t, d.Partition([]int{0}, []int{1, 2}))
+	time.Sleep(4 * time.Second)
+	require.NoError(t, d.Heal())
+
+	head := d.Validators[1].Head() + 4
+	require.NoError(t, d.WaitBlock(ctx, head))
+	require.NoError(t, d.CheckConsensus(head))
+
+	_, err := d.Milestone(1, head)
+	require.NoError(t, err)
+
+	for i := range d.Validators {
+		require.NoError(t, d.WaitFinalized(ctx, i, head))
+	}
+
+	require.NoError(t, d.CheckReorgDepth(16))
+}
+
+// TestInvalidPartition checks that the partitions must cover each validator
+// exactly once.
+func TestInvalidPartition(t *testing.T) {
+	t.Parallel()
+
+	d, err := New(DefaultConfig)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, d.Partition([]int{0, 1}), errInvalidPartition)
+	require.ErrorIs(t, d.Partition([]int{0, 1}, []int{1, 2}), errInvalidPartition)
+	require.ErrorIs(t, d.Partition([]int{0, 1}, []int{3}), errUnknownValidator)
+}
+
+// TestVerifySpan checks that the spans read back from the validator contract
+// match the ones served by Heimdall and the validator sets of the snapshots.
+func TestVerifySpan(t *testing.T) {
+	t.Parallel()
+
+	d := newTestDevnet(t, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// Wait for the first span fetched from Heimdall to take over
+	require.NoError(t, d.WaitBlock(ctx, 12))
+
+	backend := d.Validators[0].Backend
+	engine := backend.Engine().(*bor.Bor)
+
+	report, err := engine.VerifySpan(ctx, backend.BlockChain(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"heimdall", "snapshot"}, report.Sources)
+	require.Empty(t, report.Mismatches)
+	require.Len(t, report.Span.SelectedProducers, 2)
+
+	_, err = engine.VerifySpan(ctx, backend.BlockChain(), 1000)
+	require.ErrorIs(t, err, span.ErrSpanNotFound)
+}
+
+// TestSigningProtection checks that a validator refuses to sign a block
+// conflicting with one it already released.
+func TestSigningProtection(t *testing.T) {
+	t.Parallel()
+
+	config := DefaultConfig
+	config.Validators = 1
+	config.SigningProtection = true
+
+	d, err := New(config)
+	require.NoError(t, err)
+	require.NoError(t, d.Start())
+
+	t.Cleanup(d.Stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	require.NoError(t, d.WaitBlock(ctx, 4))
+
+	backend := d.Validators[0].Backend
+	chain := backend.BlockChain()
+	block := chain.GetBlockByNumber(3)
+
+	header := types.CopyHeader(block.Header())
+	header.Time++
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	err = backend.Engine().Seal(ctx, chain, block.WithSeal(header), make(chan *types.Block, 1), stop)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, protection.ErrConflict) || errors.Is(err, protection.ErrRegression), err)
+}
@@ -0,0 +1,131 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// CheckpointFetcher retrieves the checkpoints submitted by Heimdall.
+type CheckpointFetcher interface {
+	FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error)
+	FetchCheckpointCount(ctx context.Context) (int64, error)
+}
+
+// Coverage is the range of blocks anchored by verified checkpoints.
+type Coverage struct {
+	Checkpoints int    // Number of checkpoints verified
+	First       uint64 // First block covered by the verified checkpoints
+	Last        uint64 // Last block covered by the verified checkpoints
+}
+
+// VerifyCheckpoints checks the given contiguous headers against the checkpoints
+// covering them, returning the range of blocks they anchor. Checkpoints only
+// partially covering the headers are skipped, the blocks they cover are left
+// out of the range.
+func VerifyCheckpoints(ctx context.Context, fetcher CheckpointFetcher, headers []*types.Header) (Coverage, error) {
+	var coverage Coverage
+
+	if len(headers) == 0 {
+		return coverage, nil
+	}
+
+	count, err := fetcher.FetchCheckpointCount(ctx)
+	if err != nil {
+		return coverage, err
+	}
+
+	var (
+		first = headers[0].Number.Uint64()
+		last  = headers[len(headers)-1].Number.Uint64()
+		cache = make(map[int64]*checkpoint.Checkpoint)
+	)
+
+	fetch := func(number int64) (*checkpoint.Checkpoint, error) {
+		if cp, ok := cache[number]; ok {
+			return cp, nil
+		}
+
+		cp, err := fetcher.FetchCheckpoint(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+
+		cache[number] = cp
+
+		return cp, nil
+	}
+
+	// Find the first checkpoint starting within the headers, checkpoints are
+	// numbered from one and cover increasing block ranges
+	var searchErr error
+
+	number := int64(sort.Search(int(count), func(i int) bool {
+		if searchErr != nil {
+			return true
+		}
+
+		cp, err := fetch(int64(i + 1))
+		if err != nil {
+			searchErr = err
+			return true
+		}
+
+		return cp.StartBlock.Uint64() >= first
+	})) + 1
+
+	if searchErr != nil {
+		return coverage, searchErr
+	}
+
+	for ; number <= count; number++ {
+		cp, err := fetch(number)
+		if err != nil {
+			return coverage, err
+		}
+
+		start, end := cp.StartBlock.Uint64(), cp.EndBlock.Uint64()
+		if end > last {
+			break
+		}
+
+		root, err := bor.ComputeRootHash(headers[start-first : end-first+1])
+		if err != nil {
+			return coverage, err
+		}
+
+		if common.BytesToHash(root) != cp.RootHash {
+			return coverage, fmt.Errorf("checkpoint %d root mismatch for blocks #%d-#%d: have %x, want %x", number, start, end, root, cp.RootHash)
+		}
+
+		if coverage.Checkpoints == 0 {
+			coverage.First = start
+		}
+
+		coverage.Checkpoints++
+		coverage.Last = end
+	}
+
+	return coverage, nil
+}
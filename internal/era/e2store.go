@@ -0,0 +1,97 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	headerSize = 8       // Size of the type-length header of an entry
+	valueLimit = 1 << 28 // Maximum size of an entry value, guards against corrupt lengths
+)
+
+// entry is a single type-length-value record of an e2store file. The header of
+// an entry is the little endian encoded type (2 bytes) and value length (4
+// bytes), followed by 2 reserved zero bytes.
+type entry struct {
+	typ   uint16
+	value []byte
+}
+
+// e2Writer writes entries to an e2store file.
+type e2Writer struct {
+	w io.Writer
+}
+
+// write writes a single entry, returning the number of bytes written.
+func (w *e2Writer) write(typ uint16, value []byte) (int, error) {
+	if len(value) > valueLimit {
+		return 0, fmt.Errorf("entry value too large: %d bytes", len(value))
+	}
+
+	var header [headerSize]byte
+
+	binary.LittleEndian.PutUint16(header[:2], typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(value)))
+
+	n, err := w.w.Write(header[:])
+	if err != nil {
+		return n, err
+	}
+
+	m, err := w.w.Write(value)
+
+	return n + m, err
+}
+
+// e2Reader reads entries from an e2store file.
+type e2Reader struct {
+	r io.Reader
+}
+
+// read reads the next entry, returning io.EOF if the file was fully consumed.
+func (r *e2Reader) read() (*entry, error) {
+	var header [headerSize]byte
+
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("truncated entry header: %w", err)
+		}
+
+		return nil, err
+	}
+
+	if header[6] != 0 || header[7] != 0 {
+		return nil, errors.New("reserved bytes of entry header are not zero")
+	}
+
+	length := binary.LittleEndian.Uint32(header[2:6])
+	if length > valueLimit {
+		return nil, fmt.Errorf("entry value too large: %d bytes", length)
+	}
+
+	value := make([]byte, length)
+	if _, err := io.ReadFull(r.r, value); err != nil {
+		return nil, fmt.Errorf("truncated entry value: %w", err)
+	}
+
+	return &entry{typ: binary.LittleEndian.Uint16(header[:2]), value: value}, nil
+}
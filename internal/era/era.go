@@ -0,0 +1,437 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package era implements a self-verifying archive format for Bor chain history.
+//
+// An archive is an e2store file holding a contiguous range of blocks:
+//
+//	Version | Block* | Accumulator | Checksum
+//	Block = CompressedHeader | CompressedBody | CompressedReceipts | CompressedBorReceipt | TotalDifficulty
+//
+// Headers, bodies, receipts and bor receipts are stored snappy compressed, in
+// their database RLP representation. The accumulator is the checkpoint root hash
+// of the headers, so an archive aligned with a checkpoint can be verified against
+// Heimdall, and the checksum is the SHA-256 of all the preceding entries.
+package era
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/golang/snappy"
+)
+
+const (
+	typeVersion              uint16 = 0x3265
+	typeCompressedHeader     uint16 = 0x03
+	typeCompressedBody       uint16 = 0x04
+	typeCompressedReceipts   uint16 = 0x05
+	typeTotalDifficulty      uint16 = 0x06
+	typeAccumulator          uint16 = 0x07
+	typeCompressedBorReceipt uint16 = 0x0a
+	typeChecksum             uint16 = 0x0b
+)
+
+const (
+	// MaxBlocks is the maximum number of blocks in an archive, bounded by the
+	// maximum length of a checkpoint.
+	MaxBlocks = 1 << 15
+
+	// DefaultBlocks is the default number of blocks in an archive.
+	DefaultBlocks = 8192
+)
+
+var (
+	errArchiveFull  = errors.New("archive is full")
+	errEmptyArchive = errors.New("archive has no blocks")
+)
+
+// Block is a block along with its receipts, bor receipt and total difficulty,
+// RLP encoded in their database representation.
+type Block struct {
+	Header     rlp.RawValue
+	Body       rlp.RawValue
+	Receipts   rlp.RawValue
+	BorReceipt rlp.RawValue // Empty if the block has no bor receipt
+	Td         rlp.RawValue
+}
+
+// Filename returns the name of the archive file of the given epoch, suffixed
+// with the first bytes of its accumulator.
+func Filename(network string, epoch uint64, accumulator common.Hash) string {
+	return fmt.Sprintf("%s-%05d-%s.era", network, epoch, hex.EncodeToString(accumulator[:4]))
+}
+
+// Builder writes blocks into an archive.
+type Builder struct {
+	out     io.Writer
+	w       *e2Writer // Writer of the entries covered by the checksum
+	sum     hash.Hash // Checksum of all the entries written
+	headers []*types.Header
+}
+
+// NewBuilder creates a builder writing an archive into w.
+func NewBuilder(w io.Writer) *Builder {
+	sum := sha256.New()
+
+	return &Builder{
+		out: w,
+		w:   &e2Writer{w: io.MultiWriter(w, sum)},
+		sum: sum,
+	}
+}
+
+// Add appends a block to the archive. Blocks must be added in chain order.
+func (b *Builder) Add(block *Block) error {
+	if len(b.headers) == MaxBlocks {
+		return errArchiveFull
+	}
+
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(block.Header, header); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+
+	if n := len(b.headers); n > 0 {
+		if err := verifyLink(b.headers[n-1], header); err != nil {
+			return err
+		}
+	} else if _, err := b.w.write(typeVersion, nil); err != nil {
+		return err
+	}
+
+	entries := []struct {
+		typ   uint16
+		value []byte
+	}{
+		{typeCompressedHeader, snappy.Encode(nil, block.Header)},
+		{typeCompressedBody, snappy.Encode(nil, block.Body)},
+		{typeCompressedReceipts, snappy.Encode(nil, block.Receipts)},
+		{typeCompressedBorReceipt, snappy.Encode(nil, block.BorReceipt)},
+		{typeTotalDifficulty, block.Td},
+	}
+	for _, entry := range entries {
+		if _, err := b.w.write(entry.typ, entry.value); err != nil {
+			return err
+		}
+	}
+
+	b.headers = append(b.headers, header)
+
+	return nil
+}
+
+// Finalize writes the accumulator and the checksum of the archive, returning
+// the accumulator.
+func (b *Builder) Finalize() (common.Hash, error) {
+	if len(b.headers) == 0 {
+		return common.Hash{}, errEmptyArchive
+	}
+
+	root, err := bor.ComputeRootHash(b.headers)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if _, err := b.w.write(typeAccumulator, root); err != nil {
+		return common.Hash{}, err
+	}
+
+	// The checksum doesn't cover itself, so bypass the checksum writer
+	if _, err := (&e2Writer{w: b.out}).write(typeChecksum, b.sum.Sum(nil)); err != nil {
+		return common.Hash{}, err
+	}
+
+	return common.BytesToHash(root), nil
+}
+
+// Reader reads and verifies the blocks of an archive.
+type Reader struct {
+	r           *e2Reader
+	sum         hash.Hash // Checksum of all the entries read
+	headers     []*types.Header
+	td          *big.Int
+	accumulator common.Hash
+	done        bool
+}
+
+// NewReader creates a reader for the archive in r.
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := &Reader{
+		r:   &e2Reader{r: r},
+		sum: sha256.New(),
+	}
+
+	entry, err := reader.read()
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.typ != typeVersion {
+		return nil, fmt.Errorf("invalid version entry type %#x", entry.typ)
+	}
+
+	return reader, nil
+}
+
+// read reads the next entry and adds it to the checksum.
+func (r *Reader) read() (*entry, error) {
+	entry, err := r.r.read()
+	if err != nil {
+		return nil, err
+	}
+
+	var header [headerSize]byte
+
+	binary.LittleEndian.PutUint16(header[:2], entry.typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(entry.value)))
+
+	r.sum.Write(header[:])
+	r.sum.Write(entry.value)
+
+	return entry, nil
+}
+
+// readValue reads the next entry, which must be of the given type.
+func (r *Reader) readValue(typ uint16, compressed bool) ([]byte, error) {
+	entry, err := r.read()
+	if errors.Is(err, io.EOF) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.typ != typ {
+		return nil, fmt.Errorf("unexpected entry type %#x, want %#x", entry.typ, typ)
+	}
+
+	if !compressed {
+		return entry.value, nil
+	}
+
+	return snappy.Decode(nil, entry.value)
+}
+
+// Next returns the next block of the archive, verified against its header and
+// its parent. Once all the blocks were read and the accumulator and checksum of
+// the archive verified, io.EOF is returned.
+func (r *Reader) Next() (*types.Header, *Block, error) {
+	if r.done {
+		return nil, nil, io.EOF
+	}
+
+	entry, err := r.read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, nil, err
+	}
+
+	switch entry.typ {
+	case typeCompressedHeader:
+	case typeAccumulator:
+		return nil, nil, r.finalize(entry.value)
+	case typeChecksum:
+		return nil, nil, errors.New("checksum before accumulator")
+	default:
+		return nil, nil, fmt.Errorf("unexpected entry type %#x", entry.typ)
+	}
+
+	block := new(Block)
+	if block.Header, err = snappy.Decode(nil, entry.value); err != nil {
+		return nil, nil, err
+	}
+
+	if block.Body, err = r.readValue(typeCompressedBody, true); err != nil {
+		return nil, nil, err
+	}
+
+	if block.Receipts, err = r.readValue(typeCompressedReceipts, true); err != nil {
+		return nil, nil, err
+	}
+
+	if block.BorReceipt, err = r.readValue(typeCompressedBorReceipt, true); err != nil {
+		return nil, nil, err
+	}
+
+	if block.Td, err = r.readValue(typeTotalDifficulty, false); err != nil {
+		return nil, nil, err
+	}
+
+	header, err := r.verify(block)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.headers = append(r.headers, header)
+
+	return header, block, nil
+}
+
+// finalize verifies the accumulator and the checksum closing the archive.
+func (r *Reader) finalize(accumulator []byte) error {
+	if len(r.headers) == 0 {
+		return errEmptyArchive
+	}
+
+	root, err := bor.ComputeRootHash(r.headers)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(root, accumulator) {
+		return fmt.Errorf("accumulator mismatch: have %x, want %x", accumulator, root)
+	}
+
+	// The checksum doesn't cover itself, so take it before reading the entry
+	sum := r.sum.Sum(nil)
+
+	checksum, err := r.readValue(typeChecksum, false)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(checksum, sum) {
+		return fmt.Errorf("checksum mismatch: have %x, want %x", checksum, sum)
+	}
+
+	if _, err := r.r.read(); !errors.Is(err, io.EOF) {
+		return errors.New("trailing data after checksum")
+	}
+
+	r.accumulator = common.BytesToHash(root)
+	r.done = true
+
+	return io.EOF
+}
+
+// Headers returns the headers of the blocks read so far.
+func (r *Reader) Headers() []*types.Header {
+	return r.headers
+}
+
+// Accumulator returns the accumulator of the archive, once it was fully read.
+func (r *Reader) Accumulator() common.Hash {
+	return r.accumulator
+}
+
+// verify checks that a block is consistent with its header, and that it links
+// to the previous block of the archive.
+func (r *Reader) verify(block *Block) (*types.Header, error) {
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(block.Header, header); err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+
+	number := header.Number.Uint64()
+
+	if n := len(r.headers); n > 0 {
+		if err := verifyLink(r.headers[n-1], header); err != nil {
+			return nil, err
+		}
+	}
+
+	body := new(types.Body)
+	if err := rlp.DecodeBytes(block.Body, body); err != nil {
+		return nil, fmt.Errorf("invalid body of block #%d: %w", number, err)
+	}
+
+	hasher := trie.NewStackTrie(nil)
+
+	if root := types.DeriveSha(types.Transactions(body.Transactions), hasher); root != header.TxHash {
+		return nil, fmt.Errorf("transaction root mismatch of block #%d: have %x, want %x", number, root, header.TxHash)
+	}
+
+	if uncles := types.CalcUncleHash(body.Uncles); uncles != header.UncleHash {
+		return nil, fmt.Errorf("uncle hash mismatch of block #%d: have %x, want %x", number, uncles, header.UncleHash)
+	}
+
+	if header.WithdrawalsHash != nil {
+		if root := types.DeriveSha(types.Withdrawals(body.Withdrawals), hasher); root != *header.WithdrawalsHash {
+			return nil, fmt.Errorf("withdrawal root mismatch of block #%d: have %x, want %x", number, root, *header.WithdrawalsHash)
+		}
+	}
+
+	var stored []*types.ReceiptForStorage
+	if err := rlp.DecodeBytes(block.Receipts, &stored); err != nil {
+		return nil, fmt.Errorf("invalid receipts of block #%d: %w", number, err)
+	}
+
+	if len(stored) != len(body.Transactions) {
+		return nil, fmt.Errorf("receipt count mismatch of block #%d: have %d, want %d", number, len(stored), len(body.Transactions))
+	}
+
+	receipts := make(types.Receipts, len(stored))
+	for i, receipt := range stored {
+		receipts[i] = (*types.Receipt)(receipt)
+		receipts[i].Type = body.Transactions[i].Type()
+	}
+
+	if root := types.DeriveSha(receipts, hasher); root != header.ReceiptHash {
+		return nil, fmt.Errorf("receipt root mismatch of block #%d: have %x, want %x", number, root, header.ReceiptHash)
+	}
+
+	if len(block.BorReceipt) > 0 {
+		if err := rlp.DecodeBytes(block.BorReceipt, new(types.ReceiptForStorage)); err != nil {
+			return nil, fmt.Errorf("invalid bor receipt of block #%d: %w", number, err)
+		}
+	}
+
+	td := new(big.Int)
+	if err := rlp.DecodeBytes(block.Td, td); err != nil {
+		return nil, fmt.Errorf("invalid total difficulty of block #%d: %w", number, err)
+	}
+
+	if r.td != nil {
+		if want := new(big.Int).Add(r.td, header.Difficulty); td.Cmp(want) != 0 {
+			return nil, fmt.Errorf("total difficulty mismatch of block #%d: have %v, want %v", number, td, want)
+		}
+	}
+
+	r.td = td
+
+	return header, nil
+}
+
+// verifyLink checks that a header is the child of the given parent.
+func verifyLink(parent *types.Header, header *types.Header) error {
+	if header.Number.Uint64() != parent.Number.Uint64()+1 {
+		return fmt.Errorf("non contiguous blocks: #%d follows #%d", header.Number, parent.Number)
+	}
+
+	if header.ParentHash != parent.Hash() {
+		return fmt.Errorf("parent hash mismatch of block #%d: have %x, want %x", header.Number, header.ParentHash, parent.Hash())
+	}
+
+	return nil
+}
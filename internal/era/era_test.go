@@ -0,0 +1,289 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/heimdall/checkpoint"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// makeBlocks generates a chain of blocks with transactions, encoded in their
+// database representation.
+func makeBlocks(t *testing.T, n int) ([]*types.Block, []*Block) {
+	t.Helper()
+
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &core.Genesis{Config: params.TestChainConfig, Alloc: core.GenesisAlloc{address: {Balance: big.NewInt(1000000000000000000)}}}
+		signer  = types.LatestSigner(gspec.Config)
+	)
+
+	_, blocks, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), n, func(i int, block *core.BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), common.Address{0x01}, big.NewInt(1000), params.TxGas, block.BaseFee(), nil), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		block.AddTx(tx)
+	})
+
+	var (
+		td      = new(big.Int)
+		encoded = make([]*Block, len(blocks))
+	)
+
+	for i, block := range blocks {
+		td.Add(td, block.Difficulty())
+
+		stored := make([]*types.ReceiptForStorage, len(receipts[i]))
+		for j, receipt := range receipts[i] {
+			stored[j] = (*types.ReceiptForStorage)(receipt)
+		}
+
+		encoded[i] = &Block{
+			Header:   mustEncode(t, block.Header()),
+			Body:     mustEncode(t, block.Body()),
+			Receipts: mustEncode(t, stored),
+			Td:       mustEncode(t, td),
+		}
+		// Attach a bor receipt to every other block
+		if i%2 == 0 {
+			encoded[i].BorReceipt = mustEncode(t, &types.ReceiptForStorage{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}})
+		}
+	}
+
+	return blocks, encoded
+}
+
+func mustEncode(t *testing.T, val interface{}) []byte {
+	t.Helper()
+
+	data, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", val, err)
+	}
+
+	return data
+}
+
+// buildArchive writes the given blocks into an archive.
+func buildArchive(t *testing.T, blocks []*Block) ([]byte, common.Hash) {
+	t.Helper()
+
+	var (
+		buf     = new(bytes.Buffer)
+		builder = NewBuilder(buf)
+	)
+
+	for _, block := range blocks {
+		if err := builder.Add(block); err != nil {
+			t.Fatalf("failed to add block: %v", err)
+		}
+	}
+
+	accumulator, err := builder.Finalize()
+	if err != nil {
+		t.Fatalf("failed to finalize archive: %v", err)
+	}
+
+	return buf.Bytes(), accumulator
+}
+
+// readArchive reads all the blocks of an archive.
+func readArchive(data []byte) (*Reader, []*Block, error) {
+	reader, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var blocks []*Block
+
+	for {
+		_, block, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return reader, blocks, nil
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		blocks = append(blocks, block)
+	}
+}
+
+// Tests that archives can be written and read back, with their accumulator
+// matching the checkpoint root hash of their headers.
+func TestArchiveRoundtrip(t *testing.T) {
+	blocks, encoded := makeBlocks(t, 16)
+
+	data, accumulator := buildArchive(t, encoded)
+
+	reader, read, err := readArchive(data)
+	if err != nil {
+		t.Fatalf("failed to read archive: %v", err)
+	}
+
+	if len(read) != len(encoded) {
+		t.Fatalf("block count mismatch: have %d, want %d", len(read), len(encoded))
+	}
+
+	for i := range read {
+		if !bytes.Equal(read[i].Header, encoded[i].Header) || !bytes.Equal(read[i].Body, encoded[i].Body) ||
+			!bytes.Equal(read[i].Receipts, encoded[i].Receipts) || !bytes.Equal(read[i].BorReceipt, encoded[i].BorReceipt) ||
+			!bytes.Equal(read[i].Td, encoded[i].Td) {
+			t.Fatalf("block %d mismatch", i)
+		}
+	}
+
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+
+	root, err := bor.ComputeRootHash(headers)
+	if err != nil {
+		t.Fatalf("failed to compute root hash: %v", err)
+	}
+
+	if accumulator != common.BytesToHash(root) || reader.Accumulator() != accumulator {
+		t.Fatalf("accumulator mismatch: have %x/%x, want %x", accumulator, reader.Accumulator(), root)
+	}
+}
+
+// Tests that corrupted or inconsistent archives are rejected.
+func TestArchiveCorruption(t *testing.T) {
+	_, encoded := makeBlocks(t, 4)
+
+	data, _ := buildArchive(t, encoded)
+
+	// Flip a byte of the checksum
+	corrupt := common.CopyBytes(data)
+	corrupt[len(corrupt)-1] ^= 0xff
+
+	if _, _, err := readArchive(corrupt); err == nil {
+		t.Fatalf("corrupted checksum accepted")
+	}
+
+	// Truncate the archive
+	if _, _, err := readArchive(data[:len(data)-10]); err == nil {
+		t.Fatalf("truncated archive accepted")
+	}
+
+	// Swap the receipts of two blocks, keeping the archive well formed
+	swapped := make([]*Block, len(encoded))
+	for i, block := range encoded {
+		copied := *block
+		swapped[i] = &copied
+	}
+
+	swapped[1].Receipts, swapped[2].Receipts = swapped[2].Receipts, swapped[1].Receipts
+
+	data, _ = buildArchive(t, swapped)
+	if _, _, err := readArchive(data); err == nil {
+		t.Fatalf("mismatching receipts accepted")
+	}
+
+	// Skip a block
+	builder := NewBuilder(new(bytes.Buffer))
+	if err := builder.Add(encoded[0]); err != nil {
+		t.Fatalf("failed to add block: %v", err)
+	}
+
+	if err := builder.Add(encoded[2]); err == nil {
+		t.Fatalf("non contiguous block accepted")
+	}
+}
+
+// testCheckpointFetcher is a CheckpointFetcher serving a fixed list of
+// checkpoints.
+type testCheckpointFetcher []*checkpoint.Checkpoint
+
+func (f testCheckpointFetcher) FetchCheckpoint(ctx context.Context, number int64) (*checkpoint.Checkpoint, error) {
+	return f[number-1], nil
+}
+
+func (f testCheckpointFetcher) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	return int64(len(f)), nil
+}
+
+// Tests that the headers of an archive are verified against the checkpoints
+// fully covering them.
+func TestVerifyCheckpoints(t *testing.T) {
+	blocks, _ := makeBlocks(t, 16)
+
+	headers := make([]*types.Header, len(blocks))
+	for i, block := range blocks {
+		headers[i] = block.Header()
+	}
+
+	checkpointOf := func(start, end uint64) *checkpoint.Checkpoint {
+		root, err := bor.ComputeRootHash(headers[start-1 : end])
+		if err != nil {
+			t.Fatalf("failed to compute root hash: %v", err)
+		}
+
+		return &checkpoint.Checkpoint{
+			StartBlock: new(big.Int).SetUint64(start),
+			EndBlock:   new(big.Int).SetUint64(end),
+			RootHash:   common.BytesToHash(root),
+		}
+	}
+
+	// Only the checkpoints fully covered by blocks #5-#12 are verified
+	fetcher := testCheckpointFetcher{checkpointOf(1, 3), checkpointOf(4, 6), checkpointOf(7, 9), checkpointOf(10, 12), checkpointOf(13, 16)}
+
+	coverage, err := VerifyCheckpoints(context.Background(), fetcher, headers[4:12])
+	if err != nil {
+		t.Fatalf("failed to verify checkpoints: %v", err)
+	}
+
+	if want := (Coverage{Checkpoints: 2, First: 7, Last: 12}); coverage != want {
+		t.Fatalf("coverage mismatch: have %+v, want %+v", coverage, want)
+	}
+
+	// Blocks within a single checkpoint aren't anchored
+	coverage, err = VerifyCheckpoints(context.Background(), fetcher, headers[7:11])
+	if err != nil {
+		t.Fatalf("failed to verify checkpoints: %v", err)
+	}
+
+	if coverage.Checkpoints != 0 {
+		t.Fatalf("partially covered checkpoint verified: %+v", coverage)
+	}
+
+	// Tamper with a covered checkpoint
+	fetcher[2].RootHash = common.Hash{0x01}
+
+	if _, err := VerifyCheckpoints(context.Background(), fetcher, headers[4:12]); err == nil {
+		t.Fatalf("mismatching checkpoint accepted")
+	}
+}
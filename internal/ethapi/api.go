@@ -44,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
@@ -92,6 +93,7 @@ type feeHistoryResult struct {
 	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
 	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
 	GasUsedRatio []float64        `json:"gasUsedRatio"`
+	GasEstimates *RPCGasEstimates `json:"gasEstimates,omitempty"` // Bor mode of the gas price oracle only
 }
 
 // FeeHistory returns the fee market history.
@@ -122,6 +124,19 @@ func (s *EthereumAPI) FeeHistory(ctx context.Context, blockCount math.HexOrDecim
 		}
 	}
 
+	// The fee suggestions for the next block extend the history up to the head
+	if lastBlock == rpc.LatestBlockNumber || lastBlock == rpc.PendingBlockNumber {
+		// The history is still served if the estimates can't be computed
+		estimates, err := s.b.GasEstimates(ctx)
+		if err != nil && !errors.Is(err, gasprice.ErrBorModeDisabled) {
+			log.Warn("Failed to compute gas estimates", "err", err)
+		}
+
+		if err == nil && estimates != nil {
+			results.GasEstimates = newRPCGasEstimates(estimates)
+		}
+	}
+
 	return results, nil
 }
 
@@ -231,6 +246,7 @@ func (s *TxPoolAPI) Status() map[string]hexutil.Uint {
 	return map[string]hexutil.Uint{
 		"pending": hexutil.Uint(pending),
 		"queued":  hexutil.Uint(queue),
+		"private": hexutil.Uint(s.b.PrivateStats()),
 	}
 }
 
@@ -2305,6 +2321,35 @@ func (s *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.B
 	return SubmitTransaction(ctx, s.b, tx)
 }
 
+// SendPrivateTransaction will add the signed transaction to the private transaction
+// pool of the node. Private transactions are only offered to the local block producer
+// and are never propagated to the network. They are dropped if not included within
+// the configured number of blocks. The private and public transactions of a sender
+// can't be mixed: while it has transactions in one pool, the other refuses them.
+func (s *TransactionAPI) SendPrivateTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+
+	if err := checkTxFee(tx.GasPrice(), tx.Gas(), s.b.RPCTxFeeCap()); err != nil {
+		return common.Hash{}, err
+	}
+
+	if !s.b.UnprotectedAllowed() && !tx.Protected() {
+		// Ensure only eip155 signed transactions are submitted if EIP155Required is set.
+		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+
+	if err := s.b.SendPrivateTx(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+
+	log.Info("Submitted private transaction", "hash", tx.Hash().Hex(), "nonce", tx.Nonce(), "recipient", tx.To(), "value", tx.Value())
+
+	return tx.Hash(), nil
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
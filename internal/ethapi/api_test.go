@@ -40,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/blocktest"
@@ -383,6 +384,7 @@ func allTransactionTypes(addr common.Address, config *params.ChainConfig) []txDa
 type testBackend struct {
 	db      ethdb.Database
 	chain   *core.BlockChain
+	engine  consensus.Engine // Engine overriding the one of the chain, if set
 	pending *types.Block
 }
 
@@ -424,6 +426,9 @@ func (b testBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
 func (b testBackend) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
 	return nil, nil, nil, nil, nil
 }
+func (b testBackend) GasEstimates(ctx context.Context) (*gasprice.GasEstimates, error) {
+	return nil, gasprice.ErrBorModeDisabled
+}
 func (b testBackend) ChainDb() ethdb.Database           { return b.db }
 func (b testBackend) AccountManager() *accounts.Manager { return nil }
 func (b testBackend) ExtRPCEnabled() bool               { return false }
@@ -548,7 +553,11 @@ func (b testBackend) GetPoolTransaction(txHash common.Hash) *types.Transaction {
 func (b testBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uint64, error) {
 	panic("implement me")
 }
+func (b testBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction) error {
+	panic("implement me")
+}
 func (b testBackend) Stats() (pending int, queued int) { panic("implement me") }
+func (b testBackend) PrivateStats() int                { panic("implement me") }
 func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
 	panic("implement me")
 }
@@ -559,7 +568,12 @@ func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.
 	panic("implement me")
 }
 func (b testBackend) ChainConfig() *params.ChainConfig { return b.chain.Config() }
-func (b testBackend) Engine() consensus.Engine         { return b.chain.Engine() }
+func (b testBackend) Engine() consensus.Engine {
+	if b.engine != nil {
+		return b.engine
+	}
+	return b.chain.Engine()
+}
 func (b testBackend) GetLogs(ctx context.Context, blockHash common.Hash, number uint64) ([][]*types.Log, error) {
 	panic("implement me")
 }
@@ -923,6 +937,187 @@ type Account struct {
 	addr common.Address
 }
 
+// Tests that simulated blocks are executed on top of each other on a single
+// state, with the header overrides applied and logs and traces collected.
+func TestSimulateBlocks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		emitter  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		coinbase = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		code     = hexutil.Bytes(common.Hex2Bytes("60006000a000")) // LOG0(0, 0)
+		number   = hexutil.Big(*big.NewInt(10))
+	)
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {}))
+
+	results, err := api.SimulateBlocks(context.Background(), SimOpts{
+		BlockStateCalls: []SimBlock{
+			{
+				StateOverrides: &StateOverride{emitter: OverrideAccount{Code: &code}},
+				Calls: []TransactionArgs{{
+					From:  &accounts[0].addr,
+					To:    &accounts[1].addr,
+					Value: (*hexutil.Big)(big.NewInt(1000)),
+				}},
+			},
+			{
+				BlockOverrides: &BlockOverrides{Number: &number, Coinbase: &coinbase},
+				Calls: []TransactionArgs{
+					{From: &accounts[1].addr, To: &emitter},
+					{From: &accounts[1].addr, To: &accounts[0].addr, Value: (*hexutil.Big)(big.NewInt(1000))},
+				},
+			},
+		},
+		TraceCalls: true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to simulate blocks: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("block count mismatch: have %d, want 2", len(results))
+	}
+	if results[0].Number != 2 || results[1].Number != 10 {
+		t.Fatalf("block numbers mismatch: have %d and %d, want 2 and 10", results[0].Number, results[1].Number)
+	}
+	if results[1].ParentHash != results[0].Hash {
+		t.Fatalf("parent hash mismatch: have %x, want %x", results[1].ParentHash, results[0].Hash)
+	}
+	if results[1].Miner != coinbase {
+		t.Fatalf("coinbase mismatch: have %x, want %x", results[1].Miner, coinbase)
+	}
+	if results[0].GasUsed != hexutil.Uint64(params.TxGas) {
+		t.Fatalf("gas used mismatch: have %d, want %d", results[0].GasUsed, params.TxGas)
+	}
+	// The second block spends the funds received in the first one
+	calls := results[1].Calls
+	if len(calls) != 2 {
+		t.Fatalf("call count mismatch: have %d, want 2", len(calls))
+	}
+	for i, call := range calls {
+		if call.Status != hexutil.Uint64(types.ReceiptStatusSuccessful) {
+			t.Fatalf("call %d failed: %s", i, call.Error)
+		}
+		if call.Trace == nil {
+			t.Fatalf("call %d not traced", i)
+		}
+	}
+	if len(calls[0].Logs) != 1 {
+		t.Fatalf("log count mismatch: have %d, want 1", len(calls[0].Logs))
+	}
+	if log := calls[0].Logs[0]; log.Address != emitter || log.BlockHash != results[1].Hash || log.BlockNumber != 10 {
+		t.Fatalf("log mismatch: %+v", log)
+	}
+	if results[1].StateSyncReceipt != nil {
+		t.Fatalf("unexpected state-sync receipt outside of bor")
+	}
+}
+
+// testStateSyncEngine is an engine committing the state-sync events injected
+// in it at the start of every sprint of four blocks, the way Bor commits the
+// events fetched from Heimdall. Every event emits a log from the receiver.
+type testStateSyncEngine struct {
+	consensus.Engine
+	receiver common.Address
+	events   []*types.StateSyncData
+}
+
+func (e *testStateSyncEngine) SimulateCommitStates(ctx context.Context, state *state.StateDB, header *types.Header, chain consensus.ChainHeaderReader, base *types.Header) ([]*types.StateSyncData, error) {
+	if header.Number.Uint64()%4 != 0 {
+		return nil, nil
+	}
+	for _, event := range e.events {
+		state.AddLog(&types.Log{
+			Address:     e.receiver,
+			Topics:      []common.Hash{common.BigToHash(new(big.Int).SetUint64(event.ID))},
+			Data:        common.FromHex(event.Data),
+			BlockNumber: header.Number.Uint64(),
+		})
+	}
+	return e.events, nil
+}
+
+func TestSimulateBlocksStateSync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: core.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			},
+		}
+		emitter  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		receiver = common.HexToAddress("0x0000000000000000000000000000000000001001")
+		code     = hexutil.Bytes(common.Hex2Bytes("60006000a000")) // LOG0(0, 0)
+		number   = hexutil.Big(*big.NewInt(4))
+		events   = []*types.StateSyncData{
+			{ID: 1, Contract: receiver, Data: "01"},
+			{ID: 2, Contract: receiver, Data: "02"},
+		}
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {})
+	backend.engine = &testStateSyncEngine{Engine: backend.chain.Engine(), receiver: receiver, events: events}
+	api := NewBlockChainAPI(backend)
+
+	// The events are only committed at the start of a sprint, after the calls
+	results, err := api.SimulateBlocks(context.Background(), SimOpts{
+		BlockStateCalls: []SimBlock{
+			{
+				StateOverrides: &StateOverride{emitter: OverrideAccount{Code: &code}},
+				Calls:          []TransactionArgs{{From: &accounts[0].addr, To: &emitter}},
+			},
+			{
+				BlockOverrides: &BlockOverrides{Number: &number},
+				Calls:          []TransactionArgs{{From: &accounts[0].addr, To: &emitter}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("failed to simulate blocks: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("block count mismatch: have %d, want 2", len(results))
+	}
+	if results[0].StateSyncReceipt != nil {
+		t.Fatalf("unexpected state-sync receipt within a sprint: %+v", results[0].StateSyncReceipt)
+	}
+	block := results[1]
+	receipt := block.StateSyncReceipt
+	if receipt == nil {
+		t.Fatalf("missing state-sync receipt at the start of a sprint")
+	}
+	txHash := types.GetDerivedBorTxHash(types.BorReceiptKey(4, block.Hash))
+	if receipt.TransactionHash != txHash || receipt.TransactionIndex != 1 || receipt.BlockHash != block.Hash || receipt.BlockNumber != 4 {
+		t.Fatalf("state-sync receipt mismatch: %+v", receipt)
+	}
+	if receipt.Status != hexutil.Uint64(types.ReceiptStatusSuccessful) {
+		t.Fatalf("state-sync receipt status mismatch: have %d, want %d", receipt.Status, types.ReceiptStatusSuccessful)
+	}
+	// The logs of the events follow the ones of the calls
+	if len(block.Calls) != 1 || len(block.Calls[0].Logs) != 1 || block.Calls[0].Logs[0].Index != 0 {
+		t.Fatalf("call logs mismatch: %+v", block.Calls)
+	}
+	if len(receipt.Logs) != len(events) {
+		t.Fatalf("state-sync log count mismatch: have %d, want %d", len(receipt.Logs), len(events))
+	}
+	for i, log := range receipt.Logs {
+		if log.Address != receiver || log.Topics[0] != common.BigToHash(new(big.Int).SetUint64(events[i].ID)) || common.Bytes2Hex(log.Data) != events[i].Data {
+			t.Fatalf("state-sync log %d mismatch: %+v", i, log)
+		}
+		if log.TxHash != txHash || log.TxIndex != 1 || log.Index != uint(i+1) || log.BlockHash != block.Hash || log.BlockNumber != 4 {
+			t.Fatalf("state-sync log %d fields mismatch: %+v", i, log)
+		}
+	}
+}
+
 func newAccounts(n int) (accounts []Account) {
 	for i := 0; i < n; i++ {
 		key, _ := crypto.GenerateKey()
@@ -2146,3 +2341,29 @@ func TestRPCGetTransactionReceipt(t *testing.T) {
 		require.JSONEqf(t, want, have, "test %d: json not match, want: %s, have: %s", i, want, have)
 	}
 }
+
+// failingEstimatesBackend is a backend whose gas estimates can't be computed.
+type failingEstimatesBackend struct {
+	*testBackend
+}
+
+func (b failingEstimatesBackend) GasEstimates(ctx context.Context) (*gasprice.GasEstimates, error) {
+	return nil, errors.New("block not found")
+}
+
+// Tests that the fee history is served without the gas estimates if these can't
+// be computed.
+func TestFeeHistoryEstimatesFailure(t *testing.T) {
+	t.Parallel()
+
+	genesis := &core.Genesis{Config: params.TestChainConfig}
+	api := NewEthereumAPI(failingEstimatesBackend{newTestBackend(t, 1, genesis, nil)})
+
+	result, err := api.FeeHistory(context.Background(), 1, rpc.LatestBlockNumber, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve fee history: %v", err)
+	}
+	if result.GasEstimates != nil {
+		t.Fatalf("gas estimates returned: %+v", result.GasEstimates)
+	}
+}
@@ -2,13 +2,24 @@ package ethapi
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
+// maxStateSyncEventsRange is the maximum number of state-sync events which can
+// be queried by ID in a single request.
+const maxStateSyncEventsRange = 1000
+
+var errInvalidStateSyncRange = errors.New("invalid state-sync event range")
+
 // GetRootHash returns root hash for given start and end block
 func (s *BlockChainAPI) GetRootHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64) (string, error) {
 	root, err := s.b.GetRootHash(ctx, starBlockNr, endBlockNr)
@@ -109,3 +120,154 @@ func (api *BorAPI) SendRawTransactionConditional(ctx context.Context, input hexu
 func (api *BorAPI) GetVoteOnHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64, hash string, milestoneId string) (bool, error) {
 	return api.b.GetVoteOnHash(ctx, starBlockNr, endBlockNr, hash, milestoneId)
 }
+
+// RPCStateSyncEvent is a state-sync event as committed into the Bor chain.
+type RPCStateSyncEvent struct {
+	ID          hexutil.Uint64 `json:"id"`
+	Contract    common.Address `json:"contract"`
+	Data        hexutil.Bytes  `json:"data"`
+	TxHash      common.Hash    `json:"txHash"`
+	BorTxHash   common.Hash    `json:"borTxHash"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+// newRPCStateSyncEvent returns a state-sync event that will serialize to the RPC
+// representation, with the given location fields set.
+func newRPCStateSyncEvent(event *types.StateSyncData, blockHash common.Hash, blockNumber uint64) (*RPCStateSyncEvent, error) {
+	data, err := hex.DecodeString(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data of state-sync event %d: %w", event.ID, err)
+	}
+
+	return &RPCStateSyncEvent{
+		ID:          hexutil.Uint64(event.ID),
+		Contract:    event.Contract,
+		Data:        data,
+		TxHash:      event.TxHash,
+		BorTxHash:   types.GetDerivedBorTxHash(types.BorReceiptKey(blockNumber, blockHash)),
+		BlockHash:   blockHash,
+		BlockNumber: hexutil.Uint64(blockNumber),
+	}, nil
+}
+
+// GetStateSyncEvents returns the state-sync events with IDs in the range
+// [fromID, toID] which were committed on the canonical chain. Events not
+// committed (yet) are omitted.
+func (api *BorAPI) GetStateSyncEvents(ctx context.Context, fromID hexutil.Uint64, toID hexutil.Uint64) ([]*RPCStateSyncEvent, error) {
+	if fromID > toID {
+		return nil, errInvalidStateSyncRange
+	}
+
+	if toID-fromID >= maxStateSyncEventsRange {
+		return nil, fmt.Errorf("%w: more than %d events requested", errInvalidStateSyncRange, maxStateSyncEventsRange)
+	}
+
+	events := make([]*RPCStateSyncEvent, 0, toID-fromID+1)
+
+	for id := uint64(fromID); id <= uint64(toID); id++ {
+		event, blockHash, blockNumber := rawdb.ReadBorStateSync(api.b.ChainDb(), id)
+		if event == nil {
+			continue
+		}
+
+		rpcEvent, err := newRPCStateSyncEvent(event, blockHash, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, rpcEvent)
+	}
+
+	return events, nil
+}
+
+// GetStateSyncEventsByBlock returns the state-sync events committed in the
+// given block.
+func (api *BorAPI) GetStateSyncEventsByBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*RPCStateSyncEvent, error) {
+	header, err := api.b.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if header == nil || err != nil {
+		return nil, err
+	}
+
+	var (
+		blockHash   = header.Hash()
+		blockNumber = header.Number.Uint64()
+		stored      = rawdb.ReadBorStateSyncs(api.b.ChainDb(), blockHash, blockNumber)
+		events      = make([]*RPCStateSyncEvent, 0, len(stored))
+	)
+
+	for _, event := range stored {
+		rpcEvent, err := newRPCStateSyncEvent(event, blockHash, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, rpcEvent)
+	}
+
+	return events, nil
+}
+
+// RPCGasEstimate is a fee suggestion that will serialize to the RPC
+// representation.
+type RPCGasEstimate struct {
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas"`
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas"`
+}
+
+// RPCGasEstimates are the fee suggestions for the next block that will
+// serialize to the RPC representation.
+type RPCGasEstimates struct {
+	BlockNumber        hexutil.Uint64 `json:"blockNumber"`
+	BaseFee            *hexutil.Big   `json:"baseFeePerGas"`
+	TipFloor           *hexutil.Big   `json:"tipFloor"`
+	SprintGasUsedRatio float64        `json:"sprintGasUsedRatio"`
+	GasUsedRatioTrend  float64        `json:"gasUsedRatioTrend"`
+	PendingBacklog     float64        `json:"pendingBacklog"`
+	Pressure           float64        `json:"pressure"`
+	Safe               RPCGasEstimate `json:"safe"`
+	Standard           RPCGasEstimate `json:"standard"`
+	Fast               RPCGasEstimate `json:"fast"`
+}
+
+// newRPCGasEstimates returns the fee suggestions that will serialize to the RPC
+// representation.
+func newRPCGasEstimates(estimates *gasprice.GasEstimates) *RPCGasEstimates {
+	estimate := func(estimate gasprice.GasEstimate) RPCGasEstimate {
+		return RPCGasEstimate{
+			MaxPriorityFeePerGas: (*hexutil.Big)(estimate.MaxPriorityFeePerGas),
+			MaxFeePerGas:         (*hexutil.Big)(estimate.MaxFeePerGas),
+		}
+	}
+
+	return &RPCGasEstimates{
+		BlockNumber:        hexutil.Uint64(estimates.BlockNumber),
+		BaseFee:            (*hexutil.Big)(estimates.BaseFee),
+		TipFloor:           (*hexutil.Big)(estimates.TipFloor),
+		SprintGasUsedRatio: estimates.SprintGasUsedRatio,
+		GasUsedRatioTrend:  estimates.GasUsedRatioTrend,
+		PendingBacklog:     estimates.PendingBacklog,
+		Pressure:           estimates.Pressure,
+		Safe:               estimate(estimates.Safe),
+		Standard:           estimate(estimates.Standard),
+		Fast:               estimate(estimates.Fast),
+	}
+}
+
+// GasEstimates returns the safe, standard and fast fee suggestions for the next
+// block, accounting for the fullness of the blocks over the recent sprints, for
+// the pending transactions of the pool and for the tip floor. The gas price
+// oracle must be run in the Bor mode.
+func (api *BorAPI) GasEstimates(ctx context.Context) (*RPCGasEstimates, error) {
+	estimates, err := api.b.GasEstimates(ctx)
+	if errors.Is(err, gasprice.ErrBorModeDisabled) {
+		return nil, fmt.Errorf("%w, enable it with --gpo.bor", err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newRPCGasEstimates(estimates), nil
+}
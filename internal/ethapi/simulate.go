@@ -0,0 +1,597 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks that can be simulated
+	// in a single request.
+	maxSimulateBlocks = 256
+
+	// defaultSimulatePeriod is the timestamp increment of simulated blocks if
+	// neither an override nor a Bor block period is available.
+	defaultSimulatePeriod = 12
+)
+
+var (
+	errSimulateNoBlocks       = errors.New("no blocks to simulate")
+	errSimulateTooManyBlocks  = fmt.Errorf("too many blocks to simulate, maximum is %d", maxSimulateBlocks)
+	errSimulateBlockNumber    = errors.New("simulated block numbers must be increasing")
+	errSimulateBlockTimestamp = errors.New("simulated block timestamps must be increasing")
+)
+
+// SimBlock is a block to be simulated on top of the previous one.
+type SimBlock struct {
+	BlockOverrides *BlockOverrides   `json:"blockOverrides"`
+	StateOverrides *StateOverride    `json:"stateOverrides"`
+	Calls          []TransactionArgs `json:"calls"`
+}
+
+// SimOpts are the inputs to eth_simulateBlocks.
+type SimOpts struct {
+	BlockStateCalls []SimBlock `json:"blockStateCalls"`
+	TraceCalls      bool       `json:"traceCalls"`
+}
+
+// simCallResult is the result of a single simulated call.
+type simCallResult struct {
+	ReturnValue hexutil.Bytes  `json:"returnData"`
+	Logs        []*types.Log   `json:"logs"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Status      hexutil.Uint64 `json:"status"`
+	Error       string         `json:"error,omitempty"`
+	Trace       *simCallFrame  `json:"trace,omitempty"`
+}
+
+// simStateSyncReceipt is the synthetic receipt of the state-sync events
+// committed at the start of a Bor sprint.
+type simStateSyncReceipt struct {
+	TransactionHash  common.Hash    `json:"transactionHash"`
+	TransactionIndex hexutil.Uint64 `json:"transactionIndex"`
+	BlockHash        common.Hash    `json:"blockHash"`
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	Logs             []*types.Log   `json:"logs"`
+	Status           hexutil.Uint64 `json:"status"`
+}
+
+// simBlockResult is the result of a single simulated block.
+type simBlockResult struct {
+	Number           hexutil.Uint64       `json:"number"`
+	Hash             common.Hash          `json:"hash"`
+	ParentHash       common.Hash          `json:"parentHash"`
+	Timestamp        hexutil.Uint64       `json:"timestamp"`
+	GasLimit         hexutil.Uint64       `json:"gasLimit"`
+	GasUsed          hexutil.Uint64       `json:"gasUsed"`
+	Miner            common.Address       `json:"miner"`
+	BaseFee          *hexutil.Big         `json:"baseFeePerGas,omitempty"`
+	Calls            []*simCallResult     `json:"calls"`
+	StateSyncReceipt *simStateSyncReceipt `json:"stateSyncReceipt,omitempty"`
+}
+
+// stateSyncSimulator is implemented by consensus engines which commit events
+// into the state at sprint boundaries (i.e. Bor).
+type stateSyncSimulator interface {
+	SimulateCommitStates(ctx context.Context, state *state.StateDB, header *types.Header, chain consensus.ChainHeaderReader, base *types.Header) ([]*types.StateSyncData, error)
+}
+
+// SimulateBlocks executes a sequence of blocks, each consisting of a list of
+// calls, on top of the given block. All blocks are executed on the same state,
+// so the effects of a call are visible to all subsequent calls, in the same or
+// in later blocks.
+//
+// On Bor, the state-sync events due at the first block of a sprint are committed
+// after the calls of that block, same as during block production, and returned
+// as a synthetic state-sync receipt.
+//
+// Note, this function doesn't make any changes in the state/blockchain.
+func (s *BlockChainAPI) SimulateBlocks(ctx context.Context, opts SimOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]*simBlockResult, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, errSimulateNoBlocks
+	}
+
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, errSimulateTooManyBlocks
+	}
+
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+
+	statedb, base, err := s.b.StateAndHeaderByNumberOrHash(ctx, bNrOrHash)
+	if statedb == nil || err != nil {
+		return nil, err
+	}
+
+	// Setup context so it may be cancelled when the simulation has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var cancel context.CancelFunc
+	if timeout := s.b.RPCEVMTimeout(); timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	var (
+		chain   = &simChain{ctx: ctx, b: s.b, base: base, headers: make(map[uint64]*types.Header)}
+		parent  = base
+		gasCap  = s.b.RPCGasCap()
+		results = make([]*simBlockResult, 0, len(opts.BlockStateCalls))
+	)
+
+	for i, block := range opts.BlockStateCalls {
+		header, err := s.makeSimHeader(parent, block.BlockOverrides)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		if err := block.StateOverrides.Apply(statedb); err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		result, err := s.simulateBlock(ctx, chain, statedb, header, block.Calls, &gasCap, opts.TraceCalls)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", i, err)
+		}
+
+		results = append(results, result)
+		chain.headers[header.Number.Uint64()] = header
+		parent = header
+	}
+
+	return results, nil
+}
+
+// makeSimHeader assembles the header of the block following parent, with the
+// given overrides applied.
+func (s *BlockChainAPI) makeSimHeader(parent *types.Header, overrides *BlockOverrides) (*types.Header, error) {
+	var (
+		config = s.b.ChainConfig()
+		number = new(big.Int).Add(parent.Number, common.Big1)
+	)
+
+	if overrides != nil && overrides.Number != nil {
+		if overrides.Number.ToInt().Cmp(parent.Number) <= 0 {
+			return nil, errSimulateBlockNumber
+		}
+
+		number = new(big.Int).Set(overrides.Number.ToInt())
+	}
+
+	period := uint64(defaultSimulatePeriod)
+	if config.Bor != nil && config.Bor.Period != nil {
+		period = config.Bor.CalculatePeriod(number.Uint64())
+	}
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int).Set(parent.Difficulty),
+		Number:     number,
+		GasLimit:   parent.GasLimit,
+		Time:       parent.Time + period,
+	}
+
+	if config.IsLondon(number) {
+		header.BaseFee = eip1559.CalcBaseFee(config, parent)
+	}
+
+	if overrides == nil {
+		return header, nil
+	}
+
+	if overrides.Time != nil {
+		if uint64(*overrides.Time) <= parent.Time {
+			return nil, errSimulateBlockTimestamp
+		}
+
+		header.Time = uint64(*overrides.Time)
+	}
+
+	if overrides.Difficulty != nil {
+		header.Difficulty = new(big.Int).Set(overrides.Difficulty.ToInt())
+	}
+
+	if overrides.GasLimit != nil {
+		header.GasLimit = uint64(*overrides.GasLimit)
+	}
+
+	if overrides.Coinbase != nil {
+		header.Coinbase = *overrides.Coinbase
+	}
+
+	if overrides.Random != nil {
+		header.MixDigest = *overrides.Random
+	}
+
+	if overrides.BaseFee != nil {
+		header.BaseFee = new(big.Int).Set(overrides.BaseFee.ToInt())
+	}
+
+	return header, nil
+}
+
+// simulateBlock executes the calls of a single simulated block on the given
+// state, commits the Bor state-sync events if they are due and seals the header
+// with the resulting gas usage and state root.
+func (s *BlockChainAPI) simulateBlock(ctx context.Context, chain *simChain, statedb *state.StateDB, header *types.Header, calls []TransactionArgs, gasCap *uint64, trace bool) (*simBlockResult, error) {
+	var (
+		config   = s.b.ChainConfig()
+		number   = header.Number.Uint64()
+		gp       = new(core.GasPool).AddGas(header.GasLimit)
+		blockCtx = core.NewEVMBlockContext(header, chain, &header.Coinbase)
+		results  = make([]*simCallResult, 0, len(calls))
+		hashes   = make([]common.Hash, 0, len(calls))
+	)
+
+	for i, args := range calls {
+		// Default the gas allowance of the call to whatever is left in both the
+		// block and the global gas cap.
+		allowance := gp.Gas()
+		if *gasCap != 0 && *gasCap < allowance {
+			allowance = *gasCap
+		}
+
+		msg, err := args.ToMessage(allowance, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+
+		var tracer *simCallTracer
+
+		vmConfig := &vm.Config{NoBaseFee: true}
+		if trace {
+			tracer = new(simCallTracer)
+			vmConfig.Tracer = tracer
+		}
+
+		// Calls have no transaction hash, derive a unique one to key their logs.
+		hash := simCallHash(number, i)
+		statedb.SetTxContext(hash, i)
+
+		evm, vmError := s.b.GetEVM(ctx, msg, statedb, header, vmConfig, &blockCtx)
+
+		// Wait for the context to be done and cancel the evm. Even if the
+		// EVM has finished, cancelling may be done (repeatedly)
+		go func() {
+			<-ctx.Done()
+			evm.Cancel()
+		}()
+
+		// Calls without any fees would credit the coinbase a negative tip if the
+		// block has a base fee, so skip the fee burn and tip for them.
+		var result *core.ExecutionResult
+
+		// nolint : contextcheck
+		if msg.GasFeeCap.BitLen() == 0 && msg.GasTipCap.BitLen() == 0 {
+			result, err = core.ApplyMessageNoFeeBurnOrTip(evm, *msg, gp, context.Background())
+		} else {
+			result, err = core.ApplyMessage(evm, msg, gp, context.Background())
+		}
+		if err := vmError(); err != nil {
+			return nil, err
+		}
+
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", s.b.RPCEVMTimeout())
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w (supplied gas %d)", i, err, msg.GasLimit)
+		}
+
+		statedb.Finalise(config.IsEIP158(header.Number))
+
+		header.GasUsed += result.UsedGas
+		if *gasCap != 0 {
+			*gasCap -= result.UsedGas
+		}
+
+		res := &simCallResult{
+			ReturnValue: result.Return(),
+			GasUsed:     hexutil.Uint64(result.UsedGas),
+			Status:      hexutil.Uint64(types.ReceiptStatusSuccessful),
+		}
+
+		if result.Failed() {
+			res.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			res.Error = result.Err.Error()
+
+			if len(result.Revert()) > 0 {
+				res.ReturnValue = result.Revert()
+				res.Error = newRevertError(result).Error()
+			}
+		}
+
+		if tracer != nil {
+			res.Trace = tracer.root
+		}
+
+		results = append(results, res)
+		hashes = append(hashes, hash)
+	}
+
+	// Commit the state-sync events after the calls, same as Finalize does.
+	var (
+		stateSyncs []*types.StateSyncData
+		err        error
+	)
+
+	if engine, ok := s.b.Engine().(stateSyncSimulator); ok {
+		borHash := simCallHash(number, len(calls))
+		statedb.SetTxContext(borHash, len(calls))
+
+		if stateSyncs, err = engine.SimulateCommitStates(ctx, statedb, header, chain, chain.base); err != nil {
+			return nil, fmt.Errorf("state sync: %w", err)
+		}
+
+		log.Debug("Simulated state syncs", "number", number, "events", len(stateSyncs))
+
+		hashes = append(hashes, borHash)
+	}
+
+	header.Root = statedb.IntermediateRoot(config.IsEIP158(header.Number))
+
+	var (
+		blockHash = header.Hash()
+		logIndex  uint
+	)
+
+	for i, res := range results {
+		res.Logs = statedb.GetLogs(hashes[i], number, blockHash)
+		for _, l := range res.Logs {
+			l.Index = logIndex
+			logIndex++
+		}
+
+		if res.Logs == nil {
+			res.Logs = []*types.Log{}
+		}
+	}
+
+	block := &simBlockResult{
+		Number:     hexutil.Uint64(number),
+		Hash:       blockHash,
+		ParentHash: header.ParentHash,
+		Timestamp:  hexutil.Uint64(header.Time),
+		GasLimit:   hexutil.Uint64(header.GasLimit),
+		GasUsed:    hexutil.Uint64(header.GasUsed),
+		Miner:      header.Coinbase,
+		BaseFee:    (*hexutil.Big)(header.BaseFee),
+		Calls:      results,
+	}
+
+	if len(stateSyncs) > 0 {
+		logs := statedb.GetLogs(hashes[len(results)], number, blockHash)
+		types.DeriveFieldsForBorLogs(logs, blockHash, number, uint(len(results)), logIndex)
+
+		if logs == nil {
+			logs = []*types.Log{}
+		}
+
+		block.StateSyncReceipt = &simStateSyncReceipt{
+			TransactionHash:  types.GetDerivedBorTxHash(types.BorReceiptKey(number, blockHash)),
+			TransactionIndex: hexutil.Uint64(len(results)),
+			BlockHash:        blockHash,
+			BlockNumber:      hexutil.Uint64(number),
+			Logs:             logs,
+			Status:           hexutil.Uint64(types.ReceiptStatusSuccessful),
+		}
+	}
+
+	return block, nil
+}
+
+// simCallHash derives a unique placeholder hash for a simulated call, used to
+// track the logs it emits.
+func simCallHash(number uint64, index int) common.Hash {
+	var enc [16]byte
+
+	binary.BigEndian.PutUint64(enc[:8], number)
+	binary.BigEndian.PutUint64(enc[8:], uint64(index))
+
+	return crypto.Keccak256Hash(enc[:])
+}
+
+// simChain resolves headers for the simulated blocks, falling back to the
+// canonical chain for the ancestors of the simulation base. It implements both
+// core.ChainContext and consensus.ChainHeaderReader.
+type simChain struct {
+	ctx     context.Context
+	b       Backend
+	base    *types.Header
+	headers map[uint64]*types.Header // Simulated headers by number
+}
+
+func (c *simChain) Config() *params.ChainConfig {
+	return c.b.ChainConfig()
+}
+
+func (c *simChain) Engine() consensus.Engine {
+	return c.b.Engine()
+}
+
+func (c *simChain) CurrentHeader() *types.Header {
+	return c.b.CurrentHeader()
+}
+
+func (c *simChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header := c.GetHeaderByNumber(number)
+	if header == nil || header.Hash() != hash {
+		return nil
+	}
+
+	return header
+}
+
+func (c *simChain) GetHeaderByNumber(number uint64) *types.Header {
+	if header, ok := c.headers[number]; ok {
+		return header
+	}
+
+	if number > c.base.Number.Uint64() {
+		return nil
+	}
+
+	if number == c.base.Number.Uint64() {
+		return c.base
+	}
+
+	header, err := c.b.HeaderByNumber(c.ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return nil
+	}
+
+	return header
+}
+
+func (c *simChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	for _, header := range c.headers {
+		if header.Hash() == hash {
+			return header
+		}
+	}
+
+	header, err := c.b.HeaderByHash(c.ctx, hash)
+	if err != nil {
+		return nil
+	}
+
+	return header
+}
+
+func (c *simChain) GetTd(hash common.Hash, number uint64) *big.Int {
+	return c.b.GetTd(c.ctx, hash)
+}
+
+// simCallFrame is a single call frame of a simulated call.
+type simCallFrame struct {
+	Type    string          `json:"type"`
+	From    common.Address  `json:"from"`
+	To      common.Address  `json:"to"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Output  hexutil.Bytes   `json:"output,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*simCallFrame `json:"calls,omitempty"`
+}
+
+// simCallTracer is a minimal call tracer collecting the call frames of a
+// simulated call. The tracers in eth/tracers cannot be used here, as they
+// depend on this package.
+type simCallTracer struct {
+	root  *simCallFrame
+	stack []*simCallFrame
+}
+
+func newSimCallFrame(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) *simCallFrame {
+	frame := &simCallFrame{
+		Type:  typ.String(),
+		From:  from,
+		To:    to,
+		Gas:   hexutil.Uint64(gas),
+		Input: common.CopyBytes(input),
+	}
+	if value != nil {
+		frame.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+
+	return frame
+}
+
+func (t *simCallTracer) CaptureTxStart(gasLimit uint64) {}
+
+func (t *simCallTracer) CaptureTxEnd(restGas uint64) {}
+
+func (t *simCallTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+
+	t.root = newSimCallFrame(typ, from, to, input, gas, value)
+	t.stack = []*simCallFrame{t.root}
+}
+
+func (t *simCallTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exit(output, gasUsed, err)
+}
+
+func (t *simCallTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if len(t.stack) == 0 {
+		return
+	}
+
+	frame := newSimCallFrame(typ, from, to, input, gas, value)
+
+	parent := t.stack[len(t.stack)-1]
+	parent.Calls = append(parent.Calls, frame)
+
+	t.stack = append(t.stack, frame)
+}
+
+func (t *simCallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(output, gasUsed, err)
+}
+
+func (t *simCallTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+func (t *simCallTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+// exit finalizes the innermost open call frame.
+func (t *simCallTracer) exit(output []byte, gasUsed uint64, err error) {
+	if len(t.stack) == 0 {
+		return
+	}
+
+	frame := t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+
+	frame.GasUsed = hexutil.Uint64(gasUsed)
+	frame.Output = common.CopyBytes(output)
+
+	if err != nil {
+		frame.Error = err.Error()
+	}
+}
+
+var _ vm.EVMLogger = (*simCallTracer)(nil)
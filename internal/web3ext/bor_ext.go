@@ -60,6 +60,11 @@ web3._extend({
 			call: 'bor_getRootHash',
 			params: 2,
 		}),
+		new web3._extend.Method({
+			name: 'verifySpan',
+			call: 'bor_verifySpan',
+			params: 1,
+		}),
 		new web3._extend.Method({
 			name: 'getVoteOnHash',
 			call: 'bor_getVoteOnHash',
@@ -71,6 +76,18 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null]
 		}),
+		new web3._extend.Method({
+			name: 'getStateSyncEvents',
+			call: 'bor_getStateSyncEvents',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'getStateSyncEventsByBlock',
+			call: 'bor_getStateSyncEventsByBlock',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 	]
 });
 `
@@ -972,6 +972,11 @@ web3._extend({
 			call: 'dev_setFeeRecipient',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'injectStateSync',
+			call: 'dev_injectStateSync',
+			params: 2
+		}),
 	],
 });
 `
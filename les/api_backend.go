@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
@@ -199,6 +200,10 @@ func (b *LesApiBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	return b.eth.txPool.Add(ctx, signedTx)
 }
 
+func (b *LesApiBackend) SendPrivateTx(ctx context.Context, signedTx *types.Transaction) error {
+	return txpool.ErrPrivateTxUnsupported
+}
+
 func (b *LesApiBackend) RemoveTx(txHash common.Hash) {
 	b.eth.txPool.RemoveTx(txHash)
 }
@@ -223,6 +228,10 @@ func (b *LesApiBackend) Stats() (pending int, queued int) {
 	return b.eth.txPool.Stats(), 0
 }
 
+func (b *LesApiBackend) PrivateStats() int {
+	return 0
+}
+
 func (b *LesApiBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
 	return b.eth.txPool.Content()
 }
@@ -278,6 +287,10 @@ func (b *LesApiBackend) FeeHistory(ctx context.Context, blockCount uint64, lastB
 	return b.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
 }
 
+func (b *LesApiBackend) GasEstimates(ctx context.Context) (*gasprice.GasEstimates, error) {
+	return b.gpo.GasEstimates(ctx)
+}
+
 func (b *LesApiBackend) ChainDb() ethdb.Database {
 	return b.eth.chainDb
 }
@@ -364,15 +377,17 @@ func (b *LesApiBackend) GetBorBlockTransactionWithBlockHash(ctx context.Context,
 }
 
 func (b *LesApiBackend) GetWhitelistedCheckpoint() (bool, uint64, common.Hash) {
-	return false, 0, common.Hash{}
+	return b.eth.checker.GetWhitelistedCheckpoint()
 }
 
 func (b *LesApiBackend) PurgeWhitelistedCheckpoint() {
+	b.eth.checker.PurgeWhitelistedCheckpoint()
 }
 
 func (b *LesApiBackend) GetWhitelistedMilestone() (bool, uint64, common.Hash) {
-	return false, 0, common.Hash{}
+	return b.eth.checker.GetWhitelistedMilestone()
 }
 
 func (b *LesApiBackend) PurgeWhitelistedMilestone() {
+	b.eth.checker.PurgeWhitelistedMilestone()
 }
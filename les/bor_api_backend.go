@@ -4,13 +4,28 @@ import (
 	"context"
 	"errors"
 
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/event"
 )
 
+var errBorEngineNotAvailable = errors.New("Only available in Bor engine")
+
 // GetRootHash returns root hash for given start and end block
 func (b *LesApiBackend) GetRootHash(ctx context.Context, starBlockNr uint64, endBlockNr uint64) (string, error) {
-	return "", errors.New("Not implemented")
+	var api *bor.API
+
+	for _, _api := range b.eth.Engine().APIs(b.eth.BlockChain().HeaderChain()) {
+		if _api.Namespace == "bor" {
+			api = _api.Service.(*bor.API)
+		}
+	}
+
+	if api == nil {
+		return "", errBorEngineNotAvailable
+	}
+
+	return api.GetRootHash(starBlockNr, endBlockNr)
 }
 
 // SubscribeStateSyncEvent subscribe state sync event
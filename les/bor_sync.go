@@ -0,0 +1,312 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package les
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/light"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	borSyncInterval       = 2 * time.Second   // Interval between two header sync rounds
+	borSyncTimeout        = 10 * time.Second  // Time allowed to the servers to deliver a batch of headers
+	borSyncOverlap        = 16                // Number of local headers requested again first to find a common ancestor on reorgs
+	borMaxForkAncestry    = 4096              // Maximum depth of the common ancestor of the servers' chain
+	borCheckpointInterval = 100 * time.Second // Interval between two checkpoint whitelisting rounds
+	borCheckpointTimeout  = 30 * time.Second  // Time allowed to fetch and verify a checkpoint
+)
+
+var (
+	// errNoBorAncestor is returned when the servers' chain doesn't share any
+	// header with the local one, down to the maximum fork ancestry or to the
+	// whitelisted checkpoint.
+	errNoBorAncestor = errors.New("no common ancestor")
+
+	// errInvalidCheckpoint is returned when a checkpoint covers an invalid range.
+	errInvalidCheckpoint = errors.New("invalid checkpoint range")
+
+	// errMissingCheckpointHeaders is returned when the headers covered by a
+	// checkpoint are not available locally.
+	errMissingCheckpointHeaders = errors.New("missing checkpoint headers")
+
+	// errCheckpointRootMismatch is returned when the root hash of the local
+	// headers doesn't match the one of a checkpoint.
+	errCheckpointRootMismatch = errors.New("checkpoint root hash mismatch")
+)
+
+// checkpointHeaderReader is the header access needed to verify a checkpoint.
+type checkpointHeaderReader interface {
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// borSyncLoop periodically fetches the headers past the local head from the
+// servers and inserts them into the light chain. Bor chains have no beacon light
+// client to follow, instead the headers are verified by the Bor engine on
+// insertion, starting from the validator set of the genesis and checking the
+// validator sets announced at the end of the sprints against the state of the
+// validator contract.
+func (s *LightEthereum) borSyncLoop() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(borSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.syncBorHeaders(); err != nil {
+				log.Warn("Failed to sync headers", "err", err)
+			}
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// syncBorHeaders fetches and inserts batches of headers until the light chain
+// reaches the highest head announced by the servers.
+func (s *LightEthereum) syncBorHeaders() error {
+	for {
+		var target uint64
+
+		for _, peer := range s.peers.allPeers() {
+			if number := peer.headNumber(); number > target {
+				target = number
+			}
+		}
+
+		head := s.blockchain.CurrentHeader().Number.Uint64()
+		if target <= head {
+			return nil
+		}
+
+		ancestor, err := s.findBorAncestor(head)
+		if err != nil {
+			return err
+		}
+
+		// The headers must link to the ancestor, the servers delivering others
+		// are treated as failing
+		amount := target - ancestor
+		if amount > MaxHeaderFetch {
+			amount = MaxHeaderFetch
+		}
+
+		req := &light.HeadersRequest{
+			Origin: ancestor + 1,
+			Amount: amount,
+			Parent: s.blockchain.GetHeaderByNumber(ancestor).Hash(),
+		}
+
+		if err := s.retrieveBorHeaders(req); err != nil {
+			return err
+		}
+
+		if i, err := s.blockchain.InsertHeaderChain(req.Headers); err != nil {
+			return fmt.Errorf("invalid header %d: %w", req.Headers[i].Number, err)
+		}
+
+		// Stop if the servers' chain doesn't beat ours, it won't get any better
+		if s.blockchain.CurrentHeader().Number.Uint64() <= head {
+			return nil
+		}
+
+		select {
+		case <-s.closeCh:
+			return nil
+		default:
+		}
+	}
+}
+
+// findBorAncestor searches the highest local header also in the servers' chain,
+// requesting the headers below the local head in windows growing backwards, no
+// deeper than the whitelisted checkpoint nor the maximum fork ancestry.
+func (s *LightEthereum) findBorAncestor(head uint64) (uint64, error) {
+	// The genesis and the whitelisted checkpoint are common to all the valid
+	// chains, the servers' chain is checked to link to them afterwards
+	var (
+		floor    uint64
+		anchored = true
+	)
+
+	if head > borMaxForkAncestry {
+		floor, anchored = head-borMaxForkAncestry, false
+	}
+
+	if exists, number, _ := s.checker.GetWhitelistedCheckpoint(); exists && number >= floor && number <= head {
+		floor, anchored = number, true
+	}
+
+	for span := uint64(borSyncOverlap); head > floor; span *= 2 {
+		if span > MaxHeaderFetch {
+			span = MaxHeaderFetch
+		}
+
+		origin := floor + 1
+		if head-floor > span {
+			origin = head - span + 1
+		}
+
+		req := &light.HeadersRequest{Origin: origin, Amount: head - origin + 1}
+		if err := s.retrieveBorHeaders(req); err != nil {
+			return 0, err
+		}
+
+		if ancestor, ok := borAncestor(s.blockchain, req.Headers); ok {
+			return ancestor, nil
+		}
+
+		head = origin - 1
+	}
+
+	if anchored {
+		return floor, nil
+	}
+
+	return 0, errNoBorAncestor
+}
+
+// retrieveBorHeaders retrieves a batch of headers from the servers.
+func (s *LightEthereum) retrieveBorHeaders(req *light.HeadersRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), borSyncTimeout)
+	defer cancel()
+
+	return s.odr.Retrieve(ctx, req)
+}
+
+// borAncestor returns the number of the highest of the given headers also in
+// the local chain.
+func borAncestor(chain checkpointHeaderReader, headers []*types.Header) (uint64, bool) {
+	for i := len(headers) - 1; i >= 0; i-- {
+		number := headers[i].Number.Uint64()
+		if local := chain.GetHeaderByNumber(number); local != nil && local.Hash() == headers[i].Hash() {
+			return number, true
+		}
+	}
+
+	return 0, false
+}
+
+// borCheckpointLoop periodically whitelists the latest checkpoint submitted to
+// the root chain, anchoring the trust of the light chain on it.
+func (s *LightEthereum) borCheckpointLoop(engine *bor.Bor) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(borCheckpointInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), borCheckpointTimeout)
+			err := s.whitelistBorCheckpoint(ctx, engine)
+
+			cancel()
+
+			if err != nil {
+				log.Warn("Failed to whitelist checkpoint", "err", err)
+			}
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+// whitelistBorCheckpoint fetches the latest checkpoint from Heimdall and checks
+// it against the local headers. If they match, the end of the checkpoint gets
+// whitelisted so that the light chain never reorgs past it, otherwise the chain
+// is rewound to before the checkpoint.
+func (s *LightEthereum) whitelistBorCheckpoint(ctx context.Context, engine *bor.Bor) error {
+	checkpoint, err := engine.HeimdallClient.FetchCheckpoint(ctx, -1)
+	if err != nil {
+		return err
+	}
+
+	start, end := checkpoint.StartBlock.Uint64(), checkpoint.EndBlock.Uint64()
+
+	if head := s.blockchain.CurrentHeader().Number.Uint64(); head < end {
+		log.Debug("Light chain behind the latest checkpoint", "head", head, "end", end)
+		return nil
+	}
+
+	hash, err := verifyCheckpointRoot(s.blockchain, start, end, checkpoint.RootHash)
+	if errors.Is(err, errCheckpointRootMismatch) {
+		rewindTo := uint64(0)
+		if exists, number, _ := s.checker.GetWhitelistedCheckpoint(); exists {
+			rewindTo = number
+		} else if start > 0 {
+			rewindTo = start - 1
+		}
+
+		log.Warn("Rewinding light chain due to checkpoint root hash mismatch", "number", rewindTo)
+
+		if err := s.blockchain.SetHead(rewindTo); err != nil {
+			log.Error("Error while rewinding the light chain", "to", rewindTo, "err", err)
+		}
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	s.checker.ProcessCheckpoint(end, hash)
+	log.Info("Whitelisted checkpoint", "start", start, "end", end, "hash", hash)
+
+	return nil
+}
+
+// verifyCheckpointRoot checks the root hash of a checkpoint against the local
+// headers it covers, returning the hash of the last one.
+func verifyCheckpointRoot(chain checkpointHeaderReader, start, end uint64, root common.Hash) (common.Hash, error) {
+	if start > end || end-start+1 > bor.MaxCheckpointLength {
+		return common.Hash{}, errInvalidCheckpoint
+	}
+
+	headers := make([]*types.Header, 0, end-start+1)
+
+	for number := start; number <= end; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return common.Hash{}, errMissingCheckpointHeaders
+		}
+
+		headers = append(headers, header)
+	}
+
+	local, err := bor.ComputeRootHash(headers)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if !bytes.Equal(local, root[:]) {
+		return common.Hash{}, fmt.Errorf("%w: local %x, checkpoint %x", errCheckpointRootMismatch, local, root)
+	}
+
+	return headers[len(headers)-1].Hash(), nil
+}
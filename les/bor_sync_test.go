@@ -0,0 +1,147 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package les
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// testHeaderChain is a contiguous chain of headers, indexed by number.
+type testHeaderChain []*types.Header
+
+func newTestHeaderChain(n int) testHeaderChain {
+	chain := make(testHeaderChain, n)
+	for i := range chain {
+		chain[i] = &types.Header{Number: big.NewInt(int64(i)), Time: uint64(i * 2), Extra: []byte{byte(i)}}
+		if i > 0 {
+			chain[i].ParentHash = chain[i-1].Hash()
+		}
+	}
+	return chain
+}
+
+func (c testHeaderChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number >= uint64(len(c)) {
+		return nil
+	}
+	return c[number]
+}
+
+// Tests that header batches are only accepted if they link up from the origin.
+func TestHeadersRequestValidate(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestHeaderChain(10)
+	forked := &types.Header{Number: big.NewInt(5), ParentHash: common.Hash{0x01}}
+
+	tests := []struct {
+		headers []*types.Header
+		err     error
+	}{
+		{chain[3:8], nil},
+		{chain[3:5], nil},
+		{nil, errInvalidEntryCount},
+		{chain[3:10], errInvalidEntryCount},
+		{chain[4:8], errBrokenHeaderChain},
+		{[]*types.Header{chain[3], chain[4], chain[6]}, errBrokenHeaderChain},
+		{[]*types.Header{chain[3], chain[4], forked}, errBrokenHeaderChain},
+	}
+	for i, tt := range tests {
+		req := &HeadersRequest{Origin: 3, Amount: 5}
+
+		err := req.Validate(nil, &Msg{MsgType: MsgBlockHeaders, Obj: tt.headers})
+		if !errors.Is(err, tt.err) {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+			continue
+		}
+		if err == nil && len(req.Headers) != len(tt.headers) {
+			t.Errorf("test %d: headers mismatch: have %d, want %d", i, len(req.Headers), len(tt.headers))
+		}
+	}
+	if err := (&HeadersRequest{Origin: 3, Amount: 5}).Validate(nil, &Msg{MsgType: MsgBlockBodies}); !errors.Is(err, errInvalidMessageType) {
+		t.Errorf("message type error mismatch: have %v, want %v", err, errInvalidMessageType)
+	}
+
+	// Batches not linking to the expected parent are refused
+	if err := (&HeadersRequest{Origin: 3, Amount: 5, Parent: chain[2].Hash()}).Validate(nil, &Msg{MsgType: MsgBlockHeaders, Obj: []*types.Header(chain[3:8])}); err != nil {
+		t.Errorf("linking headers refused: %v", err)
+	}
+	if err := (&HeadersRequest{Origin: 3, Amount: 5, Parent: chain[1].Hash()}).Validate(nil, &Msg{MsgType: MsgBlockHeaders, Obj: []*types.Header(chain[3:8])}); !errors.Is(err, errBrokenHeaderChain) {
+		t.Errorf("parent error mismatch: have %v, want %v", err, errBrokenHeaderChain)
+	}
+}
+
+// Tests that the common ancestor is the highest header shared with the local chain.
+func TestBorAncestor(t *testing.T) {
+	t.Parallel()
+
+	var (
+		local  = newTestHeaderChain(20)
+		remote = newTestHeaderChain(24)
+	)
+
+	// The remote chain forks off after the header 11
+	for i := 12; i < len(remote); i++ {
+		remote[i] = &types.Header{Number: big.NewInt(int64(i)), ParentHash: remote[i-1].Hash(), Extra: []byte{byte(i), 0x01}}
+	}
+
+	if ancestor, ok := borAncestor(local, remote[4:20]); !ok || ancestor != 11 {
+		t.Fatalf("ancestor mismatch: have %d, want 11", ancestor)
+	}
+	if ancestor, ok := borAncestor(local, local[4:20]); !ok || ancestor != 19 {
+		t.Fatalf("ancestor mismatch: have %d, want 19", ancestor)
+	}
+	if _, ok := borAncestor(local, remote[12:24]); ok {
+		t.Fatalf("ancestor found past the fork")
+	}
+}
+
+// Tests that checkpoints are verified against the root hash of the local headers.
+func TestVerifyCheckpointRoot(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestHeaderChain(32)
+
+	root, err := bor.ComputeRootHash(chain[8:24])
+	if err != nil {
+		t.Fatalf("failed to compute root hash: %v", err)
+	}
+
+	hash, err := verifyCheckpointRoot(chain, 8, 23, common.BytesToHash(root))
+	if err != nil {
+		t.Fatalf("failed to verify checkpoint: %v", err)
+	}
+	if hash != chain[23].Hash() {
+		t.Fatalf("end hash mismatch: have %x, want %x", hash, chain[23].Hash())
+	}
+
+	if _, err := verifyCheckpointRoot(chain, 8, 24, common.BytesToHash(root)); !errors.Is(err, errCheckpointRootMismatch) {
+		t.Fatalf("mismatch error mismatch: have %v, want %v", err, errCheckpointRootMismatch)
+	}
+	if _, err := verifyCheckpointRoot(chain, 24, 40, common.BytesToHash(root)); !errors.Is(err, errMissingCheckpointHeaders) {
+		t.Fatalf("missing headers error mismatch: have %v, want %v", err, errMissingCheckpointHeaders)
+	}
+	if _, err := verifyCheckpointRoot(chain, 24, 8, common.BytesToHash(root)); !errors.Is(err, errInvalidCheckpoint) {
+		t.Fatalf("invalid range error mismatch: have %v, want %v", err, errInvalidCheckpoint)
+	}
+}
@@ -27,10 +27,12 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/bor"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/downloader/whitelist"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
 	"github.com/ethereum/go-ethereum/event"
@@ -61,6 +63,7 @@ type LightEthereum struct {
 	handler            *clientHandler
 	txPool             *light.TxPool
 	blockchain         *light.LightChain
+	checker            *whitelist.Service
 	serverPool         *vfc.ServerPool
 	serverPoolIterator enode.Iterator
 	merger             *consensus.Merger
@@ -103,7 +106,11 @@ func New(stack *node.Node, config *ethconfig.Config) (*LightEthereum, error) {
 	if _, isCompat := genesisErr.(*params.ConfigCompatError); genesisErr != nil && !isCompat {
 		return nil, genesisErr
 	}
-	engine, err := ethconfig.CreateConsensusEngine(chainConfig, config, chainDb, nil)
+	// Bor reads the validator sets from the validator contract, calling it on state
+	// retrieved on demand and proven against the state roots of the headers.
+	apiBackend := &LesApiBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, nil, nil}
+
+	engine, err := ethconfig.CreateConsensusEngine(chainConfig, config, chainDb, ethapi.NewBlockChainAPI(apiBackend))
 	if err != nil {
 		return nil, err
 	}
@@ -133,6 +140,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*LightEthereum, error) {
 		accountManager:  stack.AccountManager(),
 		merger:          merger,
 		engine:          engine,
+		checker:         whitelist.NewService(chainDb),
 		bloomRequests:   make(chan chan *bloombits.Retrieval),
 		bloomIndexer:    core.NewBloomIndexer(chainDb, params.BloomBitsBlocksClient, params.HelperTrieConfirmations),
 		p2pServer:       stack.Server(),
@@ -159,9 +167,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*LightEthereum, error) {
 	// Note: NewLightChain adds the trusted checkpoint so it needs an ODR with
 	// indexers already set but not started yet
 
-	// checker := whitelist.NewService(chainDb)
-
-	if leth.blockchain, err = light.NewLightChain(leth.odr, leth.chainConfig, leth.engine, nil); err != nil {
+	if leth.blockchain, err = light.NewLightChain(leth.odr, leth.chainConfig, leth.engine, leth.checker); err != nil {
 		return nil, err
 	}
 	leth.chainReader = leth.blockchain
@@ -183,7 +189,8 @@ func New(stack *node.Node, config *ethconfig.Config) (*LightEthereum, error) {
 		rawdb.WriteChainConfig(chainDb, genesisHash, chainConfig)
 	}
 
-	leth.ApiBackend = &LesApiBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, leth, nil}
+	apiBackend.eth = leth
+	leth.ApiBackend = apiBackend
 	gpoParams := config.GPO
 	if gpoParams.Default == nil {
 		gpoParams.Default = config.Miner.GasPrice
@@ -359,6 +366,18 @@ func (s *LightEthereum) Start() error {
 	s.wg.Add(bloomServiceThreads)
 	s.startBloomHandlers(params.BloomBitsBlocksClient)
 
+	// Bor chains have no beacon light client to follow, sync the headers from
+	// the servers instead, anchored on the checkpoints
+	if engine, ok := s.engine.(*bor.Bor); ok {
+		s.wg.Add(1)
+		go s.borSyncLoop()
+
+		if engine.HeimdallClient != nil {
+			s.wg.Add(1)
+			go s.borCheckpointLoop(engine)
+		}
+	}
+
 	return nil
 }
 
@@ -43,6 +43,7 @@ var (
 	errCHTHashMismatch     = errors.New("cht hash mismatch")
 	errCHTNumberMismatch   = errors.New("cht number mismatch")
 	errUselessNodes        = errors.New("useless nodes in merkle proof nodeset")
+	errBrokenHeaderChain   = errors.New("non contiguous header chain")
 )
 
 type LesOdrRequest interface {
@@ -62,6 +63,8 @@ func LesRequest(req light.OdrRequest) LesOdrRequest {
 		return (*TrieRequest)(r)
 	case *light.CodeRequest:
 		return (*CodeRequest)(r)
+	case *light.HeadersRequest:
+		return (*HeadersRequest)(r)
 	case *light.ChtRequest:
 		return (*ChtRequest)(r)
 	case *light.BloomRequest:
@@ -312,6 +315,56 @@ type HelperTrieResps struct { // describes all responses, not just a single one
 	AuxData [][]byte
 }
 
+// HeadersRequest is the ODR request type for a contiguous batch of headers
+type HeadersRequest light.HeadersRequest
+
+// GetCost returns the cost of the given ODR request according to the serving
+// peer's cost table (implementation of LesOdrRequest)
+func (r *HeadersRequest) GetCost(peer *serverPeer) uint64 {
+	return peer.getRequestCost(GetBlockHeadersMsg, int(r.Amount))
+}
+
+// CanSend tells if a certain peer is suitable for serving the given request
+func (r *HeadersRequest) CanSend(peer *serverPeer) bool {
+	return peer.HasBlock(common.Hash{}, r.Origin+r.Amount-1, false)
+}
+
+// Request sends an ODR request to the LES network (implementation of LesOdrRequest)
+func (r *HeadersRequest) Request(reqID uint64, peer *serverPeer) error {
+	peer.Log().Debug("Requesting headers", "origin", r.Origin, "amount", r.Amount)
+	return peer.requestHeadersByNumber(reqID, r.Origin, int(r.Amount), 0, false)
+}
+
+// Validate processes an ODR request reply message from the LES network
+// returns true and stores results in memory if the message was a valid reply
+// to the request (implementation of LesOdrRequest). Only the linkage of the
+// headers is checked, their validity is up to the consensus engine.
+func (r *HeadersRequest) Validate(db ethdb.Database, msg *Msg) error {
+	log.Debug("Validating headers", "origin", r.Origin, "amount", r.Amount)
+
+	// Ensure we have a correct message with the requested headers
+	if msg.MsgType != MsgBlockHeaders {
+		return errInvalidMessageType
+	}
+	headers := msg.Obj.([]*types.Header)
+	if len(headers) == 0 || uint64(len(headers)) > r.Amount {
+		return errInvalidEntryCount
+	}
+	if headers[0].Number == nil || headers[0].Number.Uint64() != r.Origin {
+		return errBrokenHeaderChain
+	}
+	if r.Parent != (common.Hash{}) && headers[0].ParentHash != r.Parent {
+		return errBrokenHeaderChain
+	}
+	for i := 1; i < len(headers); i++ {
+		if headers[i].Number == nil || headers[i].Number.Uint64() != headers[i-1].Number.Uint64()+1 || headers[i].ParentHash != headers[i-1].Hash() {
+			return errBrokenHeaderChain
+		}
+	}
+	r.Headers = headers
+	return nil
+}
+
 // ChtRequest is the ODR request type for requesting headers by Canonical Hash Trie, see LesOdrRequest interface
 type ChtRequest light.ChtRequest
 
@@ -187,6 +187,14 @@ func (p *peerCommons) Head() (hash common.Hash) {
 	return p.headInfo.Hash
 }
 
+// headNumber retrieves the number of the current head of a peer.
+func (p *peerCommons) headNumber() uint64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.headInfo.Number
+}
+
 // Td retrieves the current total difficulty of a peer.
 func (p *peerCommons) Td() *big.Int {
 	p.lock.RLock()
@@ -136,6 +136,19 @@ func (req *ReceiptsRequest) StoreResult(db ethdb.Database) {
 	rawdb.WriteReceipts(db, req.Hash, req.Number, req.Receipts)
 }
 
+// HeadersRequest is the ODR request type for retrieving a contiguous batch of
+// headers by number, used by consensus engines without a beacon light client.
+type HeadersRequest struct {
+	Origin  uint64
+	Amount  uint64
+	Parent  common.Hash // Parent hash of the origin header, unchecked if empty
+	Headers []*types.Header
+}
+
+// StoreResult is a noop, the headers are stored once verified and inserted
+// into the chain
+func (req *HeadersRequest) StoreResult(db ethdb.Database) {}
+
 // ChtRequest is the ODR request type for retrieving header by Canonical Hash Trie
 type ChtRequest struct {
 	Config           *IndexerConfig
@@ -0,0 +1,271 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// maxBundles is the maximum number of bundles tracked by the worker at any
+	// point in time.
+	maxBundles = 1024
+
+	// maxBundleBlockRange is the maximum number of blocks a bundle may target.
+	maxBundleBlockRange = 1024
+)
+
+var (
+	errBundleEmpty         = errors.New("bundle contains no transactions")
+	errBundleInvalidRange  = errors.New("invalid bundle block range")
+	errBundleRangeTooLarge = fmt.Errorf("bundle block range exceeds %d blocks", maxBundleBlockRange)
+	errBundlePoolFull      = errors.New("bundle pool is full")
+	errBundleKnown         = errors.New("bundle already known")
+
+	bundleCommittedCounter = metrics.NewRegisteredCounter("worker/bundles/committed", nil)
+	bundleDiscardedCounter = metrics.NewRegisteredCounter("worker/bundles/discarded", nil)
+)
+
+// Bundle is a list of transactions which must be included in a block atomically,
+// in the given order and next to each other, or not at all.
+type Bundle struct {
+	Txs      types.Transactions // Transactions to include, in order
+	MinBlock uint64             // First block number the bundle may be included in
+	MaxBlock uint64             // Last block number the bundle may be included in
+
+	// RevertingTxHashes lists the transactions of the bundle which are allowed
+	// to revert. Every other transaction is revert protected: if it reverts, the
+	// whole bundle is discarded.
+	RevertingTxHashes []common.Hash
+}
+
+// Hash returns the identifier of the bundle, which is the hash of the ordered
+// transaction hashes it contains.
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+// validate sanity checks the bundle before it's accepted by the worker.
+func (b *Bundle) validate(head uint64) error {
+	if len(b.Txs) == 0 {
+		return errBundleEmpty
+	}
+	if b.MaxBlock < b.MinBlock || b.MaxBlock <= head {
+		return errBundleInvalidRange
+	}
+	if b.MaxBlock-head > maxBundleBlockRange {
+		return errBundleRangeTooLarge
+	}
+	return nil
+}
+
+// reverting returns whether the given transaction is allowed to revert.
+func (b *Bundle) reverting(hash common.Hash) bool {
+	for _, allowed := range b.RevertingTxHashes {
+		if allowed == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// bundlePool tracks the bundles submitted to the worker until they expire.
+type bundlePool struct {
+	bundles []*Bundle // Bundles in submission order
+	lock    sync.Mutex
+}
+
+// add inserts a new bundle into the pool.
+func (p *bundlePool) add(bundle *Bundle) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	hash := bundle.Hash()
+	for _, known := range p.bundles {
+		if known.Hash() == hash {
+			return errBundleKnown
+		}
+	}
+	if len(p.bundles) >= maxBundles {
+		return errBundlePoolFull
+	}
+	p.bundles = append(p.bundles, bundle)
+
+	return nil
+}
+
+// eligible drops all the expired bundles and returns the ones which may be
+// included in the block with the given number.
+func (p *bundlePool) eligible(number uint64) []*Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		keep     = p.bundles[:0]
+		eligible []*Bundle
+	)
+	for _, bundle := range p.bundles {
+		if bundle.MaxBlock < number {
+			continue
+		}
+		keep = append(keep, bundle)
+
+		if bundle.MinBlock <= number {
+			eligible = append(eligible, bundle)
+		}
+	}
+	p.bundles = keep
+
+	return eligible
+}
+
+// reset drops the bundles which can't be included on top of the given head
+// anymore, either expired or with transactions included in it.
+func (p *bundlePool) reset(head *types.Block) {
+	included := make(map[common.Hash]struct{}, len(head.Transactions()))
+	for _, tx := range head.Transactions() {
+		included[tx.Hash()] = struct{}{}
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	keep := p.bundles[:0]
+	for _, bundle := range p.bundles {
+		if bundle.MaxBlock <= head.NumberU64() || bundle.includedIn(included) {
+			continue
+		}
+		keep = append(keep, bundle)
+	}
+	for i := len(keep); i < len(p.bundles); i++ {
+		p.bundles[i] = nil
+	}
+	p.bundles = keep
+}
+
+// includedIn returns whether any transaction of the bundle is in the given set,
+// making the bundle either included or no longer includable atomically.
+func (b *Bundle) includedIn(txs map[common.Hash]struct{}) bool {
+	for _, tx := range b.Txs {
+		if _, ok := txs[tx.Hash()]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AddBundle submits a bundle of transactions to be included atomically in one
+// of the blocks produced by the local miner within the bundle's block range.
+func (miner *Miner) AddBundle(bundle *Bundle) error {
+	if err := bundle.validate(miner.worker.chain.CurrentBlock().Number.Uint64()); err != nil {
+		return err
+	}
+	return miner.worker.bundles.add(bundle)
+}
+
+// commitBundles simulates every bundle eligible for the sealing block on top of
+// the current environment and includes the ones which fully succeed. A bundle
+// is simulated on a copy of the environment, so a failure, revert or interrupt
+// half-way through leaves the block untouched.
+func (w *worker) commitBundles(env *environment, interrupt *atomic.Int32, interruptCtx context.Context) error {
+	bundles := w.bundles.eligible(env.header.Number.Uint64())
+	if len(bundles) == 0 {
+		return nil
+	}
+	if env.gasPool == nil {
+		env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+	}
+	env.record.startPhase(PhaseBundles)
+
+	for _, bundle := range bundles {
+		// Check interruption signal and abort building if it's fired.
+		if interrupt != nil {
+			if signal := interrupt.Load(); signal != commitInterruptNone {
+				env.record.endPhase(ExitInterrupt)
+				return signalToErr(signal)
+			}
+		}
+		if interruptCtx != nil {
+			select {
+			case <-interruptCtx.Done():
+				txCommitInterruptCounter.Inc(1)
+				log.Warn("Bundle Level Interrupt")
+				env.record.endPhase(ExitDeadline)
+
+				return nil
+			default:
+			}
+		}
+		sim := env.copy()
+
+		if err := w.simulateBundle(sim, bundle, interruptCtx); err != nil {
+			env.record.bundle(bundle, err)
+			sim.discard()
+			bundleDiscardedCounter.Inc(1)
+			log.Debug("Discarding bundle", "hash", bundle.Hash(), "number", env.header.Number, "err", err)
+
+			continue
+		}
+		// Bundle fully succeeded, adopt the simulated environment. The parallel
+		// execution dependencies are not tracked for bundled transactions, so
+		// mark the block to not advertise any.
+		env.discard()
+		*env = *sim
+		env.bundled = true
+		env.record.bundle(bundle, nil)
+
+		bundleCommittedCounter.Inc(1)
+		log.Debug("Committed bundle", "hash", bundle.Hash(), "number", env.header.Number, "txs", len(bundle.Txs))
+	}
+	return nil
+}
+
+// simulateBundle applies all the transactions of a bundle on the given
+// environment, failing if any of them is invalid or reverts without being
+// allowed to.
+func (w *worker) simulateBundle(env *environment, bundle *Bundle, interruptCtx context.Context) error {
+	for _, tx := range bundle.Txs {
+		if tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
+			return fmt.Errorf("replay protected transaction %x before EIP155", tx.Hash())
+		}
+		env.state.SetTxContext(tx.Hash(), env.tcount)
+
+		if _, err := w.commitTransaction(env, tx, interruptCtx); err != nil {
+			return fmt.Errorf("transaction %x: %w", tx.Hash(), err)
+		}
+		receipt := env.receipts[len(env.receipts)-1]
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.reverting(tx.Hash()) {
+			return fmt.Errorf("transaction %x reverted", tx.Hash())
+		}
+		env.tcount++
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func newBundleTx(nonce uint64) *types.Transaction {
+	return types.MustSignNewTx(testBankKey, types.LatestSigner(ethashChainConfig), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &testUserAddress,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	})
+}
+
+// Tests that bundles are committed atomically: either all transactions of a
+// bundle make it into the block, or none of them.
+func TestCommitBundlesAtomic(t *testing.T) {
+	t.Parallel()
+
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	w, _, cleanup := newTestWorker(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), false, 0, 0)
+	defer cleanup()
+
+	// A bundle with a nonce gap fails half-way through and must leave no trace,
+	// while a valid one must be included in full.
+	broken := &Bundle{Txs: types.Transactions{newBundleTx(0), newBundleTx(2)}, MaxBlock: 1}
+	valid := &Bundle{Txs: types.Transactions{newBundleTx(0), newBundleTx(1)}, MaxBlock: 1}
+
+	for _, bundle := range []*Bundle{broken, valid} {
+		if err := bundle.validate(0); err != nil {
+			t.Fatalf("failed to validate bundle: %v", err)
+		}
+		if err := w.bundles.add(bundle); err != nil {
+			t.Fatalf("failed to add bundle: %v", err)
+		}
+	}
+	env, err := w.prepareWork(&generateParams{timestamp: uint64(time.Now().Unix()), coinbase: testBankAddress})
+	if err != nil {
+		t.Fatalf("failed to prepare work: %v", err)
+	}
+	defer env.discard()
+
+	if err := w.commitBundles(env, nil, context.Background()); err != nil {
+		t.Fatalf("failed to commit bundles: %v", err)
+	}
+	if len(env.txs) != 2 {
+		t.Fatalf("transaction count mismatch: have %d, want 2", len(env.txs))
+	}
+	for i, tx := range valid.Txs {
+		if env.txs[i].Hash() != tx.Hash() {
+			t.Fatalf("transaction %d mismatch: have %x, want %x", i, env.txs[i].Hash(), tx.Hash())
+		}
+	}
+	if balance := env.state.GetBalance(testUserAddress); balance.Cmp(big.NewInt(2000)) != 0 {
+		t.Fatalf("balance mismatch: have %v, want 2000", balance)
+	}
+	if !env.bundled {
+		t.Fatalf("environment not marked as bundled")
+	}
+}
+
+// Tests that bundles are only offered within their block range and dropped once
+// the range is exceeded.
+func TestBundlePoolEligibility(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pool  bundlePool
+		early = &Bundle{Txs: types.Transactions{newBundleTx(0)}, MinBlock: 1, MaxBlock: 2}
+		late  = &Bundle{Txs: types.Transactions{newBundleTx(1)}, MinBlock: 3, MaxBlock: 4}
+	)
+	if err := pool.add(early); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+	if err := pool.add(early); err != errBundleKnown {
+		t.Fatalf("duplicate bundle error mismatch: have %v, want %v", err, errBundleKnown)
+	}
+	if err := pool.add(late); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+	if eligible := pool.eligible(1); len(eligible) != 1 || eligible[0] != early {
+		t.Fatalf("eligible bundles mismatch at block 1: %v", eligible)
+	}
+	if eligible := pool.eligible(3); len(eligible) != 1 || eligible[0] != late {
+		t.Fatalf("eligible bundles mismatch at block 3: %v", eligible)
+	}
+	if len(pool.bundles) != 1 {
+		t.Fatalf("expired bundle not dropped: have %d bundles, want 1", len(pool.bundles))
+	}
+	// A new head drops the bundles it expires or includes
+	var (
+		included = &Bundle{Txs: types.Transactions{newBundleTx(2), newBundleTx(3)}, MinBlock: 5, MaxBlock: 10}
+		pending  = &Bundle{Txs: types.Transactions{newBundleTx(4)}, MinBlock: 5, MaxBlock: 10}
+	)
+	if err := pool.add(included); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+	if err := pool.add(pending); err != nil {
+		t.Fatalf("failed to add bundle: %v", err)
+	}
+	pool.reset(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(4)}).WithBody(types.Transactions{included.Txs[1]}, nil))
+
+	if len(pool.bundles) != 1 || pool.bundles[0] != pending {
+		t.Fatalf("bundles mismatch after new head: %v", pool.bundles)
+	}
+	if err := (&Bundle{Txs: early.Txs, MinBlock: 5, MaxBlock: 4}).validate(0); err != errBundleInvalidRange {
+		t.Fatalf("invalid range error mismatch: have %v, want %v", err, errBundleInvalidRange)
+	}
+}
@@ -55,6 +55,8 @@ type Config struct {
 	CommitInterruptFlag bool           // Interrupt commit when time is up ( default = true)
 
 	NewPayloadTimeout time.Duration // The maximum time allowance for creating a new payload
+
+	RecordBuilds string `toml:",omitempty"` // Directory to record the building of the sealed blocks to, disabled if empty
 }
 
 // DefaultConfig contains default settings for miner.
@@ -0,0 +1,382 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxBuildRecords is the number of build records kept on disk, the oldest ones
+// are deleted first.
+const maxBuildRecords = 1024
+
+var (
+	errRecordEvicted         = errors.New("evicted from the pool")
+	errRecordReplayProtected = errors.New("replay protected before EIP155")
+)
+
+// Outcomes of the transactions considered while building a block.
+const (
+	OutcomeIncluded    = "included"    // Included in the block
+	OutcomeSkipped     = "skipped"     // Nonce too low, the next transaction of the sender is tried
+	OutcomeDropped     = "dropped"     // Failed, the rest of the sender's transactions are skipped
+	OutcomeIgnored     = "ignored"     // Not executable in this block, the rest of the sender's transactions are skipped
+	OutcomeInterrupted = "interrupted" // Interrupted at the commit deadline, the rest of the sender's transactions are skipped
+	OutcomeNotReached  = "not reached" // Never tried, only reported by the replay
+)
+
+// Causes of the end of a building phase.
+const (
+	ExitDone      = "all transactions considered"
+	ExitGas       = "not enough gas for further transactions"
+	ExitDeadline  = "commit interrupt"
+	ExitInterrupt = "interrupted"
+)
+
+// Building phases, in the order they are run.
+const (
+	PhaseBundles = "bundles"
+	PhaseLocal   = "local"
+	PhaseRemote  = "remote"
+)
+
+// BuildRecord captures the inputs and the decisions of the worker building a
+// sealed block, for the build to be replayed offline.
+type BuildRecord struct {
+	Number     uint64         `json:"number"`
+	Hash       common.Hash    `json:"hash"`       // Hash of the sealed block
+	ParentHash common.Hash    `json:"parentHash"` // Hash of the parent the block is built on
+	Header     *types.Header  `json:"header"`     // Header prepared before filling the block
+	Coinbase   common.Address `json:"coinbase"`   // Recipient of the fees
+
+	Started   time.Time `json:"started"`             // Start of the building
+	Filled    time.Time `json:"filled"`              // End of the filling
+	Sealed    time.Time `json:"sealed"`              // Sealing of the block
+	Presealed bool      `json:"presealed"`           // Whether the empty block sealed ahead of the filling was sealed
+	Interrupt string    `json:"interrupt,omitempty"` // Interrupt aborting the filling, if any
+
+	Pending []*RecordedTx     `json:"pending"`           // Pending transactions of the pool
+	Bundles []*RecordedBundle `json:"bundles,omitempty"` // Bundles simulated, in order
+	Phases  []*BuildPhase     `json:"phases"`            // Phases run, in order
+}
+
+// RecordedTx is a pending transaction of the pool at the time of the building.
+type RecordedTx struct {
+	Tx    *types.Transaction `json:"tx"`
+	Time  time.Time          `json:"time"` // Time the transaction was first seen, ordering the equally priced ones
+	Local bool               `json:"local"`
+}
+
+// RecordedBundle is a bundle simulated while building the block.
+type RecordedBundle struct {
+	Bundle    *Bundle `json:"bundle"`
+	Committed bool    `json:"committed"`
+	Reason    string  `json:"reason,omitempty"` // Reason of the discarding
+}
+
+// BuildPhase lists the transactions tried in a phase of the building, in order.
+type BuildPhase struct {
+	Name  string       `json:"name"`
+	Exit  string       `json:"exit"`
+	Steps []*BuildStep `json:"steps"`
+}
+
+// BuildStep is a transaction tried by the worker.
+type BuildStep struct {
+	Hash    common.Hash    `json:"hash"`
+	From    common.Address `json:"from"`
+	Outcome string         `json:"outcome"`
+	Reason  string         `json:"reason,omitempty"`
+}
+
+// Phase returns the phase of the given name, nil if it wasn't run.
+func (r *BuildRecord) Phase(name string) *BuildPhase {
+	for _, phase := range r.Phases {
+		if phase.Name == name {
+			return phase
+		}
+	}
+
+	return nil
+}
+
+// ReadBuildRecord reads a build record written by the worker.
+func ReadBuildRecord(path string) (*BuildRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	record := new(BuildRecord)
+	if err := json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("invalid build record %s: %w", path, err)
+	}
+
+	if record.Header == nil {
+		return nil, fmt.Errorf("invalid build record %s: no header", path)
+	}
+
+	return record, nil
+}
+
+// buildRecord tracks the building of a block. All the methods are safe to call
+// on a nil record, when the recording is disabled.
+type buildRecord struct {
+	data  BuildRecord
+	phase *BuildPhase // Phase being run
+	lock  sync.Mutex  // The empty block may be sealed while the block is filled
+}
+
+// newBuildRecord starts recording the building of the block of the environment.
+func newBuildRecord(env *environment, start time.Time) *buildRecord {
+	return &buildRecord{
+		data: BuildRecord{
+			Number:     env.header.Number.Uint64(),
+			ParentHash: env.header.ParentHash,
+			Header:     types.CopyHeader(env.header),
+			Coinbase:   env.coinbase,
+			Started:    start,
+		},
+	}
+}
+
+// setPending records the pending transactions the block is filled from.
+func (r *buildRecord) setPending(local, remote map[common.Address][]*txpool.LazyTransaction) {
+	if r == nil {
+		return
+	}
+
+	var pending []*RecordedTx
+
+	for isLocal, txs := range map[bool]map[common.Address][]*txpool.LazyTransaction{true: local, false: remote} {
+		for _, accTxs := range txs {
+			for _, ltx := range accTxs {
+				// Evicted transactions are not tried by the worker either
+				if tx := ltx.Resolve(); tx != nil {
+					pending = append(pending, &RecordedTx{Tx: tx.Tx, Time: ltx.Time, Local: isLocal})
+				}
+			}
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data.Pending = pending
+}
+
+// startPhase records the start of a building phase.
+func (r *buildRecord) startPhase(name string) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.phase = &BuildPhase{Name: name, Exit: ExitDone}
+	r.data.Phases = append(r.data.Phases, r.phase)
+}
+
+// endPhase records the cause of the end of the current phase.
+func (r *buildRecord) endPhase(exit string) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.phase != nil {
+		r.phase.Exit = exit
+	}
+}
+
+// step records the outcome of a transaction tried in the current phase.
+func (r *buildRecord) step(hash common.Hash, from common.Address, outcome string, err error) {
+	if r == nil {
+		return
+	}
+
+	step := &BuildStep{Hash: hash, From: from, Outcome: outcome}
+	if err != nil {
+		step.Reason = err.Error()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.phase != nil {
+		r.phase.Steps = append(r.phase.Steps, step)
+	}
+}
+
+// bundle records the result of the simulation of a bundle.
+func (r *buildRecord) bundle(bundle *Bundle, err error) {
+	if r == nil {
+		return
+	}
+
+	recorded := &RecordedBundle{Bundle: bundle, Committed: err == nil}
+	if err != nil {
+		recorded.Reason = err.Error()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data.Bundles = append(r.data.Bundles, recorded)
+}
+
+// filled records the end of the filling, and the interrupt aborting it if any.
+func (r *buildRecord) filled(err error) {
+	if r == nil {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data.Filled = time.Now()
+	if err != nil {
+		r.data.Interrupt = err.Error()
+	}
+}
+
+// encode returns the record of the given sealed block.
+func (r *buildRecord) encode(block *types.Block, presealed bool, sealed time.Time) ([]byte, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	data := r.data
+	data.Hash = block.Hash()
+	data.Sealed = sealed
+	data.Presealed = presealed
+
+	return json.MarshalIndent(&data, "", "  ")
+}
+
+// txOutcome returns the outcome of a transaction which failed to be committed.
+func txOutcome(err error) string {
+	if errors.Is(err, vm.ErrInterrupt) {
+		return OutcomeInterrupted
+	}
+
+	return OutcomeDropped
+}
+
+// buildRecorder writes the records of the sealed blocks to a directory.
+type buildRecorder struct {
+	dir  string
+	keep int
+	lock sync.Mutex // Serialises the writing and the pruning
+}
+
+// newBuildRecorder creates a recorder writing to the given directory.
+func newBuildRecorder(dir string) (*buildRecorder, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	return &buildRecorder{dir: dir, keep: maxBuildRecords}, nil
+}
+
+// write stores the record of a sealed block in the background.
+func (r *buildRecorder) write(record *buildRecord, block *types.Block, presealed bool) {
+	sealed := time.Now()
+
+	go func() {
+		if err := r.store(record, block, presealed, sealed); err != nil {
+			log.Warn("Failed to write build record", "number", block.Number(), "err", err)
+		}
+	}()
+}
+
+// store writes the record of a sealed block and prunes the oldest ones.
+func (r *buildRecorder) store(record *buildRecord, block *types.Block, presealed bool, sealed time.Time) error {
+	data, err := record.encode(block, presealed, sealed)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	path := filepath.Join(r.dir, fmt.Sprintf("%010d-%x.json", block.NumberU64(), block.Hash().Bytes()[:8]))
+	if err := writeFileAtomic(path, data); err != nil {
+		return err
+	}
+
+	log.Debug("Recorded block building", "number", block.Number(), "hash", block.Hash(), "path", path)
+
+	r.prune()
+
+	return nil
+}
+
+// prune deletes the oldest records beyond the number kept.
+func (r *buildRecorder) prune() {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+
+	if len(names) <= r.keep {
+		return
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names[:len(names)-r.keep] {
+		if err := os.Remove(filepath.Join(r.dir, name)); err != nil {
+			log.Warn("Failed to delete build record", "name", name, "err", err)
+		}
+	}
+}
+
+// writeFileAtomic writes the data to a temporary file renamed over the path, so
+// that a record is never read half-written.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,145 @@
+package miner
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Tests that a recorded block building is replayed offline with the same
+// outcome, and that the recorded interrupts are reproduced.
+func TestReplayBuild(t *testing.T) {
+	t.Parallel()
+
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	w, b, cleanup := newTestWorker(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), false, 0, 0)
+	defer cleanup()
+
+	b.txPool.Add([]*txpool.Transaction{{Tx: b.newRandomTxWithNonce(false, 1)}, {Tx: b.newRandomTxWithNonce(false, 2)}}, true, true)
+
+	env, err := w.prepareWork(&generateParams{timestamp: uint64(time.Now().Unix()), coinbase: testBankAddress})
+	if err != nil {
+		t.Fatalf("failed to prepare work: %v", err)
+	}
+	defer env.discard()
+
+	env.record = newBuildRecord(env, time.Now())
+
+	err = w.fillTransactions(context.Background(), nil, env, context.Background())
+	env.record.filled(err)
+
+	if err != nil {
+		t.Fatalf("failed to fill transactions: %v", err)
+	}
+	if len(env.txs) != 3 {
+		t.Fatalf("transaction count mismatch: have %d, want 3", len(env.txs))
+	}
+
+	data, err := env.record.encode(types.NewBlockWithHeader(env.header), false, time.Now())
+	if err != nil {
+		t.Fatalf("failed to encode record: %v", err)
+	}
+
+	replay := func(record *BuildRecord) *BuildReport {
+		t.Helper()
+
+		statedb, err := w.chain.StateAt(w.chain.GetHeaderByHash(record.ParentHash).Root)
+		if err != nil {
+			t.Fatalf("failed to open parent state: %v", err)
+		}
+
+		report, err := ReplayBuild(w.chain, ethashChainConfig, statedb, record)
+		if err != nil {
+			t.Fatalf("failed to replay build: %v", err)
+		}
+
+		return report
+	}
+
+	record := new(BuildRecord)
+	if err := json.Unmarshal(data, record); err != nil {
+		t.Fatalf("failed to decode record: %v", err)
+	}
+
+	report := replay(record)
+	if len(report.Included) != len(env.txs) {
+		t.Fatalf("included count mismatch: have %d, want %d", len(report.Included), len(env.txs))
+	}
+	for i, tx := range env.txs {
+		if report.Included[i] != tx.Hash() {
+			t.Fatalf("included transaction %d mismatch: have %x, want %x", i, report.Included[i], tx.Hash())
+		}
+	}
+	if report.GasUsed != env.header.GasUsed {
+		t.Fatalf("gas used mismatch: have %d, want %d", report.GasUsed, env.header.GasUsed)
+	}
+	if report.Divergent != 0 {
+		t.Fatalf("divergent outcomes: %d", report.Divergent)
+	}
+
+	// The block committed at the deadline after the first transaction, the
+	// next ones are explained
+	phase := record.Phase(PhaseLocal)
+	if phase == nil {
+		t.Fatalf("local phase not recorded")
+	}
+	phase.Steps, phase.Exit = phase.Steps[:1], ExitDeadline
+
+	report = replay(record)
+	if len(report.Included) != 1 || report.Included[0] != env.txs[0].Hash() {
+		t.Fatalf("included transactions mismatch: have %x", report.Included)
+	}
+	for _, tx := range report.Txs[1:] {
+		if tx.Outcome != OutcomeNotReached {
+			t.Fatalf("transaction %x outcome mismatch: have %s, want %s", tx.Hash, tx.Outcome, OutcomeNotReached)
+		}
+	}
+	if reason := report.Txs[1].Reason; reason != ExitDeadline {
+		t.Fatalf("transaction %x reason mismatch: have %q, want %q", report.Txs[1].Hash, reason, ExitDeadline)
+	}
+}
+
+// Tests that the recorder only keeps the most recent records.
+func TestBuildRecorderPrune(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	recorder, err := newBuildRecorder(dir)
+	if err != nil {
+		t.Fatalf("failed to create recorder: %v", err)
+	}
+	recorder.keep = 2
+
+	for number := int64(1); number <= 3; number++ {
+		header := &types.Header{Number: big.NewInt(number), Difficulty: big.NewInt(1)}
+		record := &buildRecord{data: BuildRecord{Number: uint64(number), Header: header}}
+
+		if err := recorder.store(record, types.NewBlockWithHeader(header), false, time.Now()); err != nil {
+			t.Fatalf("failed to store record of block %d: %v", number, err)
+		}
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(matches) != 2 {
+		t.Fatalf("record count mismatch: have %d, want 2", len(matches))
+	}
+
+	record, err := ReadBuildRecord(matches[0])
+	if err != nil {
+		t.Fatalf("failed to read record: %v", err)
+	}
+	if record.Number != 2 {
+		t.Fatalf("oldest record mismatch: have block %d, want 2", record.Number)
+	}
+}
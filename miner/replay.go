@@ -0,0 +1,438 @@
+// Copyright 2023 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package miner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// BuildReport explains the inclusion or exclusion of every transaction of a
+// replayed block building.
+type BuildReport struct {
+	Txs       []*TxReport   // Transactions of the pool and of the bundles, in the order they were considered
+	Included  []common.Hash // Transactions included in the replayed block, in order
+	GasUsed   uint64        // Gas used by the replayed block
+	Divergent int           // Number of transactions with an outcome different from the recorded one
+}
+
+// TxReport explains the outcome of a transaction in a replayed block building.
+type TxReport struct {
+	Hash     common.Hash
+	From     common.Address
+	Nonce    uint64
+	Phase    string // Phase the transaction was considered in, empty if never tried
+	Outcome  string
+	Reason   string
+	Recorded string // Outcome recorded by the worker, if it differs from the replayed one
+	GasUsed  uint64
+}
+
+// ReplayBuild re-runs the recorded building of a block on top of the state of
+// its parent, reproducing the interrupts of the worker, and explains the
+// outcome of every transaction of the pool.
+func ReplayBuild(chain core.ChainContext, config *params.ChainConfig, statedb *state.StateDB, record *BuildRecord) (*BuildReport, error) {
+	header := types.CopyHeader(record.Header)
+	header.GasUsed = 0
+
+	r := &replayer{
+		chain:    chain,
+		config:   config,
+		statedb:  statedb,
+		header:   header,
+		signer:   types.MakeSigner(config, header.Number, header.Time),
+		gasPool:  new(core.GasPool).AddGas(header.GasLimit),
+		recorded: make(map[common.Hash]*BuildStep),
+		reports:  make(map[common.Hash]*TxReport),
+		report:   new(BuildReport),
+	}
+
+	for _, phase := range record.Phases {
+		for _, step := range phase.Steps {
+			r.recorded[step.Hash] = step
+		}
+	}
+
+	// Bundles are committed first, the recorded ones are simulated again in order
+	for _, bundle := range record.Bundles {
+		if err := r.commitBundle(bundle); err != nil {
+			return nil, err
+		}
+	}
+
+	// Then the pending transactions, split into locals and remotes
+	local, remote := make(map[common.Address][]*txpool.LazyTransaction), make(map[common.Address][]*txpool.LazyTransaction)
+
+	pending, err := r.sortPending(record.Pending)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ptx := range pending {
+		txs := remote
+		if ptx.Local {
+			txs = local
+		}
+
+		from, _ := types.Sender(r.signer, ptx.Tx)
+		txs[from] = append(txs[from], &txpool.LazyTransaction{
+			Hash:      ptx.Tx.Hash(),
+			Tx:        &txpool.Transaction{Tx: ptx.Tx},
+			Time:      ptx.Time,
+			GasFeeCap: ptx.Tx.GasFeeCap(),
+			GasTipCap: ptx.Tx.GasTipCap(),
+		})
+	}
+
+	for _, phase := range []struct {
+		name string
+		txs  map[common.Address][]*txpool.LazyTransaction
+	}{{PhaseLocal, local}, {PhaseRemote, remote}} {
+		if len(phase.txs) == 0 {
+			continue
+		}
+
+		if err := r.commitTransactions(phase.name, phase.txs, record); err != nil {
+			return nil, err
+		}
+	}
+
+	r.explainPending(pending)
+
+	for _, report := range r.report.Txs {
+		if step, ok := r.recorded[report.Hash]; ok && step.Outcome != report.Outcome {
+			report.Recorded = step.Outcome
+			r.report.Divergent++
+		}
+	}
+
+	r.report.GasUsed = header.GasUsed
+
+	return r.report, nil
+}
+
+// replayer re-runs a recorded block building.
+type replayer struct {
+	chain   core.ChainContext
+	config  *params.ChainConfig
+	statedb *state.StateDB
+	header  *types.Header
+	signer  types.Signer
+	gasPool *core.GasPool
+	tcount  int
+
+	recorded map[common.Hash]*BuildStep // Outcomes recorded by the worker
+	reports  map[common.Hash]*TxReport  // Outcomes of the replay
+	report   *BuildReport
+
+	stop string // Cause of the end of the building, if stopped before all transactions were considered
+}
+
+// add reports the outcome of a transaction.
+func (r *replayer) add(tx *types.Transaction, from common.Address, phase string, outcome string, reason string, gasUsed uint64) {
+	report := &TxReport{
+		Hash:    tx.Hash(),
+		From:    from,
+		Nonce:   tx.Nonce(),
+		Phase:   phase,
+		Outcome: outcome,
+		Reason:  reason,
+		GasUsed: gasUsed,
+	}
+
+	r.reports[report.Hash] = report
+	r.report.Txs = append(r.report.Txs, report)
+
+	if outcome == OutcomeIncluded {
+		r.report.Included = append(r.report.Included, report.Hash)
+	}
+}
+
+// apply executes a transaction, reverting its changes if it fails.
+func (r *replayer) apply(tx *types.Transaction) (*types.Receipt, error) {
+	var (
+		snap = r.statedb.Snapshot()
+		gp   = r.gasPool.Gas()
+	)
+
+	r.statedb.SetTxContext(tx.Hash(), r.tcount)
+
+	receipt, err := core.ApplyTransaction(r.config, r.chain, &r.header.Coinbase, r.gasPool, r.statedb, r.header, tx, &r.header.GasUsed, vm.Config{}, context.Background())
+	if err != nil {
+		r.statedb.RevertToSnapshot(snap)
+		r.gasPool.SetGas(gp)
+
+		return nil, err
+	}
+
+	r.tcount++
+
+	return receipt, nil
+}
+
+// commitBundle simulates a recorded bundle, committing it only if all its
+// transactions succeed.
+func (r *replayer) commitBundle(recorded *RecordedBundle) error {
+	bundle := recorded.Bundle
+	if bundle == nil {
+		return errors.New("invalid build record: bundle without transactions")
+	}
+
+	var (
+		snap     = r.statedb.Snapshot()
+		gp       = r.gasPool.Gas()
+		gasUsed  = r.header.GasUsed
+		tcount   = r.tcount
+		receipts []*types.Receipt
+		err      error
+	)
+
+	for _, tx := range bundle.Txs {
+		if tx.Protected() && !r.config.IsEIP155(r.header.Number) {
+			err = fmt.Errorf("replay protected transaction %x before EIP155", tx.Hash())
+			break
+		}
+
+		var receipt *types.Receipt
+
+		if receipt, err = r.apply(tx); err != nil {
+			err = fmt.Errorf("transaction %x: %w", tx.Hash(), err)
+			break
+		}
+
+		if receipt.Status == types.ReceiptStatusFailed && !bundle.reverting(tx.Hash()) {
+			err = fmt.Errorf("transaction %x reverted", tx.Hash())
+			break
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	if err != nil {
+		r.statedb.RevertToSnapshot(snap)
+		r.gasPool.SetGas(gp)
+		r.header.GasUsed, r.tcount = gasUsed, tcount
+	}
+
+	for i, tx := range bundle.Txs {
+		from, _ := types.Sender(r.signer, tx)
+
+		if err != nil {
+			r.add(tx, from, PhaseBundles, OutcomeDropped, fmt.Sprintf("bundle %x discarded: %v", bundle.Hash(), err), 0)
+		} else {
+			r.add(tx, from, PhaseBundles, OutcomeIncluded, fmt.Sprintf("bundle %x", bundle.Hash()), receipts[i].GasUsed)
+		}
+
+		// The worker records the bundles, not their transactions
+		outcome := OutcomeIncluded
+		if !recorded.Committed {
+			outcome = OutcomeDropped
+		}
+
+		r.recorded[tx.Hash()] = &BuildStep{Hash: tx.Hash(), From: from, Outcome: outcome, Reason: recorded.Reason}
+	}
+
+	return nil
+}
+
+// commitTransactions mirrors the worker filling the block with the transactions
+// of a phase, stopping where the worker was interrupted.
+func (r *replayer) commitTransactions(name string, pending map[common.Address][]*txpool.LazyTransaction, record *BuildRecord) error {
+	if r.stop != "" && r.stop != ExitGas {
+		return nil
+	}
+
+	// The interrupts depend on the timing of the worker, the phase stops after
+	// the same number of transactions
+	limit := -1
+
+	phase := record.Phase(name)
+
+	switch {
+	case phase == nil:
+		r.stop = ExitInterrupt
+		if record.Interrupt != "" {
+			r.stop = fmt.Sprintf("%s: %s", ExitInterrupt, record.Interrupt)
+		}
+
+		return nil
+
+	case phase.Exit == ExitDeadline || phase.Exit == ExitInterrupt:
+		limit = len(phase.Steps)
+	}
+
+	txs := newTransactionsByPriceAndNonce(r.signer, pending, r.header.BaseFee)
+
+	for steps := 0; ; steps++ {
+		if limit >= 0 && steps >= limit {
+			r.stop = phase.Exit
+			if phase.Exit == ExitInterrupt && record.Interrupt != "" {
+				r.stop = fmt.Sprintf("%s: %s", ExitInterrupt, record.Interrupt)
+			}
+
+			return nil
+		}
+
+		if r.gasPool.Gas() < params.TxGas {
+			r.stop = ExitGas
+			return nil
+		}
+
+		ltx := txs.Peek()
+		if ltx == nil {
+			return nil
+		}
+
+		tx := ltx.Tx.Tx
+		from, _ := types.Sender(r.signer, tx)
+
+		if options := tx.GetOptions(); options != nil {
+			err := r.header.ValidateBlockNumberOptions4337(options.BlockNumberMin, options.BlockNumberMax)
+			if err == nil {
+				err = r.header.ValidateTimestampOptions4337(options.TimestampMin, options.TimestampMax)
+			}
+
+			if err == nil {
+				err = r.statedb.ValidateKnownAccounts(options.KnownAccounts)
+			}
+
+			if err != nil {
+				r.add(tx, from, name, OutcomeIgnored, fmt.Sprintf("conditional transaction: %v", err), 0)
+				txs.Pop()
+
+				continue
+			}
+		}
+
+		if tx.Protected() && !r.config.IsEIP155(r.header.Number) {
+			r.add(tx, from, name, OutcomeIgnored, errRecordReplayProtected.Error(), 0)
+			txs.Pop()
+
+			continue
+		}
+
+		// The interrupted transactions are not executed again, the worker reverted them
+		if step, ok := r.recorded[tx.Hash()]; ok && step.Outcome == OutcomeInterrupted {
+			r.add(tx, from, name, OutcomeInterrupted, step.Reason, 0)
+			txs.Pop()
+
+			continue
+		}
+
+		receipt, err := r.apply(tx)
+
+		switch {
+		case errors.Is(err, core.ErrNonceTooLow):
+			r.add(tx, from, name, OutcomeSkipped, err.Error(), 0)
+			txs.Shift()
+
+		case err == nil:
+			reason := ""
+			if receipt.Status == types.ReceiptStatusFailed {
+				reason = "reverted"
+			}
+
+			r.add(tx, from, name, OutcomeIncluded, reason, receipt.GasUsed)
+			txs.Shift()
+
+		default:
+			r.add(tx, from, name, txOutcome(err), err.Error(), 0)
+			txs.Pop()
+		}
+	}
+}
+
+// sortPending orders the pending transactions by sender and nonce.
+func (r *replayer) sortPending(pending []*RecordedTx) ([]*RecordedTx, error) {
+	senders := make(map[*RecordedTx]common.Address, len(pending))
+
+	for _, ptx := range pending {
+		if ptx.Tx == nil {
+			return nil, errors.New("invalid build record: pending entry without transaction")
+		}
+
+		from, err := types.Sender(r.signer, ptx.Tx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pending transaction %x: %w", ptx.Tx.Hash(), err)
+		}
+
+		senders[ptx] = from
+	}
+
+	sorted := make([]*RecordedTx, len(pending))
+	copy(sorted, pending)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if a, b := senders[sorted[i]], senders[sorted[j]]; a != b {
+			return a.Cmp(b) < 0
+		}
+
+		return sorted[i].Tx.Nonce() < sorted[j].Tx.Nonce()
+	})
+
+	return sorted, nil
+}
+
+// explainPending reports the pending transactions never tried, with the reason
+// the worker didn't reach them.
+func (r *replayer) explainPending(pending []*RecordedTx) {
+	blockers := make(map[common.Address]*TxReport) // First transaction of each sender blocking the next ones
+
+	for _, ptx := range pending {
+		from, _ := types.Sender(r.signer, ptx.Tx)
+
+		if report, ok := r.reports[ptx.Tx.Hash()]; ok {
+			if _, blocked := blockers[from]; !blocked && report.Outcome != OutcomeIncluded && report.Outcome != OutcomeSkipped {
+				blockers[from] = report
+			}
+
+			continue
+		}
+
+		var reason string
+
+		switch blocker := blockers[from]; {
+		case blocker != nil:
+			reason = fmt.Sprintf("earlier transaction %x of the sender %s", blocker.Hash, blocker.Outcome)
+
+		case r.header.BaseFee != nil && ptx.Tx.GasFeeCap().Cmp(r.header.BaseFee) < 0:
+			reason = fmt.Sprintf("fee cap %v below base fee %v", ptx.Tx.GasFeeCap(), r.header.BaseFee)
+
+		case r.stop != "":
+			reason = r.stop
+
+		default:
+			reason = "not tried"
+		}
+
+		r.add(ptx.Tx, from, "", OutcomeNotReached, reason, 0)
+
+		if blockers[from] == nil {
+			blockers[from] = r.reports[ptx.Tx.Hash()]
+		}
+	}
+}
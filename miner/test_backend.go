@@ -425,6 +425,16 @@ func (w *worker) fillTransactionsWithDelay(ctx context.Context, interrupt *atomi
 		err             error
 	)
 
+	// Bundles are committed ahead of the individually ordered transactions, so
+	// that their atomicity and ordering are not disturbed by the rest of the pool.
+	tracing.Exec(ctx, "", "worker.CommitBundles", func(ctx context.Context, span trace.Span) {
+		err = w.commitBundles(env, interrupt, interruptCtx)
+	})
+
+	if err != nil {
+		return err
+	}
+
 	if len(localTxs) > 0 {
 		var txs *transactionsByPriceAndNonce
 
@@ -711,7 +721,9 @@ mainloop:
 		tempVanity := env.header.Extra[:types.ExtraVanityLength]
 		tempSeal := env.header.Extra[len(env.header.Extra)-types.ExtraSealLength:]
 
-		if len(mvReadMapList) > 0 {
+		// Dependencies are not tracked for bundled transactions, don't
+		// advertise any if the block contains bundles.
+		if len(mvReadMapList) > 0 && !env.bundled {
 			tempDeps := make([][]uint64, len(mvReadMapList))
 
 			for j := range deps[0] {
@@ -112,6 +112,10 @@ type environment struct {
 
 	depsMVFullWriteList [][]blockstm.WriteDescriptor
 	mvReadMapList       []map[blockstm.Key]blockstm.ReadDescriptor
+
+	bundled bool // Whether bundles were committed, disabling dependency hints
+
+	record *buildRecord // Record of the building, nil if not recorded
 }
 
 // copy creates a deep copy of environment.
@@ -125,6 +129,8 @@ func (env *environment) copy() *environment {
 		receipts:            copyReceipts(env.receipts),
 		depsMVFullWriteList: env.depsMVFullWriteList,
 		mvReadMapList:       env.mvReadMapList,
+		bundled:             env.bundled,
+		record:              env.record,
 	}
 
 	if env.gasPool != nil {
@@ -155,6 +161,9 @@ type task struct {
 	state     *state.StateDB
 	block     *types.Block
 	createdAt time.Time
+
+	record    *buildRecord // Record of the building, nil if not recorded
+	presealed bool         // Whether the block is the empty one sealed ahead of the filling
 }
 
 const (
@@ -268,6 +277,10 @@ type worker struct {
 	interruptCommitFlag bool   // Interrupt commit ( Default true )
 	interruptedTxCache  *vm.TxCache
 
+	bundles bundlePool // Bundles awaiting atomic inclusion in a sealing block
+
+	recorder *buildRecorder // Recorder of the sealed blocks building, nil if disabled
+
 	// noempty is the flag used to control whether the feature of pre-seal empty
 	// block is enabled. The default value is false(pre-seal is enabled by default).
 	// But in some special scenario the consensus engine will seal blocks instantaneously,
@@ -343,6 +356,16 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 
 	worker.newpayloadTimeout = newpayloadTimeout
 
+	if config.RecordBuilds != "" {
+		recorder, err := newBuildRecorder(config.RecordBuilds)
+		if err != nil {
+			log.Warn("Failed to create block building recorder", "dir", config.RecordBuilds, "err", err)
+		} else {
+			log.Info("Recording block building", "dir", config.RecordBuilds)
+			worker.recorder = recorder
+		}
+	}
+
 	ctx := tracing.WithTracer(context.Background(), otel.GetTracerProvider().Tracer("MinerWorker"))
 
 	worker.wg.Add(4)
@@ -530,6 +553,7 @@ func (w *worker) newWorkLoop(ctx context.Context, recommit time.Duration) {
 
 		case head := <-w.chainHeadCh:
 			clearPending(head.Block.NumberU64())
+			w.bundles.reset(head.Block)
 
 			timestamp = time.Now().Unix()
 			commit(false, commitInterruptNewHead)
@@ -823,6 +847,10 @@ func (w *worker) resultLoop() {
 			log.Info("Successfully sealed new block", "number", block.Number(), "sealhash", sealhash, "hash", hash,
 				"elapsed", common.PrettyDuration(time.Since(task.createdAt)))
 
+			if w.recorder != nil && task.record != nil {
+				w.recorder.write(task.record, block, task.presealed)
+			}
+
 			// Broadcast the block and announce chain insertion event
 			w.mux.Post(core.NewMinedBlockEvent{Block: block})
 
@@ -959,6 +987,8 @@ mainloop:
 		if interrupt != nil {
 			if signal := interrupt.Load(); signal != commitInterruptNone {
 				breakCause = "interrupt"
+				env.record.endPhase(ExitInterrupt)
+
 				return signalToErr(signal)
 			}
 		}
@@ -973,6 +1003,8 @@ mainloop:
 			case <-interruptCtx.Done():
 				txCommitInterruptCounter.Inc(1)
 				log.Warn("Tx Level Interrupt")
+				env.record.endPhase(ExitDeadline)
+
 				break mainloop
 			default:
 			}
@@ -982,6 +1014,8 @@ mainloop:
 		if env.gasPool.Gas() < params.TxGas {
 			breakCause = "Not enough gas for further transactions"
 			log.Trace("Not enough gas for further transactions", "have", env.gasPool, "want", params.TxGas)
+			env.record.endPhase(ExitGas)
+
 			break
 		}
 		// Retrieve the next transaction and abort if all done.
@@ -993,6 +1027,7 @@ mainloop:
 		tx := ltx.Resolve()
 		if tx == nil {
 			log.Warn("Ignoring evicted transaction")
+			env.record.step(ltx.Hash, common.Address{}, OutcomeIgnored, errRecordEvicted)
 
 			txs.Pop()
 			continue
@@ -1006,6 +1041,7 @@ mainloop:
 		if options := tx.Tx.GetOptions(); options != nil {
 			if err := env.header.ValidateBlockNumberOptions4337(options.BlockNumberMin, options.BlockNumberMax); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Tx.Hash(), "reason", err)
+				env.record.step(tx.Tx.Hash(), from, OutcomeIgnored, err)
 				txs.Pop()
 
 				continue
@@ -1013,6 +1049,7 @@ mainloop:
 
 			if err := env.header.ValidateTimestampOptions4337(options.TimestampMin, options.TimestampMax); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Tx.Hash(), "reason", err)
+				env.record.step(tx.Tx.Hash(), from, OutcomeIgnored, err)
 				txs.Pop()
 
 				continue
@@ -1020,6 +1057,7 @@ mainloop:
 
 			if err := env.state.ValidateKnownAccounts(options.KnownAccounts); err != nil {
 				log.Trace("Dropping conditional transaction", "from", from, "hash", tx.Tx.Hash(), "reason", err)
+				env.record.step(tx.Tx.Hash(), from, OutcomeIgnored, err)
 				txs.Pop()
 
 				continue
@@ -1030,6 +1068,7 @@ mainloop:
 		// phase, start ignoring the sender until we do.
 		if tx.Tx.Protected() && !w.chainConfig.IsEIP155(env.header.Number) {
 			log.Trace("Ignoring reply protected transaction", "hash", tx.Tx.Hash(), "eip155", w.chainConfig.EIP155Block)
+			env.record.step(tx.Tx.Hash(), from, OutcomeIgnored, errRecordReplayProtected)
 
 			txs.Pop()
 			continue
@@ -1049,12 +1088,14 @@ mainloop:
 		case errors.Is(err, core.ErrNonceTooLow):
 			// New head notification data race between the transaction pool and miner, shift
 			log.Trace("Skipping transaction with low nonce", "sender", from, "nonce", tx.Tx.Nonce())
+			env.record.step(tx.Tx.Hash(), from, OutcomeSkipped, err)
 			txs.Shift()
 
 		case errors.Is(err, nil):
 			// Everything ok, collect the logs and shift in the next transaction from the same account
 			coalescedLogs = append(coalescedLogs, logs...)
 			env.tcount++
+			env.record.step(tx.Tx.Hash(), from, OutcomeIncluded, nil)
 
 			if EnableMVHashMap && w.IsRunning() {
 				env.depsMVFullWriteList = append(env.depsMVFullWriteList, env.state.MVFullWriteList())
@@ -1083,6 +1124,7 @@ mainloop:
 			// Transaction is regarded as invalid, drop all consecutive transactions from
 			// the same sender because of `nonce-too-high` clause.
 			log.Debug("Transaction failed, account skipped", "hash", tx.Tx.Hash(), "err", err)
+			env.record.step(tx.Tx.Hash(), from, txOutcome(err), err)
 			txs.Pop()
 		}
 
@@ -1102,7 +1144,9 @@ mainloop:
 		tempVanity := env.header.Extra[:types.ExtraVanityLength]
 		tempSeal := env.header.Extra[len(env.header.Extra)-types.ExtraSealLength:]
 
-		if len(env.mvReadMapList) > 0 {
+		// Dependencies are not tracked for bundled transactions, don't
+		// advertise any if the block contains bundles.
+		if len(env.mvReadMapList) > 0 && !env.bundled {
 			tempDeps := make([][]uint64, len(env.mvReadMapList))
 
 			for j := range deps[0] {
@@ -1418,6 +1462,8 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 			}
 		}
 
+		env.record.setPending(localTxs, remoteTxs)
+
 		postLocalsTime := time.Now()
 
 		tracing.SetAttributes(
@@ -1435,6 +1481,16 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 		err             error
 	)
 
+	// Bundles are committed ahead of the individually ordered transactions, so
+	// that their atomicity and ordering are not disturbed by the rest of the pool.
+	tracing.Exec(ctx, "", "worker.CommitBundles", func(ctx context.Context, span trace.Span) {
+		err = w.commitBundles(env, interrupt, interruptCtx)
+	})
+
+	if err != nil {
+		return err
+	}
+
 	if len(localTxs) > 0 {
 		var txs *transactionsByPriceAndNonce
 
@@ -1453,6 +1509,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 		})
 
 		tracing.Exec(ctx, "", "worker.LocalCommitTransactions", func(ctx context.Context, span trace.Span) {
+			env.record.startPhase(PhaseLocal)
 			err = w.commitTransactions(env, txs, interrupt, interruptCtx)
 		})
 
@@ -1481,6 +1538,7 @@ func (w *worker) fillTransactions(ctx context.Context, interrupt *atomic.Int32,
 		})
 
 		tracing.Exec(ctx, "", "worker.RemoteCommitTransactions", func(ctx context.Context, span trace.Span) {
+			env.record.startPhase(PhaseRemote)
 			err = w.commitTransactions(env, txs, interrupt, interruptCtx)
 		})
 
@@ -1567,6 +1625,10 @@ func (w *worker) commitWork(ctx context.Context, interrupt *atomic.Int32, noempt
 		return
 	}
 
+	if w.recorder != nil {
+		work.record = newBuildRecord(work, start)
+	}
+
 	// nolint:contextcheck
 	var interruptCtx = context.Background()
 
@@ -1597,6 +1659,7 @@ func (w *worker) commitWork(ctx context.Context, interrupt *atomic.Int32, noempt
 	}
 	// Fill pending transactions from the txpool into the block.
 	err = w.fillTransactions(ctx, interrupt, work, interruptCtx)
+	work.record.filled(err)
 
 	switch {
 	case err == nil:
@@ -1692,7 +1755,7 @@ func (w *worker) commit(ctx context.Context, env *environment, interval func(),
 		// If we're post merge, just ignore
 		if !w.isTTDReached(block.Header()) {
 			select {
-			case w.taskCh <- &task{ctx: ctx, receipts: env.receipts, state: env.state, block: block, createdAt: time.Now()}:
+			case w.taskCh <- &task{ctx: ctx, receipts: env.receipts, state: env.state, block: block, createdAt: time.Now(), record: env.record, presealed: !update}:
 				fees := totalFees(block, env.receipts)
 				feesInEther := new(big.Float).Quo(new(big.Float).SetInt(fees), big.NewFloat(params.Ether))
 				log.Info("Commit new sealing work", "number", block.Number(), "sealhash", w.engine.SealHash(block.Header()),
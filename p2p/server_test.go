@@ -341,6 +341,54 @@ func TestServerAtCap(t *testing.T) {
 	}
 }
 
+// This test checks that only trusted connections are accepted after the encryption
+// handshake when the server is restricted to trusted nodes.
+func TestServerTrustedOnly(t *testing.T) {
+	trustedNode := newkey()
+	trustedID := enode.PubkeyToIDV4(&trustedNode.PublicKey)
+
+	srv := &Server{
+		Config: Config{
+			PrivateKey:   newkey(),
+			MaxPeers:     10,
+			NoDial:       true,
+			NoDiscovery:  true,
+			TrustedNodes: []*enode.Node{newNode(trustedID, "")},
+			TrustedOnly:  true,
+			Logger:       testlog.Logger(t, log.LvlTrace),
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+
+	defer srv.Stop()
+
+	newconn := func(id enode.ID) *conn {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(&trustedNode.PublicKey, fd, nil)
+		node := enode.SignNull(new(enr.Record), id)
+
+		return &conn{fd: fd, transport: tx, flags: inboundConn, node: node, cont: make(chan error)}
+	}
+
+	// Try inserting a non-trusted connection, with room in the peer set.
+	if err := srv.checkpoint(newconn(randomID()), srv.checkpointPostHandshake); err != DiscUnexpectedIdentity {
+		t.Error("wrong error for untrusted conn @posthandshake:", err)
+	}
+	// Try inserting a trusted connection.
+	c := newconn(trustedID)
+	if err := srv.checkpoint(c, srv.checkpointPostHandshake); err != nil {
+		t.Error("unexpected error for trusted conn @posthandshake:", err)
+	}
+	// Remove from trusted set and try again
+	srv.RemoveTrustedPeer(newNode(trustedID, ""))
+
+	if err := srv.checkpoint(newconn(trustedID), srv.checkpointPostHandshake); err != DiscUnexpectedIdentity {
+		t.Error("wrong error for untrusted conn @posthandshake:", err)
+	}
+}
+
 func TestServerPeerLimits(t *testing.T) {
 	srvkey := newkey()
 	clientkey := newkey()
@@ -0,0 +1,299 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BorParams are the effective Bor consensus parameters at a given block.
+type BorParams struct {
+	Number           uint64
+	Period           uint64
+	ProducerDelay    uint64
+	Sprint           uint64
+	BackupMultiplier uint64
+	BurntContract    common.Address
+	StateSyncDelay   uint64
+	Jaipur           bool
+	Delhi            bool
+	Indore           bool
+	BlockAlloc       bool // Whether contract codes are replaced at this very block
+}
+
+// ParamsAt returns the effective consensus parameters at the given block.
+func (c *BorConfig) ParamsAt(number uint64) *BorParams {
+	key := strconv.FormatUint(number, 10)
+	_, alloc := c.BlockAlloc[key]
+
+	block := new(big.Int).SetUint64(number)
+	params := &BorParams{
+		Number:     number,
+		Jaipur:     c.IsJaipur(block),
+		Delhi:      c.IsDelhi(block),
+		Indore:     c.IsIndore(block),
+		BlockAlloc: alloc,
+	}
+
+	// The schedules don't necessarily cover the block, which the helper doesn't
+	// expect, so only the ones scheduled by then are resolved
+	if scheduledBy(c.Period, number) {
+		params.Period = c.CalculatePeriod(number)
+	}
+
+	if scheduledBy(c.ProducerDelay, number) {
+		params.ProducerDelay = c.CalculateProducerDelay(number)
+	}
+
+	if scheduledBy(c.Sprint, number) {
+		params.Sprint = c.CalculateSprint(number)
+	}
+
+	if scheduledBy(c.BackupMultiplier, number) {
+		params.BackupMultiplier = c.CalculateBackupMultiplier(number)
+	}
+
+	if scheduledBy(c.BurntContract, number) {
+		params.BurntContract = common.HexToAddress(c.CalculateBurntContract(number))
+	}
+
+	if scheduledBy(c.StateSyncConfirmationDelay, number) {
+		params.StateSyncDelay = c.CalculateStateSyncDelay(number)
+	}
+
+	return params
+}
+
+// Schedule returns the sorted blocks at which any of the consensus parameters
+// changes, including the fork blocks.
+func (c *BorConfig) Schedule() []uint64 {
+	blocks := make(map[uint64]struct{})
+
+	for _, keys := range [][]string{
+		mapKeys(c.Period),
+		mapKeys(c.ProducerDelay),
+		mapKeys(c.Sprint),
+		mapKeys(c.BackupMultiplier),
+		mapKeys(c.BurntContract),
+		mapKeys(c.StateSyncConfirmationDelay),
+		mapKeys(c.BlockAlloc),
+	} {
+		for _, key := range keys {
+			if number, err := strconv.ParseUint(key, 10, 64); err == nil {
+				blocks[number] = struct{}{}
+			}
+		}
+	}
+
+	for _, fork := range []*big.Int{c.JaipurBlock, c.DelhiBlock, c.IndoreBlock} {
+		if fork != nil && fork.IsUint64() {
+			blocks[fork.Uint64()] = struct{}{}
+		}
+	}
+
+	schedule := make([]uint64, 0, len(blocks))
+	for number := range blocks {
+		schedule = append(schedule, number)
+	}
+
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i] < schedule[j] })
+
+	return schedule
+}
+
+// Validate checks that the block-keyed schedules and the fork blocks of the Bor
+// config are well formed and consistent with each other and with the given
+// London block. All the problems found are reported together.
+func (c *BorConfig) Validate(londonBlock *big.Int) error {
+	var errs []error
+
+	report := func(field string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("bor.%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	// Parse the keys of all the schedules, which must be canonical block numbers
+	schedules := map[string][]string{
+		"period":                     mapKeys(c.Period),
+		"producerDelay":              mapKeys(c.ProducerDelay),
+		"sprint":                     mapKeys(c.Sprint),
+		"backupMultiplier":           mapKeys(c.BackupMultiplier),
+		"burntContract":              mapKeys(c.BurntContract),
+		"stateSyncConfirmationDelay": mapKeys(c.StateSyncConfirmationDelay),
+		"overrideStateSyncRecords":   mapKeys(c.OverrideStateSyncRecords),
+		"blockAlloc":                 mapKeys(c.BlockAlloc),
+	}
+
+	blocks := make(map[string][]uint64, len(schedules))
+
+	for _, field := range sortedKeys(schedules) {
+		seen := make(map[uint64]string)
+
+		for _, key := range schedules[field] {
+			number, err := strconv.ParseUint(key, 10, 64)
+			if err != nil {
+				report(field, "malformed key %q, expected a block number", key)
+				continue
+			}
+
+			if other, ok := seen[number]; ok {
+				report(field, "keys %q and %q overlap at block %d", other, key, number)
+				continue
+			}
+
+			if key != strconv.FormatUint(number, 10) {
+				report(field, "malformed key %q, expected %q", key, strconv.FormatUint(number, 10))
+			}
+
+			seen[number] = key
+			blocks[field] = append(blocks[field], number)
+		}
+
+		sort.Slice(blocks[field], func(i, j int) bool { return blocks[field][i] < blocks[field][j] })
+	}
+
+	// The parameters used by the engine from the genesis on must be scheduled
+	// at block 0. Other chains only carry a Bor config for the burnt contract.
+	for _, field := range []string{"period", "producerDelay", "sprint", "backupMultiplier"} {
+		if len(blocks[field]) == 0 && c.ValidatorContract == "" {
+			continue
+		}
+
+		if len(blocks[field]) == 0 || blocks[field][0] != 0 {
+			report(field, "missing entry for block 0")
+		}
+	}
+
+	// Sprint lengths can only change on a sprint boundary of both lengths
+	var prev uint64
+
+	for _, number := range blocks["sprint"] {
+		length := c.Sprint[strconv.FormatUint(number, 10)]
+
+		switch {
+		case length == 0:
+			report("sprint", "zero sprint length at block %d", number)
+		case number%length != 0:
+			report("sprint", "block %d is not a multiple of the new sprint length %d", number, length)
+		case prev != 0 && number%prev != 0:
+			report("sprint", "block %d is not a multiple of the previous sprint length %d", number, prev)
+		}
+
+		prev = length
+	}
+
+	// The burnt contract is needed since London, the state-sync delay since Indore
+	if londonBlock != nil && !scheduledBy(c.BurntContract, londonBlock.Uint64()) {
+		report("burntContract", "missing entry at or before the london block %v", londonBlock)
+	}
+
+	if c.IndoreBlock != nil && !scheduledBy(c.StateSyncConfirmationDelay, c.IndoreBlock.Uint64()) {
+		report("stateSyncConfirmationDelay", "missing entry at or before the indore block %v", c.IndoreBlock)
+	}
+
+	for _, number := range blocks["burntContract"] {
+		if address := c.BurntContract[strconv.FormatUint(number, 10)]; !isAddress(address) {
+			report("burntContract", "invalid address %q at block %d", address, number)
+		}
+	}
+
+	for _, number := range blocks["blockAlloc"] {
+		if _, ok := c.BlockAlloc[strconv.FormatUint(number, 10)].(map[string]interface{}); !ok {
+			report("blockAlloc", "entry at block %d is not an allocation", number)
+		}
+	}
+
+	for field, address := range map[string]string{"validatorContract": c.ValidatorContract, "stateReceiverContract": c.StateReceiverContract} {
+		if address != "" && !isAddress(address) {
+			report(field, "invalid address %q", address)
+		}
+	}
+
+	// The forks must be enabled in order
+	forks := []struct {
+		name  string
+		block *big.Int
+	}{
+		{"jaipurBlock", c.JaipurBlock},
+		{"delhiBlock", c.DelhiBlock},
+		{"indoreBlock", c.IndoreBlock},
+	}
+
+	for i := 1; i < len(forks); i++ {
+		last, cur := forks[i-1], forks[i]
+
+		switch {
+		case last.block == nil && cur.block != nil:
+			report(cur.name, "enabled at block %v, but %s not enabled", cur.block, last.name)
+		case last.block != nil && cur.block != nil && last.block.Cmp(cur.block) > 0:
+			report(cur.name, "enabled at block %v, before %s at block %v", cur.block, last.name, last.block)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isAddress tells if the given string is a hex number fitting in an address,
+// so that it's not truncated when converted to one.
+func isAddress(s string) bool {
+	if !has0xPrefix(s) {
+		return false
+	}
+
+	n, ok := new(big.Int).SetString(s[2:], 16)
+
+	return ok && n.BitLen() <= 8*common.AddressLength
+}
+
+// has0xPrefix tells if the given string starts with 0x or 0X.
+func has0xPrefix(s string) bool {
+	return len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
+
+// scheduledBy tells if the given schedule has an entry at or before the given
+// block. Malformed keys are ignored.
+func scheduledBy[T any](field map[string]T, number uint64) bool {
+	for key := range field {
+		if start, err := strconv.ParseUint(key, 10, 64); err == nil && start <= number {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mapKeys returns the keys of the given schedule.
+func mapKeys[T any](field map[string]T) []string {
+	keys := make([]string, 0, len(field))
+	for key := range field {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortedKeys returns the sorted keys of the given map.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// CheckBorConfig validates the Bor config of the chain, if any.
+func (c *ChainConfig) CheckBorConfig() error {
+	if c.Bor == nil {
+		return nil
+	}
+
+	return c.Bor.Validate(c.LondonBlock)
+}
@@ -0,0 +1,146 @@
+package params
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func newTestBorConfig() *BorConfig {
+	return &BorConfig{
+		Period:                     map[string]uint64{"0": 2},
+		ProducerDelay:              map[string]uint64{"0": 6, "128": 4},
+		Sprint:                     map[string]uint64{"0": 64, "128": 16},
+		BackupMultiplier:           map[string]uint64{"0": 2},
+		ValidatorContract:          "0x0000000000000000000000000000000000001000",
+		StateReceiverContract:      "0x0000000000000000000000000000000000001001",
+		BurntContract:              map[string]string{"0": "0x000000000000000000000000000000000000dead"},
+		StateSyncConfirmationDelay: map[string]uint64{"256": 128},
+		BlockAlloc:                 map[string]interface{}{"64": map[string]interface{}{}},
+		JaipurBlock:                big.NewInt(64),
+		DelhiBlock:                 big.NewInt(128),
+		IndoreBlock:                big.NewInt(256),
+	}
+}
+
+func TestBorConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, newTestBorConfig().Validate(big.NewInt(0)))
+
+	for _, tt := range []struct {
+		name   string
+		modify func(c *BorConfig)
+		errs   []string
+	}{
+		{
+			name:   "malformed key",
+			modify: func(c *BorConfig) { c.Period["ten"] = 1 },
+			errs:   []string{`bor.period: malformed key "ten"`},
+		},
+		{
+			name:   "overlapping keys",
+			modify: func(c *BorConfig) { c.BackupMultiplier["00"] = 5 },
+			errs:   []string{`bor.backupMultiplier: keys "0" and "00" overlap at block 0`},
+		},
+		{
+			name:   "missing genesis entry",
+			modify: func(c *BorConfig) { c.ProducerDelay = map[string]uint64{"128": 4} },
+			errs:   []string{"bor.producerDelay: missing entry for block 0"},
+		},
+		{
+			name:   "misaligned sprint",
+			modify: func(c *BorConfig) { c.Sprint["200"] = 8 },
+			errs:   []string{"bor.sprint: block 200 is not a multiple of the previous sprint length 16"},
+		},
+		{
+			name:   "missing burnt contract",
+			modify: func(c *BorConfig) { c.BurntContract = map[string]string{"64": "0x000000000000000000000000000000000000dead"} },
+			errs:   []string{"bor.burntContract: missing entry at or before the london block 0"},
+		},
+		{
+			name:   "missing state-sync delay",
+			modify: func(c *BorConfig) { c.IndoreBlock = big.NewInt(192) },
+			errs:   []string{"bor.stateSyncConfirmationDelay: missing entry at or before the indore block 192"},
+		},
+		{
+			name:   "fork order",
+			modify: func(c *BorConfig) { c.JaipurBlock = nil },
+			errs:   []string{"bor.delhiBlock: enabled at block 128, but jaipurBlock not enabled"},
+		},
+		{
+			name: "all problems",
+			modify: func(c *BorConfig) {
+				c.BurntContract["0"] = "dead"
+				c.DelhiBlock = big.NewInt(32)
+			},
+			errs: []string{
+				`bor.burntContract: invalid address "dead" at block 0`,
+				"bor.delhiBlock: enabled at block 32, before jaipurBlock at block 64",
+			},
+		},
+	} {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := newTestBorConfig()
+			tt.modify(c)
+
+			err := c.Validate(big.NewInt(0))
+			require.Error(t, err)
+			require.Len(t, strings.Split(err.Error(), "\n"), len(tt.errs))
+
+			for _, msg := range tt.errs {
+				require.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
+
+func TestBorConfigValidateNonBorChain(t *testing.T) {
+	t.Parallel()
+
+	// The configs of the other engines only carry the burnt contract
+	require.NoError(t, TestChainConfig.CheckBorConfig())
+	require.NoError(t, AllEthashProtocolChanges.CheckBorConfig())
+	require.NoError(t, BorMainnetChainConfig.CheckBorConfig())
+	require.NoError(t, MumbaiChainConfig.CheckBorConfig())
+}
+
+func TestBorConfigParamsAt(t *testing.T) {
+	t.Parallel()
+
+	c := newTestBorConfig()
+
+	require.Equal(t, []uint64{0, 64, 128, 256}, c.Schedule())
+
+	require.Equal(t, &BorParams{
+		Number:           64,
+		Period:           2,
+		ProducerDelay:    6,
+		Sprint:           64,
+		BackupMultiplier: 2,
+		BurntContract:    common.HexToAddress("0xdead"),
+		Jaipur:           true,
+		BlockAlloc:       true,
+	}, c.ParamsAt(64))
+
+	require.Equal(t, &BorParams{
+		Number:           300,
+		Period:           2,
+		ProducerDelay:    4,
+		Sprint:           16,
+		BackupMultiplier: 2,
+		BurntContract:    common.HexToAddress("0xdead"),
+		StateSyncDelay:   128,
+		Jaipur:           true,
+		Delhi:            true,
+		Indore:           true,
+	}, c.ParamsAt(300))
+}
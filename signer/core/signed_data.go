@@ -72,9 +72,19 @@ func (api *SignerAPI) sign(req *SignDataRequest, legacyV bool) (hexutil.Bytes, e
 		signature[64] += 27 // Transform V from 0/1 to 27/28 according to the yellow paper
 	}
 
+	if observer, ok := api.UI.(SignedDataObserver); ok {
+		observer.OnSignedData(req, signature)
+	}
+
 	return signature, nil
 }
 
+// SignedDataObserver is implemented by the UIs tracking the data they approved
+// once it is signed.
+type SignedDataObserver interface {
+	OnSignedData(request *SignDataRequest, signature hexutil.Bytes)
+}
+
 // SignData signs the hash of the provided data, but does so differently
 // depending on the content-type specified.
 //
@@ -175,6 +185,30 @@ func (api *SignerAPI) determineSignatureFormat(ctx context.Context, contentType
 		// Clique uses V on the form 0 or 1
 		useEthereumV = false
 		req = &SignDataRequest{ContentType: mediaType, Rawdata: cliqueRlp, Messages: messages, Hash: sighash}
+	case apitypes.ApplicationBor.Mime:
+		// Bor seals the header without the signature, as encoded by the producer
+		borRlp, err := fromHex(data)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+
+		header, err := DecodeBorHeader(borRlp)
+		if err != nil {
+			return nil, useEthereumV, err
+		}
+
+		sighash := crypto.Keccak256(borRlp)
+		messages := []*apitypes.NameValueType{
+			{
+				Name:  "Bor header",
+				Typ:   "bor",
+				Value: fmt.Sprintf("bor header %d [%#x]", header.Number, sighash),
+			},
+		}
+		// Bor uses V on the form 0 or 1. The content type keeps the parameters of
+		// the producer, such as its succession number.
+		useEthereumV = false
+		req = &SignDataRequest{ContentType: contentType, Rawdata: borRlp, Messages: messages, Hash: sighash}
 	case apitypes.DataTyped.Mime:
 		// EIP-712 conformant typed data
 		var err error
@@ -236,6 +270,17 @@ func cliqueHeaderHashAndRlp(header *types.Header) (hash, rlp []byte, err error)
 	return hash, rlp, err
 }
 
+// DecodeBorHeader decodes the header signed by a Bor block producer, the
+// header without the 65 bytes signature at the end of its extra data.
+func DecodeBorHeader(borRlp []byte) (*types.Header, error) {
+	header := new(types.Header)
+	if err := rlp.DecodeBytes(borRlp, header); err != nil {
+		return nil, fmt.Errorf("invalid bor header: %w", err)
+	}
+
+	return header, nil
+}
+
 // SignTypedData signs EIP-712 conformant typed data
 // hash = keccak256("\x19${byteVersion}${domainSeparator}${hashStruct(message)}")
 // It returns
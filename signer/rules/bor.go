@@ -0,0 +1,208 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+const (
+	// borMaxHeaderAge is the age past which a header is not sealed anymore, the
+	// producers sign the headers before their timestamp.
+	borMaxHeaderAge = time.Minute
+
+	// borMaxHeaderLead is the time a header may be sealed before its timestamp,
+	// covering the producer delay and the wiggle of the backup producers.
+	borMaxHeaderLead = 10 * time.Minute
+)
+
+var (
+	// errBorOnly is returned for any request other than sealing a Bor header.
+	errBorOnly = errors.New("only bor headers are sealed")
+
+	// errBorStaleHeader is returned when sealing a header too far from the current time.
+	errBorStaleHeader = errors.New("bor header not for the current height")
+)
+
+// borSeal is a block signed by a producer, not known to be released yet.
+type borSeal struct {
+	number   uint64
+	sealHash common.Hash // Hash of the header without the signature
+	hash     common.Hash // Hash of the signed block
+	round    uint64      // Succession number of the producer at the block
+}
+
+// borSealingUI provides an implementation of UIClientAPI approving the sealing
+// of the Bor headers of the current height only, never signing a block
+// conflicting with one already released. Any other request is rejected.
+//
+// A producer may sign several blocks at a height and release none of them, when
+// the sealing is aborted before its slot or when the block is rebuilt with new
+// transactions. A signed block is only known to be released once the producer
+// seals a block on top of it, it is recorded then.
+type borSealingUI struct {
+	next  core.UIClientAPI // The next handler, for the notifications
+	store *protection.Store
+	now   func() time.Time
+
+	sealed map[common.Address][]*borSeal // Blocks signed and not known to be released, by producer
+	lock   sync.Mutex
+}
+
+// NewBorSealingUI creates a handler sealing the Bor headers, recording the
+// released blocks in the given store.
+func NewBorSealingUI(next core.UIClientAPI, store *protection.Store) *borSealingUI {
+	return &borSealingUI{
+		next:   next,
+		store:  store,
+		now:    time.Now,
+		sealed: make(map[common.Address][]*borSeal),
+	}
+}
+
+func (b *borSealingUI) RegisterUIServer(api *core.UIServerAPI) {
+	b.next.RegisterUIServer(api)
+}
+
+func (b *borSealingUI) ApproveTx(request *core.SignTxRequest) (core.SignTxResponse, error) {
+	log.Warn("Rejected transaction signing", "from", request.Transaction.From)
+	return core.SignTxResponse{Approved: false}, errBorOnly
+}
+
+func (b *borSealingUI) ApproveSignData(request *core.SignDataRequest) (core.SignDataResponse, error) {
+	if err := b.checkSeal(request); err != nil {
+		log.Warn("Rejected data signing", "address", request.Address.Address(), "type", request.ContentType, "err", err)
+		return core.SignDataResponse{Approved: false}, err
+	}
+
+	return core.SignDataResponse{Approved: true}, nil
+}
+
+// checkSeal checks that the request seals a Bor header of the current height,
+// not conflicting with a released block. The parent of the header is recorded
+// as released if it was signed here.
+func (b *borSealingUI) checkSeal(request *core.SignDataRequest) error {
+	if mediaType, _, err := mime.ParseMediaType(request.ContentType); err != nil || mediaType != accounts.MimetypeBor {
+		return errBorOnly
+	}
+
+	header, err := core.DecodeBorHeader(request.Rawdata)
+	if err != nil {
+		return err
+	}
+
+	now := b.now()
+	timestamp := time.Unix(int64(header.Time), 0)
+
+	if timestamp.Before(now.Add(-borMaxHeaderAge)) || timestamp.After(now.Add(borMaxHeaderLead)) {
+		return fmt.Errorf("%w: block %d at %v", errBorStaleHeader, header.Number, timestamp)
+	}
+
+	var (
+		signer   = request.Address.Address()
+		number   = header.Number.Uint64()
+		sealHash = common.BytesToHash(crypto.Keccak256(request.Rawdata))
+	)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// The blocks signed below the header can't be released anymore, but for the
+	// parent the header is built on
+	var pending []*borSeal
+
+	for _, seal := range b.sealed[signer] {
+		switch {
+		case seal.number+1 == number && seal.hash == header.ParentHash:
+			if err := b.store.Record(signer, seal.number, seal.sealHash, seal.round); err != nil {
+				return err
+			}
+
+			log.Info("Recorded released bor block", "address", signer, "number", seal.number, "hash", seal.hash, "round", seal.round)
+		case seal.number >= number:
+			pending = append(pending, seal)
+		}
+	}
+
+	b.sealed[signer] = pending
+
+	if err := b.store.Check(signer, number, sealHash); err != nil {
+		return err
+	}
+
+	log.Info("Sealing bor header", "address", signer, "number", header.Number, "sealhash", sealHash)
+
+	return nil
+}
+
+// OnSignedData keeps track of the signed block, until it is known to be released.
+func (b *borSealingUI) OnSignedData(request *core.SignDataRequest, signature hexutil.Bytes) {
+	_, params, err := mime.ParseMediaType(request.ContentType)
+	if err != nil {
+		return
+	}
+
+	header, err := core.DecodeBorHeader(request.Rawdata)
+	if err != nil {
+		return
+	}
+
+	// Producers not passing their succession number are recorded in turn
+	round, _ := strconv.ParseUint(params[accounts.BorSuccessionParam], 10, 64)
+
+	seal := &borSeal{
+		number:   header.Number.Uint64(),
+		sealHash: common.BytesToHash(crypto.Keccak256(request.Rawdata)),
+		round:    round,
+	}
+
+	header.Extra = append(header.Extra, signature...)
+	seal.hash = header.Hash()
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.sealed[request.Address.Address()] = append(b.sealed[request.Address.Address()], seal)
+}
+
+// OnInputRequired not handled by the sealing rules
+func (b *borSealingUI) OnInputRequired(info core.UserInputRequest) (core.UserInputResponse, error) {
+	return b.next.OnInputRequired(info)
+}
+
+// ApproveListing lists the accounts, for the producer to find its signing key
+func (b *borSealingUI) ApproveListing(request *core.ListRequest) (core.ListResponse, error) {
+	return core.ListResponse{Accounts: request.Accounts}, nil
+}
+
+func (b *borSealingUI) ApproveNewAccount(request *core.NewAccountRequest) (core.NewAccountResponse, error) {
+	return core.NewAccountResponse{Approved: false}, errBorOnly
+}
+
+func (b *borSealingUI) ShowError(message string) {
+	b.next.ShowError(message)
+}
+
+func (b *borSealingUI) ShowInfo(message string) {
+	b.next.ShowInfo(message)
+}
+
+func (b *borSealingUI) OnSignerStartup(info core.StartupInfo) {
+	b.next.OnSignerStartup(info)
+}
+
+func (b *borSealingUI) OnApprovedTx(tx ethapi.SignTransactionResult) {
+	b.next.OnApprovedTx(tx)
+}
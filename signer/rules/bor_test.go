@@ -0,0 +1,123 @@
+package rules
+
+import (
+	"context"
+	"math/big"
+	"mime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/bor"
+	"github.com/ethereum/go-ethereum/consensus/bor/protection"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/ethereum/go-ethereum/signer/storage"
+)
+
+func newBorTestHeader(number uint64, timestamp time.Time) *types.Header {
+	return &types.Header{
+		ParentHash: common.HexToHash("0x01"),
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: big.NewInt(1),
+		GasLimit:   30_000_000,
+		Time:       uint64(timestamp.Unix()),
+		Extra:      make([]byte, types.ExtraVanityLength+types.ExtraSealLength),
+	}
+}
+
+func TestBorSealing(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, "password")
+	require.NoError(t, err)
+
+	pwStorage := storage.NewEphemeralStorage()
+	pwStorage.Put(account.Address.Hex(), "password")
+
+	store, err := protection.Open("")
+	require.NoError(t, err)
+
+	next := &dummyUI{}
+	ui := NewBorSealingUI(next, store)
+	am := core.StartClefAccountManager(dir, true, true, "")
+	api := core.NewSignerAPI(am, 1337, true, ui, nil, false, pwStorage)
+
+	addr := common.NewMixedcaseAddress(account.Address)
+	config := params.TestChainConfig.Bor
+
+	seal := func(header *types.Header) ([]byte, error) {
+		mimeType := mime.FormatMediaType(accounts.MimetypeBor, map[string]string{accounts.BorSuccessionParam: "2"})
+		return api.SignData(context.Background(), mimeType, addr, hexutil.Encode(bor.BorRLP(header, config)))
+	}
+
+	// The producer recovers its own address from the seal
+	header := newBorTestHeader(10, time.Now().Add(2*time.Second))
+	signature, err := seal(header)
+	require.NoError(t, err)
+
+	pubkey, err := crypto.Ecrecover(bor.SealHash(header, config).Bytes(), signature)
+	require.NoError(t, err)
+	require.Equal(t, account.Address, common.BytesToAddress(crypto.Keccak256(pubkey[1:])[12:]))
+
+	// The same header may be sealed again, and a conflicting one as long as none
+	// was released
+	_, err = seal(header)
+	require.NoError(t, err)
+
+	conflicting := newBorTestHeader(10, time.Now().Add(4*time.Second))
+	_, err = seal(conflicting)
+	require.NoError(t, err)
+
+	_, ok := store.Last(account.Address)
+	require.False(t, ok)
+
+	// Sealing the next header on top of the first one releases it
+	header.Extra = append(header.Extra[:types.ExtraVanityLength], signature...)
+	child := newBorTestHeader(11, time.Now().Add(4*time.Second))
+	child.ParentHash = header.Hash()
+
+	_, err = seal(child)
+	require.NoError(t, err)
+
+	_, err = seal(conflicting)
+	require.ErrorIs(t, err, protection.ErrConflict)
+
+	_, err = seal(newBorTestHeader(9, time.Now()))
+	require.ErrorIs(t, err, protection.ErrRegression)
+
+	// Only the headers of the current height are sealed
+	_, err = seal(newBorTestHeader(11, time.Now().Add(-time.Hour)))
+	require.ErrorIs(t, err, errBorStaleHeader)
+
+	_, err = seal(newBorTestHeader(11, time.Now().Add(time.Hour)))
+	require.ErrorIs(t, err, errBorStaleHeader)
+
+	// Any other request is rejected
+	_, err = api.SignData(context.Background(), accounts.MimetypeTextPlain, addr, hexutil.Encode([]byte("hello")))
+	require.ErrorIs(t, err, errBorOnly)
+
+	_, err = api.SignData(context.Background(), accounts.MimetypeClique, addr, hexutil.Encode(bor.BorRLP(header, config)))
+	require.ErrorIs(t, err, errBorOnly)
+
+	// The sealing is never left to the next handler
+	require.NotContains(t, next.calls, "ApproveSignData")
+
+	last, ok := store.Last(account.Address)
+	require.True(t, ok)
+	require.Equal(t, uint64(10), last.Number)
+	require.Equal(t, bor.SealHash(header, config), last.Hash)
+	require.Equal(t, uint64(2), last.Round)
+}
@@ -31,7 +31,7 @@ import (
 type Config struct {
 	Cache     int            // Memory allowance (MB) to use for caching trie nodes in memory
 	Preimages bool           // Flag whether the preimage of trie key is recorded
-	PathDB    *pathdb.Config // Configs for experimental path-based scheme, not used yet.
+	PathDB    *pathdb.Config // Configs for experimental path-based scheme, hash-based scheme is used if nil
 
 	// Testing hooks
 	OnCommit func(states *triestate.Set) // Hook invoked when commit is performed
@@ -98,14 +98,18 @@ func NewDatabase(diskdb ethdb.Database) *Database {
 }
 
 // NewDatabaseWithConfig initializes the trie database with provided configs.
-// The path-based scheme is not activated yet, always initialized with legacy
-// hash-based scheme by default.
+// The path-based scheme is only activated if its config is provided, otherwise
+// the legacy hash-based scheme is used by default.
 func NewDatabaseWithConfig(diskdb ethdb.Database, config *Config) *Database {
+	db := prepare(diskdb, config)
+	if config != nil && config.PathDB != nil {
+		db.backend = pathdb.New(diskdb, config.PathDB)
+		return db
+	}
 	var cleans int
 	if config != nil && config.Cache != 0 {
 		cleans = config.Cache * 1024 * 1024
 	}
-	db := prepare(diskdb, config)
 	db.backend = hashdb.New(diskdb, cleans, mptResolver{})
 	return db
 }
@@ -240,3 +244,15 @@ func (db *Database) Node(hash common.Hash) ([]byte, error) {
 	}
 	return hdb.Node(hash)
 }
+
+// Journal commits an entire diff hierarchy to disk into a single journal entry.
+// This is meant to be used during shutdown to persist the in-memory layers
+// without flattening everything down. It's only supported by path-based
+// database and will return an error for others.
+func (db *Database) Journal(root common.Hash) error {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return errors.New("not supported")
+	}
+	return pdb.Journal(root)
+}